@@ -0,0 +1,252 @@
+package cani
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/organization"
+)
+
+type resource struct {
+	Name       string
+	Group      string
+	Namespaced bool
+}
+
+// knownResources are the Giant Swarm resources checked with --matrix.
+var knownResources = []resource{
+	{Name: "releases", Group: "release.giantswarm.io"},
+	{Name: "clusters", Group: "cluster.x-k8s.io", Namespaced: true},
+	{Name: "machinedeployments", Group: "cluster.x-k8s.io", Namespaced: true},
+	{Name: "machinepools", Group: "cluster.x-k8s.io", Namespaced: true},
+	{Name: "apps", Group: "application.giantswarm.io", Namespaced: true},
+	{Name: "catalogs", Group: "application.giantswarm.io", Namespaced: true},
+}
+
+var matrixVerbs = []string{"get", "create", "update", "delete"}
+
+// ruleWildcard matches any verb, API group or resource in a rule.
+const ruleWildcard = "*"
+
+// access tells which verbs are allowed on a resource in the namespace of
+// an organization. Organization and namespace are empty for resources which
+// are not namespaced.
+type access struct {
+	Organization string          `json:"organization,omitempty"`
+	Namespace    string          `json:"namespace,omitempty"`
+	Resource     string          `json:"resource"`
+	Group        string          `json:"group,omitempty"`
+	Allowed      map[string]bool `json:"allowed"`
+}
+
+type organizationNamespace struct {
+	Organization string
+	Namespace    string
+}
+
+// getAccess checks the verbs on the resources in the namespaces of the
+// organizations. The access in the namespace of an organization is taken
+// from a single SelfSubjectRulesReview, so the number of requests does not
+// grow with the number of resources and verbs.
+func (r *runner) getAccess(ctx context.Context, orgs []organizationNamespace, resources []resource, verbs []string) ([]access, error) {
+	var result []access
+	for _, res := range resources {
+		if !res.Namespaced {
+			a, err := r.checkAccess(ctx, organizationNamespace{}, res, verbs)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+			result = append(result, a)
+		}
+	}
+
+	for _, org := range orgs {
+		rules, err := r.getResourceRules(ctx, org.Namespace)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, res := range resources {
+			if !res.Namespaced {
+				continue
+			}
+
+			var a access
+			if rules != nil {
+				a = newAccessFromRules(org, res, verbs, rules)
+			} else {
+				a, err = r.checkAccess(ctx, org, res, verbs)
+				if err != nil {
+					return nil, microerror.Mask(err)
+				}
+			}
+			result = append(result, a)
+		}
+	}
+
+	return result, nil
+}
+
+// getResourceRules returns the rules of the user in the namespace. It
+// returns nil if the rules are incomplete, e.g. because an authorizer
+// cannot list them, in which case each verb must be checked separately.
+func (r *runner) getResourceRules(ctx context.Context, namespace string) ([]authorizationv1.ResourceRule, error) {
+	review := &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{
+			Namespace: namespace,
+		},
+	}
+
+	review, err := r.client.K8sClient().AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if review.Status.Incomplete {
+		return nil, nil
+	}
+
+	return review.Status.ResourceRules, nil
+}
+
+func (r *runner) checkAccess(ctx context.Context, org organizationNamespace, res resource, verbs []string) (access, error) {
+	a := newAccess(org, res)
+
+	for _, verb := range verbs {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: org.Namespace,
+					Verb:      verb,
+					Group:     res.Group,
+					Resource:  res.Name,
+				},
+			},
+		}
+
+		review, err := r.client.K8sClient().AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return access{}, microerror.Mask(err)
+		}
+		a.Allowed[verb] = review.Status.Allowed
+	}
+
+	return a, nil
+}
+
+func newAccess(org organizationNamespace, res resource) access {
+	return access{
+		Organization: org.Organization,
+		Namespace:    org.Namespace,
+		Resource:     res.Name,
+		Group:        res.Group,
+		Allowed:      map[string]bool{},
+	}
+}
+
+func newAccessFromRules(org organizationNamespace, res resource, verbs []string, rules []authorizationv1.ResourceRule) access {
+	a := newAccess(org, res)
+
+	for _, verb := range verbs {
+		a.Allowed[verb] = false
+		for _, rule := range rules {
+			if ruleAllows(rule, res, verb) {
+				a.Allowed[verb] = true
+				break
+			}
+		}
+	}
+
+	return a
+}
+
+// ruleAllows tells whether the rule allows the verb on all resources of
+// the kind. Rules limited to resources with certain names do not.
+func ruleAllows(rule authorizationv1.ResourceRule, res resource, verb string) bool {
+	return len(rule.ResourceNames) == 0 &&
+		matchesRule(rule.Verbs, verb) &&
+		matchesRule(rule.APIGroups, res.Group) &&
+		matchesRule(rule.Resources, res.Name)
+}
+
+func matchesRule(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == ruleWildcard {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getOrganizations returns the organizations to check, sorted by name.
+func (r *runner) getOrganizations(ctx context.Context) ([]organizationNamespace, error) {
+	var orgs []organizationNamespace
+
+	if len(r.flag.Organizations) > 0 {
+		for _, name := range r.flag.Organizations {
+			org, err := r.organizationService.Get(ctx, organization.GetOptions{Name: name})
+			if organization.IsNotFound(err) {
+				return nil, microerror.Maskf(notFoundError, "An organization '%s' cannot be found.\n", name)
+			} else if err != nil {
+				return nil, microerror.Mask(err)
+			}
+
+			namespace := org.(*organization.Organization).Organization.Status.Namespace
+			if namespace == "" {
+				return nil, microerror.Maskf(notFoundError, "The namespace of organization '%s' is not known yet.\n", name)
+			}
+
+			orgs = append(orgs, organizationNamespace{Organization: name, Namespace: namespace})
+		}
+	} else {
+		resource, err := r.organizationService.Get(ctx, organization.GetOptions{})
+		if organization.IsNoResources(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, org := range resource.(*organization.Collection).Items {
+			if org.Organization.Status.Namespace == "" {
+				continue
+			}
+
+			orgs = append(orgs, organizationNamespace{
+				Organization: org.Organization.Name,
+				Namespace:    org.Organization.Status.Namespace,
+			})
+		}
+	}
+
+	sort.Slice(orgs, func(i, j int) bool {
+		return orgs[i].Organization < orgs[j].Organization
+	})
+
+	return orgs, nil
+}
+
+// parseResource returns the resource given as 'resource' or
+// 'resource.group'. Resources other than the known ones are assumed to be
+// namespaced.
+func parseResource(arg string) resource {
+	arg = strings.ToLower(arg)
+
+	for _, res := range knownResources {
+		if arg == res.Name || arg == res.Name+"."+res.Group {
+			return res
+		}
+	}
+
+	name, group, _ := strings.Cut(arg, ".")
+
+	return resource{
+		Name:       name,
+		Group:      group,
+		Namespaced: true,
+	}
+}
@@ -0,0 +1,107 @@
+package cani
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
+)
+
+const (
+	name = "can-i [verb] [resource]"
+
+	shortDescription = "Check what you are allowed to do in the organizations"
+	longDescription  = `Check what you are allowed to do in the organizations
+
+Checks whether you are allowed to perform an action on a resource in the
+namespace of each organization you have access to, using a
+SelfSubjectRulesReview per organization namespace. Resources which are not
+namespaced, and namespaces whose rules are incomplete, are checked with a
+SelfSubjectAccessReview per verb.
+
+The resource can be given as 'resource' or 'resource.group'. These
+Giant Swarm resources are known by name:
+
+- clusters (cluster.x-k8s.io)
+- machinedeployments (cluster.x-k8s.io), node pools
+- machinepools (cluster.x-k8s.io), node pools
+- apps (application.giantswarm.io)
+- catalogs (application.giantswarm.io)
+- releases (release.giantswarm.io), which are not namespaced
+
+With --matrix, all of these resources are checked for the get, create,
+update and delete verbs at once.
+
+Use --organization to only check the given organizations.`
+
+	examples = `  # Check whether you can create apps in each organization
+  kubectl gs auth can-i create apps
+
+  # Check whether you can delete clusters in the acme organization
+  kubectl gs auth can-i delete clusters --organization acme
+
+  # Show everything you can do in all organizations
+  kubectl gs auth can-i --matrix`
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	ConfigFlags *genericclioptions.RESTClientGetter
+
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.ConfigFlags == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ConfigFlags must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		commonConfig: &commonconfig.CommonConfig{
+			ConfigFlags: config.ConfigFlags,
+		},
+		flag:   f,
+		logger: config.Logger,
+
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     name,
+		Short:   shortDescription,
+		Long:    longDescription,
+		Example: examples,
+		Args:    cobra.MaximumNArgs(2),
+		RunE:    r.Run,
+		PreRunE: middleware.Compose(
+			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
+		),
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
@@ -0,0 +1,42 @@
+package cani
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+)
+
+const (
+	flagMatrix       = "matrix"
+	flagOrganization = "organization"
+)
+
+type flag struct {
+	Matrix        bool
+	Organizations []string
+
+	print *genericclioptions.PrintFlags
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.Matrix, flagMatrix, false, "Check the get, create, update and delete verbs for all known Giant Swarm resources.")
+	cmd.Flags().StringSliceVar(&f.Organizations, flagOrganization, nil, "Only check the given organizations. Can be given multiple times or as a comma separated list.")
+
+	f.print = genericclioptions.NewPrintFlags("")
+
+	// Merging current command flags and config flags,
+	// to be able to override kubectl-specific ones.
+	f.print.AddFlags(cmd)
+}
+
+func (f *flag) Validate() error {
+	switch *f.print.OutputFormat {
+	case output.TypeDefault, output.TypeJSON, output.TypeYAML:
+	default:
+		return microerror.Maskf(invalidFlagError, "--output must be %q or %q", output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
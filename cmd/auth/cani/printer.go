@@ -0,0 +1,112 @@
+package cani
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+)
+
+const (
+	naValue = "n/a"
+)
+
+func (r *runner) printOutput(result []access, verbs []string) error {
+	if result == nil {
+		result = []access{}
+	}
+
+	switch *r.flag.print.OutputFormat {
+	case output.TypeJSON:
+		data, err := json.MarshalIndent(result, "", "    ")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprintln(r.stdout, string(data))
+
+	case output.TypeYAML:
+		data, err := yaml.Marshal(result)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprint(r.stdout, string(data))
+
+	case output.TypeDefault:
+		if len(result) == 0 {
+			fmt.Fprintf(r.stdout, "No organizations found.\n")
+			return nil
+		}
+
+		printer := printers.NewTablePrinter(printers.PrintOptions{})
+		err := printer.PrintObj(getTable(result, verbs), r.stdout)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+	default:
+		return microerror.Maskf(invalidFlagError, "output format %q is not supported, use %q or %q", *r.flag.print.OutputFormat, output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
+
+func getTable(result []access, verbs []string) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Organization", Type: "string"},
+			{Name: "Namespace", Type: "string"},
+			{Name: "Resource", Type: "string"},
+		},
+	}
+	for _, verb := range verbs {
+		table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{
+			Name: strings.ToUpper(verb[:1]) + verb[1:],
+			Type: "string",
+		})
+	}
+
+	for _, a := range result {
+		cells := []interface{}{
+			valueOrNA(a.Organization),
+			valueOrNA(a.Namespace),
+			formatResource(a.Resource, a.Group),
+		}
+		for _, verb := range verbs {
+			cells = append(cells, formatAllowed(a.Allowed[verb]))
+		}
+
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: cells})
+	}
+
+	return table
+}
+
+func formatResource(name, group string) string {
+	if group == "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s.%s", name, group)
+}
+
+func formatAllowed(allowed bool) string {
+	if allowed {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return naValue
+	}
+
+	return value
+}
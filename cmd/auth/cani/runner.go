@@ -0,0 +1,108 @@
+package cani
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/organization"
+)
+
+type runner struct {
+	commonConfig *commonconfig.CommonConfig
+	flag         *flag
+	logger       micrologger.Logger
+
+	// client is used for the SelfSubjectRulesReviews and
+	// SelfSubjectAccessReviews.
+	client              k8sclient.Interface
+	organizationService organization.Interface
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	var resources []resource
+	var verbs []string
+	{
+		switch {
+		case r.flag.Matrix && len(args) > 0:
+			return microerror.Maskf(invalidFlagError, "--%s cannot be combined with a verb and resource", flagMatrix)
+		case r.flag.Matrix:
+			resources = knownResources
+			verbs = matrixVerbs
+		case len(args) != 2 || args[0] == "" || args[1] == "":
+			return microerror.Maskf(invalidFlagError, "a verb and resource must be given, or --%s", flagMatrix)
+		default:
+			resources = []resource{parseResource(args[1])}
+			verbs = []string{strings.ToLower(args[0])}
+		}
+	}
+
+	err := r.getServices()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	orgs, err := r.getOrganizations(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	result, err := r.getAccess(ctx, orgs, resources, verbs)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.printOutput(result, verbs)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) getServices() error {
+	var err error
+
+	if r.client == nil {
+		r.client, err = r.commonConfig.GetClient(r.logger)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if r.organizationService == nil {
+		r.organizationService, err = organization.New(organization.Config{
+			Client: r.client,
+		})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package cani
+
+import (
+	"bytes"
+	"context"
+	goflag "flag"
+	"testing"
+
+	securityv1alpha1 "github.com/giantswarm/organization-operator/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/organization"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/test/goldenfile"
+	"github.com/giantswarm/kubectl-gs/v5/test/kubeclient"
+)
+
+var update = goflag.Bool("update", false, "update .golden reference test files")
+
+// Test_run uses golden files.
+//
+// go test ./cmd/auth/cani -run Test_run -update
+func Test_run(t *testing.T) {
+	orgs := []runtime.Object{
+		newOrganization("acme", "org-acme"),
+		newOrganization("beta", "org-beta"),
+		newOrganization("gamma", ""),
+	}
+
+	testCases := []struct {
+		name               string
+		args               []string
+		flags              flag
+		incompleteRules    bool
+		outputType         string
+		expectedGoldenFile string
+		errorMatcher       func(error) bool
+	}{
+		{
+			name:               "case 0: permission matrix of all organizations",
+			flags:              flag{Matrix: true},
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_can_i_matrix.golden",
+		},
+		{
+			name:               "case 1: one verb and resource",
+			args:               []string{"create", "apps"},
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_can_i_create_apps.golden",
+		},
+		{
+			name:               "case 2: one verb and resource with group, in one organization",
+			args:               []string{"delete", "clusters.cluster.x-k8s.io"},
+			flags:              flag{Organizations: []string{"acme"}},
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_can_i_delete_clusters_acme.golden",
+		},
+		{
+			name:               "case 3: resource which is not namespaced, with json output",
+			args:               []string{"get", "releases"},
+			outputType:         output.TypeJSON,
+			expectedGoldenFile: "run_can_i_get_releases_json_output.golden",
+		},
+		{
+			name:               "case 4: permission matrix, with incomplete rules",
+			flags:              flag{Matrix: true},
+			incompleteRules:    true,
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_can_i_matrix.golden",
+		},
+		{
+			name:         "case 5: organization which does not exist",
+			args:         []string{"get", "apps"},
+			flags:        flag{Organizations: []string{"delta"}},
+			outputType:   output.TypeDefault,
+			errorMatcher: IsNotFound,
+		},
+		{
+			name:         "case 6: matrix combined with a verb and resource",
+			args:         []string{"get", "apps"},
+			flags:        flag{Matrix: true},
+			outputType:   output.TypeDefault,
+			errorMatcher: IsInvalidFlag,
+		},
+		{
+			name:         "case 7: no verb and resource",
+			outputType:   output.TypeDefault,
+			errorMatcher: IsInvalidFlag,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			flag := &tc.flags
+			flag.print = genericclioptions.NewPrintFlags("").WithDefaultOutput(tc.outputType)
+
+			client := kubeclient.FakeK8sClient(orgs...)
+			client.AddSubjectAccessResolver(resolveAccess)
+			if tc.incompleteRules {
+				client.AddIncompleteSubjectResourceRules("org-acme")
+				client.AddIncompleteSubjectResourceRules("org-beta")
+			} else {
+				client.AddSubjectResourceRules("org-acme", acmeRules)
+				client.AddSubjectResourceRules("org-beta", betaRules)
+			}
+
+			organizationService, err := organization.New(organization.Config{Client: client})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			out := new(bytes.Buffer)
+			runner := &runner{
+				flag:                flag,
+				client:              client,
+				organizationService: organizationService,
+				stdout:              out,
+			}
+
+			err = runner.run(context.TODO(), nil, tc.args)
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			// With complete rules, the access in the namespaces of the
+			// organizations is not checked verb by verb.
+			if !tc.incompleteRules {
+				for _, action := range client.K8sClient().(*fakek8s.Clientset).Actions() {
+					review, ok := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+					if ok && review.Spec.ResourceAttributes.Namespace != "" {
+						t.Fatalf("unexpected access review in namespace %#q", review.Spec.ResourceAttributes.Namespace)
+					}
+				}
+			}
+
+			var expectedResult []byte
+			{
+				gf := goldenfile.New("testdata", tc.expectedGoldenFile)
+				if *update {
+					err = gf.Update(out.Bytes())
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+					expectedResult = out.Bytes()
+				} else {
+					expectedResult, err = gf.Read()
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+				}
+			}
+
+			diff := cmp.Diff(string(expectedResult), out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+// acmeRules and betaRules grant the same access as resolveAccess.
+var (
+	acmeRules = []authorizationv1.ResourceRule{
+		{Verbs: []string{"*"}, APIGroups: []string{"application.giantswarm.io"}, Resources: []string{"*"}},
+		{Verbs: []string{"get", "create", "update"}, APIGroups: []string{"cluster.x-k8s.io"}, Resources: []string{"clusters"}},
+		{Verbs: []string{"*"}, APIGroups: []string{"cluster.x-k8s.io"}, Resources: []string{"machinedeployments", "machinepools"}},
+		{Verbs: []string{"delete"}, APIGroups: []string{"cluster.x-k8s.io"}, Resources: []string{"clusters"}, ResourceNames: []string{"test"}},
+	}
+	betaRules = []authorizationv1.ResourceRule{
+		{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{"application.giantswarm.io"}, Resources: []string{"apps"}},
+	}
+)
+
+// resolveAccess allows listing organizations and getting releases. In
+// org-acme, everything but deleting clusters is allowed. In org-beta, apps
+// can only be read.
+func resolveAccess(action clienttesting.Action) bool {
+	review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+	attributes := review.Spec.ResourceAttributes
+
+	switch {
+	case attributes.Resource == "organizations":
+		return attributes.Verb == "list"
+	case attributes.Resource == "releases":
+		return attributes.Verb == "get"
+	case attributes.Namespace == "org-acme":
+		return attributes.Resource != "clusters" || attributes.Verb != "delete"
+	case attributes.Namespace == "org-beta":
+		return attributes.Resource == "apps" && attributes.Verb == "get"
+	}
+
+	return false
+}
+
+func newOrganization(name, namespace string) *securityv1alpha1.Organization {
+	return &securityv1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: securityv1alpha1.OrganizationStatus{
+			Namespace: namespace,
+		},
+	}
+}
@@ -0,0 +1,109 @@
+package contexts
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/kubectl-gs/v5/cmd/contexts/list"
+	"github.com/giantswarm/kubectl-gs/v5/cmd/contexts/prune"
+	selectcontext "github.com/giantswarm/kubectl-gs/v5/cmd/contexts/select"
+)
+
+const (
+	name        = "contexts"
+	description = "Manage the kubeconfig files written with 'kubectl gs login --kubeconfig-dir'."
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	var err error
+
+	var listCmd *cobra.Command
+	{
+		c := list.Config{
+			Logger: config.Logger,
+
+			Stderr: config.Stderr,
+			Stdout: config.Stdout,
+		}
+
+		listCmd, err = list.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var pruneCmd *cobra.Command
+	{
+		c := prune.Config{
+			Logger: config.Logger,
+
+			Stderr: config.Stderr,
+			Stdout: config.Stdout,
+		}
+
+		pruneCmd, err = prune.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var selectCmd *cobra.Command
+	{
+		c := selectcontext.Config{
+			Logger: config.Logger,
+
+			Stderr: config.Stderr,
+			Stdout: config.Stdout,
+		}
+
+		selectCmd, err = selectcontext.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:   name,
+		Short: description,
+		Long:  description,
+		RunE:  r.Run,
+	}
+
+	f.Init(c)
+
+	c.AddCommand(listCmd)
+	c.AddCommand(pruneCmd)
+	c.AddCommand(selectCmd)
+
+	return c, nil
+}
@@ -0,0 +1,75 @@
+package list
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	name = "list"
+
+	shortDescription = "List the kubeconfig files in a kubeconfig directory"
+	longDescription  = `List the kubeconfig files in a kubeconfig directory
+
+Lists the files written with 'kubectl gs login --kubeconfig-dir', one per
+context, with the server, the type of credentials and their expiry.
+
+The status tells whether the credentials have expired, and whether they
+are renewed on use. Files which are not a kubeconfig holding their current
+context are listed as invalid. Use 'kubectl gs contexts prune' to remove
+the files with expired credentials which cannot be renewed.
+
+Use --output json or --output yaml for further processing.`
+
+	examples = `  # List the files in ~/.kube/kubeconfig.d
+  kubectl gs contexts list
+
+  # List the files in another directory, as JSON
+  kubectl gs contexts list --kubeconfig-dir /path/to/dir --output json`
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     name,
+		Short:   shortDescription,
+		Long:    longDescription,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE:    r.Run,
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
@@ -0,0 +1,44 @@
+package list
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+)
+
+const (
+	flagKubeconfigDir = "kubeconfig-dir"
+)
+
+type flag struct {
+	KubeconfigDir string
+
+	print *genericclioptions.PrintFlags
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.KubeconfigDir, flagKubeconfigDir, kubeconfig.DefaultDir, "Directory holding the kubeconfig files written with 'kubectl gs login --kubeconfig-dir'.")
+
+	f.print = genericclioptions.NewPrintFlags("")
+
+	// Merging current command flags and config flags,
+	// to be able to override kubectl-specific ones.
+	f.print.AddFlags(cmd)
+}
+
+func (f *flag) Validate() error {
+	if f.KubeconfigDir == "" {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagKubeconfigDir)
+	}
+
+	switch *f.print.OutputFormat {
+	case output.TypeDefault, output.TypeJSON, output.TypeYAML:
+	default:
+		return microerror.Maskf(invalidFlagError, "--output must be %q or %q", output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
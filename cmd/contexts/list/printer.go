@@ -0,0 +1,90 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+)
+
+const (
+	naValue = "n/a"
+)
+
+func (r *runner) printOutput(contextFiles []contextFile) error {
+	switch *r.flag.print.OutputFormat {
+	case output.TypeJSON:
+		data, err := json.MarshalIndent(contextFiles, "", "    ")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprintln(r.stdout, string(data))
+
+	case output.TypeYAML:
+		data, err := yaml.Marshal(contextFiles)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprint(r.stdout, string(data))
+
+	case output.TypeDefault:
+		if len(contextFiles) < 1 {
+			fmt.Fprintf(r.stdout, "No kubeconfig files found in %s.\n", r.flag.KubeconfigDir)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(r.stdout, 0, 8, 3, ' ', 0)
+		fmt.Fprintln(w, "CONTEXT\tSERVER\tAUTH\tEXPIRY\tSTATUS\tFILE")
+		for _, c := range contextFiles {
+			expiry := naValue
+			if c.Expiry != nil {
+				expiry = c.Expiry.UTC().Format(time.RFC3339)
+			}
+
+			status := c.Status
+			if c.Error != "" {
+				status = c.Error
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", valueOrNA(c.Context), valueOrNA(c.Server), valueOrNA(c.Auth), expiry, status, c.File)
+		}
+		err := w.Flush()
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+	default:
+		return microerror.Maskf(invalidFlagError, "output format %q is not supported, use %q or %q", *r.flag.print.OutputFormat, output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
+
+func formatAuthType(authType kubeconfig.AuthType) string {
+	switch authType {
+	case kubeconfig.AuthTypeServiceAccount:
+		return "token"
+	case kubeconfig.AuthTypeAuthProvider:
+		return "oidc"
+	case kubeconfig.AuthTypeClientCertificate:
+		return "client certificate"
+	case kubeconfig.AuthTypeExec:
+		return "credential plugin"
+	}
+
+	return ""
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return naValue
+	}
+
+	return value
+}
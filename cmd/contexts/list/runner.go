@@ -0,0 +1,92 @@
+package list
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/errorprinter"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+)
+
+// contextFile is a file in the kubeconfig directory.
+type contextFile struct {
+	File    string       `json:"file"`
+	Context string       `json:"context,omitempty"`
+	Server  string       `json:"server,omitempty"`
+	Auth    string       `json:"auth,omitempty"`
+	Expiry  *metav1.Time `json:"expiry,omitempty"`
+	Status  string       `json:"status"`
+	Error   string       `json:"error,omitempty"`
+}
+
+type runner struct {
+	flag   *flag
+	logger micrologger.Logger
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	files, err := kubeconfig.ReadDir(r.flag.KubeconfigDir)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	now := time.Now()
+	contextFiles := make([]contextFile, 0, len(files))
+	for _, file := range files {
+		contextFiles = append(contextFiles, getContextFile(file, now))
+	}
+
+	err = r.printOutput(contextFiles)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func getContextFile(file kubeconfig.DirFile, now time.Time) contextFile {
+	c := contextFile{
+		File:   filepath.Base(file.Path),
+		Status: string(file.Status(now)),
+	}
+	if file.Err != nil {
+		c.Error = errorprinter.FormatSingleLine(file.Err)
+		return c
+	}
+
+	c.Context = file.Context
+	c.Server, _ = kubeconfig.GetClusterServer(file.Config, file.Context)
+	c.Auth = formatAuthType(kubeconfig.GetAuthType(file.Config, file.Context))
+	if expiry, ok := kubeconfig.GetCredentialExpiry(file.Config, file.Context); ok {
+		t := metav1.NewTime(expiry.UTC())
+		c.Expiry = &t
+	}
+
+	return c
+}
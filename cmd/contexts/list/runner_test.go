@@ -0,0 +1,99 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	goflag "flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/test/goldenfile"
+	testkubeconfig "github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
+)
+
+var update = goflag.Bool("update", false, "update .golden reference test files")
+
+// Test_run uses golden files.
+//
+// go test ./cmd/contexts/list -run Test_run -update
+func Test_run(t *testing.T) {
+	testCases := []struct {
+		name               string
+		emptyDir           bool
+		outputType         string
+		expectedGoldenFile string
+	}{
+		{
+			name:               "case 0: list files",
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_list.golden",
+		},
+		{
+			name:               "case 1: list files, with json output",
+			outputType:         output.TypeJSON,
+			expectedGoldenFile: "run_list_json_output.golden",
+		},
+		{
+			name:               "case 2: empty directory",
+			emptyDir:           true,
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_list_empty.golden",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The directory name does not depend on the test, for the
+			// golden file of the empty directory.
+			dir := filepath.Join(t.TempDir(), "kubeconfig.d")
+			if !tc.emptyDir {
+				testkubeconfig.WriteTestDir(t, dir)
+			}
+
+			flag := &flag{
+				KubeconfigDir: dir,
+				print:         genericclioptions.NewPrintFlags("").WithDefaultOutput(tc.outputType),
+			}
+
+			out := new(bytes.Buffer)
+			runner := &runner{
+				flag:   flag,
+				stdout: out,
+				stderr: new(bytes.Buffer),
+			}
+
+			err := runner.run(context.TODO(), nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			result := bytes.ReplaceAll(out.Bytes(), []byte(dir), []byte("/kubeconfig.d"))
+
+			var expectedResult []byte
+			{
+				gf := goldenfile.New("testdata", tc.expectedGoldenFile)
+				if *update {
+					err = gf.Update(result)
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+					expectedResult = result
+				} else {
+					expectedResult, err = gf.Read()
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+				}
+			}
+
+			diff := cmp.Diff(string(expectedResult), string(result))
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
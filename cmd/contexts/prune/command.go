@@ -0,0 +1,75 @@
+package prune
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	name = "prune"
+
+	shortDescription = "Remove stale kubeconfig files from a kubeconfig directory"
+	longDescription  = `Remove stale kubeconfig files from a kubeconfig directory
+
+Removes the files written with 'kubectl gs login --kubeconfig-dir' whose
+credentials have expired and cannot be renewed, like tokens obtained
+without a refresh token, or client certificates not created by
+'kubectl gs login'.
+
+Only files named after a context created by kubectl-gs, which hold that
+context, are removed. Files with expired credentials which are renewed on
+use, like OIDC tokens with a refresh token, are kept. Files which are not a
+kubeconfig holding their current context are reported, but never removed.`
+
+	examples = `  # Show which files in ~/.kube/kubeconfig.d would be removed
+  kubectl gs contexts prune --dry-run
+
+  # Remove stale files from ~/.kube/kubeconfig.d
+  kubectl gs contexts prune`
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     name,
+		Short:   shortDescription,
+		Long:    longDescription,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE:    r.Run,
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
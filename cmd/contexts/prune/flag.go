@@ -0,0 +1,31 @@
+package prune
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+)
+
+const (
+	flagDryRun        = "dry-run"
+	flagKubeconfigDir = "kubeconfig-dir"
+)
+
+type flag struct {
+	DryRun        bool
+	KubeconfigDir string
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.DryRun, flagDryRun, false, "Print the files which would be removed, without removing them.")
+	cmd.Flags().StringVar(&f.KubeconfigDir, flagKubeconfigDir, kubeconfig.DefaultDir, "Directory holding the kubeconfig files written with 'kubectl gs login --kubeconfig-dir'.")
+}
+
+func (f *flag) Validate() error {
+	if f.KubeconfigDir == "" {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagKubeconfigDir)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package prune
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/errorprinter"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+)
+
+type runner struct {
+	flag   *flag
+	logger micrologger.Logger
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	files, err := kubeconfig.ReadDir(r.flag.KubeconfigDir)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	now := time.Now()
+
+	var pruned int
+	for _, file := range files {
+		status := file.Status(now)
+
+		if status == kubeconfig.DirFileStatusInvalid {
+			fmt.Fprintf(r.stderr, "Skipped %s (%s): %s\n", file.Path, status, errorprinter.FormatSingleLine(file.Err))
+			continue
+		}
+		// Files which were not written by 'kubectl gs login' are left
+		// alone, even if their credentials have expired.
+		if status != kubeconfig.DirFileStatusExpired || !file.IsWrittenByLogin() {
+			continue
+		}
+
+		expiry, _ := kubeconfig.GetCredentialExpiry(file.Config, file.Context)
+		reason := fmt.Sprintf("The credentials of context '%s' expired at %s and cannot be renewed.", file.Context, expiry.UTC().Format(time.RFC3339))
+
+		if r.flag.DryRun {
+			fmt.Fprintf(r.stdout, "Would remove %s (%s): %s\n", file.Path, status, reason)
+		} else {
+			err = os.Remove(file.Path)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+			fmt.Fprintf(r.stdout, "Removed %s (%s): %s\n", file.Path, status, reason)
+		}
+		pruned++
+	}
+
+	if pruned < 1 {
+		fmt.Fprintf(r.stdout, "No stale kubeconfig files found in %s.\n", r.flag.KubeconfigDir)
+	}
+
+	return nil
+}
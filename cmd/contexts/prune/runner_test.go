@@ -0,0 +1,106 @@
+package prune
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	testkubeconfig "github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
+)
+
+func Test_run(t *testing.T) {
+	testCases := []struct {
+		name   string
+		dryRun bool
+
+		expectedFiles     []string
+		expectedOutput    []string
+		expectedErrOutput []string
+	}{
+		{
+			name:          "case 0: prune",
+			expectedFiles: []string{"custom.yaml", "gs-renewable.yaml", "gs-token.yaml", "gs-valid.yaml", "invalid.yaml"},
+			expectedOutput: []string{
+				"Removed /kubeconfig.d/gs-expired.yaml (Expired): The credentials of context 'gs-expired' expired at 2026-01-02T15:04:05Z and cannot be renewed.\n",
+			},
+			expectedErrOutput: []string{
+				"Skipped /kubeconfig.d/invalid.yaml (Invalid): Invalid kubeconfig file: The file has no current context.\n",
+			},
+		},
+		{
+			name:          "case 1: dry run",
+			dryRun:        true,
+			expectedFiles: []string{"custom.yaml", "gs-expired.yaml", "gs-renewable.yaml", "gs-token.yaml", "gs-valid.yaml", "invalid.yaml"},
+			expectedOutput: []string{
+				"Would remove /kubeconfig.d/gs-expired.yaml (Expired): ",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "kubeconfig.d")
+			testkubeconfig.WriteTestDir(t, dir)
+
+			out := new(bytes.Buffer)
+			errOut := new(bytes.Buffer)
+			runner := &runner{
+				flag: &flag{
+					DryRun:        tc.dryRun,
+					KubeconfigDir: dir,
+				},
+				stdout: out,
+				stderr: errOut,
+			}
+
+			err := runner.run(context.TODO(), nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			paths, err := kubeconfig.ListDirFiles(dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			var files []string
+			for _, path := range paths {
+				files = append(files, filepath.Base(path))
+			}
+			if strings.Join(files, ",") != strings.Join(tc.expectedFiles, ",") {
+				t.Fatalf("expected files %v, got %v", tc.expectedFiles, files)
+			}
+
+			outStr := strings.ReplaceAll(out.String(), dir, "/kubeconfig.d")
+			for _, expected := range tc.expectedOutput {
+				if !strings.Contains(outStr, expected) {
+					t.Fatalf("output does not contain expected string:\nvalue: %s\nexpected string: %s\n", outStr, expected)
+				}
+			}
+
+			errOutStr := strings.ReplaceAll(errOut.String(), dir, "/kubeconfig.d")
+			for _, expected := range tc.expectedErrOutput {
+				if !strings.Contains(errOutStr, expected) {
+					t.Fatalf("error output does not contain expected string:\nvalue: %s\nexpected string: %s\n", errOutStr, expected)
+				}
+			}
+			if strings.Contains(outStr, "custom.yaml") || strings.Contains(errOutStr, "custom.yaml") {
+				t.Fatalf("expected the file not written by login to be left alone, got:\n%s%s", outStr, errOutStr)
+			}
+
+			// Nothing is left to prune.
+			if !tc.dryRun {
+				out.Reset()
+				err = runner.run(context.TODO(), nil, nil)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				if !strings.HasPrefix(out.String(), "No stale kubeconfig files found") {
+					t.Fatalf("expected nothing to prune, got:\n%s", out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package selectcontext
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	name = "select <context>"
+
+	shortDescription = "Print the KUBECONFIG value selecting a context of a kubeconfig directory"
+	longDescription  = `Print the KUBECONFIG value selecting a context of a kubeconfig directory
+
+Prints a command setting KUBECONFIG to the files written with
+'kubectl gs login --kubeconfig-dir', with the file of the given context
+first, so that kubectl uses it as the current context. Evaluate the output
+in your shell to apply it.
+
+The context can be given by its name, or by the code name of a management
+cluster. Invalid files are left out.`
+
+	examples = `  # Select the context gs-mymc in the current shell
+  eval "$(kubectl gs contexts select gs-mymc)"
+
+  # Select the context of the management cluster mymc
+  eval "$(kubectl gs contexts select mymc)"`
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     name,
+		Short:   shortDescription,
+		Long:    longDescription,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE:    r.Run,
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
@@ -0,0 +1,28 @@
+package selectcontext
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+)
+
+const (
+	flagKubeconfigDir = "kubeconfig-dir"
+)
+
+type flag struct {
+	KubeconfigDir string
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.KubeconfigDir, flagKubeconfigDir, kubeconfig.DefaultDir, "Directory holding the kubeconfig files written with 'kubectl gs login --kubeconfig-dir'.")
+}
+
+func (f *flag) Validate() error {
+	if f.KubeconfigDir == "" {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagKubeconfigDir)
+	}
+
+	return nil
+}
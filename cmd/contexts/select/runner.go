@@ -0,0 +1,76 @@
+package selectcontext
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+)
+
+type runner struct {
+	flag   *flag
+	logger micrologger.Logger
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	contextName := args[0]
+	if kubeconfig.IsCodeName(contextName) {
+		contextName = kubeconfig.GenerateKubeContextName(contextName)
+	}
+
+	files, err := kubeconfig.ReadDir(r.flag.KubeconfigDir)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var paths []string
+	var selected *kubeconfig.DirFile
+	for i, file := range files {
+		if file.Err != nil {
+			continue
+		}
+
+		paths = append(paths, file.Path)
+		if file.Context == contextName {
+			selected = &files[i]
+		}
+	}
+
+	if selected == nil {
+		return microerror.Maskf(contextNotFoundError, "There is no file for the context '%s' in %s.", contextName, r.flag.KubeconfigDir)
+	}
+
+	if selected.Status(time.Now()) == kubeconfig.DirFileStatusExpired {
+		fmt.Fprint(r.stderr, color.YellowString("Warning: The credentials of context '%s' have expired and cannot be renewed. Please log in again.\n", contextName))
+	}
+
+	fmt.Fprintf(r.stdout, "export KUBECONFIG=%s\n", kubeconfig.GetDirPathList(paths, selected.Path))
+
+	return nil
+}
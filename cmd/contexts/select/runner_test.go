@@ -0,0 +1,91 @@
+package selectcontext
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testkubeconfig "github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
+)
+
+func Test_run(t *testing.T) {
+	testCases := []struct {
+		name     string
+		argument string
+
+		// expectedPathList lists the files in KUBECONFIG, relative to the
+		// directory.
+		expectedPathList []string
+		expectedWarning  string
+		errorMatcher     func(error) bool
+	}{
+		{
+			name:             "case 0: select a context",
+			argument:         "gs-valid",
+			expectedPathList: []string{"gs-valid.yaml", "custom.yaml", "gs-expired.yaml", "gs-renewable.yaml", "gs-token.yaml"},
+		},
+		{
+			name:             "case 1: select the context of a management cluster by its code name",
+			argument:         "renewable",
+			expectedPathList: []string{"gs-renewable.yaml", "custom.yaml", "gs-expired.yaml", "gs-token.yaml", "gs-valid.yaml"},
+		},
+		{
+			name:             "case 2: select a context with expired credentials",
+			argument:         "gs-expired",
+			expectedPathList: []string{"gs-expired.yaml", "custom.yaml", "gs-renewable.yaml", "gs-token.yaml", "gs-valid.yaml"},
+			expectedWarning:  "Warning: The credentials of context 'gs-expired' have expired and cannot be renewed.",
+		},
+		{
+			name:         "case 3: context which does not exist",
+			argument:     "gs-unknown",
+			errorMatcher: IsContextNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "kubeconfig.d")
+			testkubeconfig.WriteTestDir(t, dir)
+
+			out := new(bytes.Buffer)
+			errOut := new(bytes.Buffer)
+			runner := &runner{
+				flag: &flag{
+					KubeconfigDir: dir,
+				},
+				stdout: out,
+				stderr: errOut,
+			}
+
+			err := runner.run(context.TODO(), nil, []string{tc.argument})
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			// The invalid file is left out.
+			var pathList []string
+			for _, name := range tc.expectedPathList {
+				pathList = append(pathList, filepath.Join(dir, name))
+			}
+			expected := fmt.Sprintf("export KUBECONFIG=%s\n", strings.Join(pathList, string(filepath.ListSeparator)))
+			if out.String() != expected {
+				t.Fatalf("expected output %q, got %q", expected, out.String())
+			}
+
+			if tc.expectedWarning == "" && errOut.Len() > 0 {
+				t.Fatalf("unexpected warning: %q", errOut.String())
+			} else if !strings.Contains(errOut.String(), tc.expectedWarning) {
+				t.Fatalf("expected warning to contain %q, got: %q", tc.expectedWarning, errOut.String())
+			}
+		})
+	}
+}
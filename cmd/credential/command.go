@@ -0,0 +1,73 @@
+package credential
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+)
+
+const (
+	name             = kubeconfig.CredentialCommand
+	shortDescription = "Provides OIDC tokens to kubectl as a client-go credential plugin"
+	longDescription  = `Provides OIDC tokens to kubectl as a client-go credential plugin
+
+This command implements the client.authentication.k8s.io ExecCredential
+protocol. It is not meant to be executed directly. Instead, it is
+referenced from kubeconfig user entries created by
+
+  kubectl gs login --` + flagExecCredential + `
+
+It prints a valid ID token for the given issuer, renewing it with the
+cached refresh token if needed. This way kubectl, Helm, Flux and any
+other client-go based tool can use the Giant Swarm OIDC login without
+the deprecated oidc auth provider.`
+
+	// flagExecCredential is the name of the login flag that
+	// creates kubeconfig entries which reference this command.
+	flagExecCredential = "exec-credential"
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:   name,
+		Short: shortDescription,
+		Long:  longDescription,
+		Args:  cobra.NoArgs,
+		RunE:  r.Run,
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
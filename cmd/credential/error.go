@@ -0,0 +1,32 @@
+package credential
+
+import (
+	"github.com/giantswarm/microerror"
+)
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var invalidFlagError = &microerror.Error{
+	Kind: "invalidFlagError",
+}
+
+// IsInvalidFlag asserts invalidFlagError.
+func IsInvalidFlag(err error) bool {
+	return microerror.Cause(err) == invalidFlagError
+}
+
+var newLoginRequiredError = &microerror.Error{
+	Kind: "newLoginRequiredError",
+}
+
+// IsNewLoginRequired asserts newLoginRequiredError.
+func IsNewLoginRequired(err error) bool {
+	return microerror.Cause(err) == newLoginRequiredError
+}
@@ -0,0 +1,39 @@
+package credential
+
+import (
+	"net/url"
+
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+)
+
+const (
+	flagIssuer   = kubeconfig.CredentialFlagIssuer
+	flagClientID = kubeconfig.CredentialFlagClientID
+)
+
+type flag struct {
+	Issuer   string
+	ClientID string
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.Issuer, flagIssuer, "", "URL of the OIDC issuer, e. g. the management cluster's Dex.")
+	cmd.Flags().StringVar(&f.ClientID, flagClientID, "", "OIDC client ID used to log in.")
+}
+
+func (f *flag) Validate() error {
+	if f.Issuer == "" {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagIssuer)
+	}
+	if _, err := url.ParseRequestURI(f.Issuer); err != nil {
+		return microerror.Maskf(invalidFlagError, "--%s must be a valid URL", flagIssuer)
+	}
+	if f.ClientID == "" {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagClientID)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package credential
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientauthv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	clientauthv1beta1 "k8s.io/client-go/pkg/apis/clientauthentication/v1beta1"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/tokencache"
+)
+
+const (
+	// execInfoEnvVar is set by client-go when running a credential plugin.
+	execInfoEnvVar = "KUBERNETES_EXEC_INFO"
+)
+
+type runner struct {
+	flag   *flag
+	logger micrologger.Logger
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	cacheDir, err := key.GetCacheDir()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	entry, expiry, err := tokencache.GetFreshToken(ctx, cacheDir, r.flag.Issuer, r.flag.ClientID)
+	if tokencache.IsNotFound(err) {
+		return microerror.Maskf(newLoginRequiredError, "No cached credentials found for issuer %s. Please log in again using 'kubectl gs login --%s'.", r.flag.Issuer, flagExecCredential)
+	} else if err != nil {
+		return microerror.Maskf(newLoginRequiredError, "Could not renew the token for issuer %s: %s\nPlease log in again using 'kubectl gs login --%s'.", r.flag.Issuer, err.Error(), flagExecCredential)
+	}
+
+	expirationTimestamp := metav1.NewTime(expiry)
+	execCredential := clientauthv1.ExecCredential{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: getExecCredentialAPIVersion(),
+			Kind:       "ExecCredential",
+		},
+		Status: &clientauthv1.ExecCredentialStatus{
+			Token:               entry.IDToken,
+			ExpirationTimestamp: &expirationTimestamp,
+		},
+	}
+
+	output, err := json.Marshal(execCredential)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	fmt.Fprintln(r.stdout, string(output))
+
+	return nil
+}
+
+// getExecCredentialAPIVersion returns the API version requested by
+// client-go. The v1 and v1beta1 ExecCredential status formats are
+// identical, so only the version in the response needs to match.
+func getExecCredentialAPIVersion() string {
+	execInfo := os.Getenv(execInfoEnvVar)
+	if execInfo == "" {
+		return kubeconfig.ExecCredentialAPIVersion
+	}
+
+	var typeMeta metav1.TypeMeta
+	err := json.Unmarshal([]byte(execInfo), &typeMeta)
+	if err != nil {
+		return kubeconfig.ExecCredentialAPIVersion
+	}
+
+	if typeMeta.APIVersion == clientauthv1beta1.SchemeGroupVersion.String() {
+		return typeMeta.APIVersion
+	}
+
+	return kubeconfig.ExecCredentialAPIVersion
+}
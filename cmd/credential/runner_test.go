@@ -0,0 +1,126 @@
+package credential
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cobra"
+	clientauthv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/tokencache"
+	testoidc "github.com/giantswarm/kubectl-gs/v5/test/oidc"
+)
+
+func Test_run(t *testing.T) {
+	testCases := []struct {
+		name                string
+		cachedIDTokenExp    time.Time
+		noCache             bool
+		execInfo            string
+		expectAPIVersion    string
+		expectCachedIDToken bool
+		expectError         *microerror.Error
+	}{
+		{
+			name:                "case 0: valid cached token",
+			cachedIDTokenExp:    time.Now().Add(time.Hour),
+			expectAPIVersion:    "client.authentication.k8s.io/v1",
+			expectCachedIDToken: true,
+		},
+		{
+			name:             "case 1: expired cached token gets renewed",
+			cachedIDTokenExp: time.Now().Add(-time.Hour),
+			expectAPIVersion: "client.authentication.k8s.io/v1",
+		},
+		{
+			name:                "case 2: v1beta1 requested by client-go",
+			cachedIDTokenExp:    time.Now().Add(time.Hour),
+			execInfo:            `{"apiVersion":"client.authentication.k8s.io/v1beta1","kind":"ExecCredential"}`,
+			expectAPIVersion:    "client.authentication.k8s.io/v1beta1",
+			expectCachedIDToken: true,
+		},
+		{
+			name:        "case 3: no cached tokens",
+			noCache:     true,
+			expectError: newLoginRequiredError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			t.Setenv(execInfoEnvVar, tc.execInfo)
+
+			s := testoidc.NewServer(testoidc.MockOidcServerConfig{ClientID: "client"})
+			err := s.Start(t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Stop()
+
+			idToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": tc.cachedIDTokenExp.Unix()}).SignedString([]byte("secret"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !tc.noCache {
+				cacheDir, err := key.GetCacheDir()
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = tokencache.Persist(cacheDir, s.Issuer(), "client", tokencache.Entry{IDToken: idToken, RefreshToken: "refresh-token"})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			out := new(bytes.Buffer)
+			r := runner{
+				flag: &flag{
+					Issuer:   s.Issuer(),
+					ClientID: "client",
+				},
+				stdout: out,
+				stderr: new(bytes.Buffer),
+			}
+
+			err = r.run(context.Background(), &cobra.Command{}, nil)
+			if err != nil {
+				if microerror.Cause(err) != tc.expectError {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				return
+			} else if tc.expectError != nil {
+				t.Fatalf("unexpected success")
+			}
+
+			var execCredential clientauthv1.ExecCredential
+			err = json.Unmarshal(out.Bytes(), &execCredential)
+			if err != nil {
+				t.Fatalf("output is not a valid ExecCredential: %s", err)
+			}
+
+			if execCredential.APIVersion != tc.expectAPIVersion {
+				t.Fatalf("expected API version %s, got %s", tc.expectAPIVersion, execCredential.APIVersion)
+			}
+			if execCredential.Kind != "ExecCredential" {
+				t.Fatalf("expected kind ExecCredential, got %s", execCredential.Kind)
+			}
+			if execCredential.Status == nil || execCredential.Status.Token == "" {
+				t.Fatal("expected a token in the ExecCredential status")
+			}
+			if (execCredential.Status.Token == idToken) != tc.expectCachedIDToken {
+				t.Fatalf("expected cached token to be returned: %t", tc.expectCachedIDToken)
+			}
+			if execCredential.Status.ExpirationTimestamp == nil || !execCredential.Status.ExpirationTimestamp.After(time.Now()) {
+				t.Fatal("expected an expiration timestamp in the future")
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package cluster
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
+)
+
+const (
+	name = "cluster <cluster-name>"
+
+	shortDescription = "Show the status of a cluster"
+	longDescription  = `Show the status of a cluster
+
+Gathers the status of a workload cluster from all resources belonging to it:
+
+- The CAPI Cluster, with its release version, conditions and scheduled update.
+- The infrastructure cluster, like the AWSCluster or AzureCluster.
+- The cluster app and default apps app, for clusters created from Helm charts.
+- The node pools, with the number of desired and ready nodes.
+- The apps of the cluster which are not deployed, with the reason.
+- The most recent events of the cluster and the resources named after it.
+
+Use --output json or --output yaml for further processing.`
+
+	examples = `  # Show the status of a cluster
+  kubectl gs describe cluster a01 --namespace org-acme
+
+  # Show the status of a cluster, with the 20 most recent events
+  kubectl gs describe cluster a01 --namespace org-acme --events 20
+
+  # Print the status of a cluster as JSON
+  kubectl gs describe cluster a01 --namespace org-acme --output json`
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	ConfigFlags       *genericclioptions.RESTClientGetter
+	InstallationCache *installation.CacheConfig
+
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.ConfigFlags == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ConfigFlags must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		commonConfig: &commonconfig.CommonConfig{
+			ConfigFlags:       config.ConfigFlags,
+			InstallationCache: config.InstallationCache,
+		},
+		flag:   f,
+		logger: config.Logger,
+
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     name,
+		Short:   shortDescription,
+		Long:    longDescription,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE:    r.Run,
+		PreRunE: middleware.Compose(
+			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
+		),
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
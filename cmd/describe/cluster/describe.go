@@ -0,0 +1,395 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/nodepool"
+)
+
+// description gathers the status of a cluster from all the resources
+// belonging to it.
+type description struct {
+	Name              string           `json:"name"`
+	Namespace         string           `json:"namespace"`
+	Organization      string           `json:"organization,omitempty"`
+	Description       string           `json:"description,omitempty"`
+	Release           string           `json:"release,omitempty"`
+	Phase             string           `json:"phase,omitempty"`
+	Created           metav1.Time      `json:"created"`
+	ControlPlaneReady bool             `json:"controlPlaneReady"`
+	ScheduledUpdate   *scheduledUpdate `json:"scheduledUpdate,omitempty"`
+	Conditions        []condition      `json:"conditions,omitempty"`
+	Infrastructure    *infrastructure  `json:"infrastructure,omitempty"`
+	ClusterApp        *appStatus       `json:"clusterApp,omitempty"`
+	DefaultAppsApp    *appStatus       `json:"defaultAppsApp,omitempty"`
+	NodePools         []nodePool       `json:"nodePools,omitempty"`
+	Apps              int              `json:"apps"`
+	DeployedApps      int              `json:"deployedApps"`
+	AppsNotDeployed   []appStatus      `json:"appsNotDeployed,omitempty"`
+	Events            []event          `json:"events,omitempty"`
+
+	// scheduledUpdateSummary is the scheduled update as shown by
+	// 'kubectl gs get clusters'.
+	scheduledUpdateSummary string
+	// objects are the resources known to belong to the cluster, as
+	// kind/name, to find their events.
+	objects map[string]bool
+}
+
+type scheduledUpdate struct {
+	TargetRelease string `json:"targetRelease,omitempty"`
+	TargetTime    string `json:"targetTime,omitempty"`
+}
+
+type condition struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	Severity           string      `json:"severity,omitempty"`
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+type infrastructure struct {
+	Kind       string      `json:"kind"`
+	Name       string      `json:"name"`
+	Ready      bool        `json:"ready"`
+	Conditions []condition `json:"conditions,omitempty"`
+}
+
+type appStatus struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type nodePool struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Phase    string `json:"phase,omitempty"`
+	Replicas int32  `json:"replicas"`
+	Ready    int32  `json:"ready"`
+}
+
+type event struct {
+	Type     string      `json:"type"`
+	Reason   string      `json:"reason"`
+	Object   string      `json:"object"`
+	Message  string      `json:"message"`
+	Count    int32       `json:"count,omitempty"`
+	LastSeen metav1.Time `json:"lastSeen"`
+}
+
+func (r *runner) describe(ctx context.Context, c *cluster.Cluster) (*description, error) {
+	capiCluster := c.Cluster
+
+	d := &description{
+		Name:                   capiCluster.Name,
+		Namespace:              capiCluster.Namespace,
+		Organization:           capiCluster.Labels[label.Organization],
+		Description:            capiCluster.Annotations[annotation.ClusterDescription],
+		Release:                capiCluster.Labels[label.ReleaseVersion],
+		Phase:                  capiCluster.Status.Phase,
+		Created:                capiCluster.CreationTimestamp,
+		ControlPlaneReady:      capiCluster.Status.ControlPlaneReady,
+		Conditions:             getCAPIConditions(capiCluster.Status.Conditions),
+		Infrastructure:         getInfrastructure(c),
+		ClusterApp:             getAppStatus(c.ClusterApp),
+		DefaultAppsApp:         getAppStatus(c.DefaultAppsApp),
+		scheduledUpdateSummary: cluster.FormatScheduledUpdate(capiCluster),
+		objects:                map[string]bool{objectKey("Cluster", capiCluster.Name): true},
+	}
+	if d.Infrastructure != nil && d.Infrastructure.Name != "" {
+		d.objects[objectKey(d.Infrastructure.Kind, d.Infrastructure.Name)] = true
+	}
+	if ref := capiCluster.Spec.ControlPlaneRef; ref != nil {
+		d.objects[objectKey(ref.Kind, ref.Name)] = true
+	}
+
+	targetRelease := capiCluster.Annotations[annotation.UpdateScheduleTargetRelease]
+	targetTime := capiCluster.Annotations[annotation.UpdateScheduleTargetTime]
+	if targetRelease != "" || targetTime != "" {
+		d.ScheduledUpdate = &scheduledUpdate{
+			TargetRelease: targetRelease,
+			TargetTime:    targetTime,
+		}
+	}
+
+	var err error
+
+	d.NodePools, err = r.getNodePools(ctx, capiCluster)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	for _, np := range d.NodePools {
+		d.objects[objectKey(np.Kind, np.Name)] = true
+	}
+
+	// The names of the other clusters in the namespace tell the apps and
+	// events of clusters with similar names apart.
+	clusterNames, err := r.appService.GetClusterNames(ctx, capiCluster.Namespace)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	err = r.addApps(ctx, d, clusterNames)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if r.flag.Events > 0 {
+		d.Events, err = r.getEvents(ctx, d, clusterNames)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	return d, nil
+}
+
+func (r *runner) getNodePools(ctx context.Context, capiCluster *capi.Cluster) ([]nodePool, error) {
+	resource, err := r.nodepoolService.Get(ctx, nodepool.GetOptions{
+		ClusterName: capiCluster.Name,
+		Namespace:   capiCluster.Namespace,
+		Provider:    r.getNodepoolProvider(),
+	})
+	if nodepool.IsNoResources(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	collection, ok := resource.(*nodepool.Collection)
+	if !ok {
+		return nil, nil
+	}
+
+	var nodePools []nodePool
+	for _, np := range collection.Items {
+		switch {
+		case np.MachineDeployment != nil:
+			md := np.MachineDeployment
+			nodePools = append(nodePools, nodePool{
+				Name:     md.Name,
+				Kind:     "MachineDeployment",
+				Phase:    md.Status.Phase,
+				Replicas: getReplicas(md.Spec.Replicas, md.Status.Replicas),
+				Ready:    md.Status.ReadyReplicas,
+			})
+		case np.MachinePool != nil:
+			mp := np.MachinePool
+			nodePools = append(nodePools, nodePool{
+				Name:     mp.Name,
+				Kind:     "MachinePool",
+				Phase:    mp.Status.Phase,
+				Replicas: getReplicas(mp.Spec.Replicas, mp.Status.Replicas),
+				Ready:    mp.Status.ReadyReplicas,
+			})
+		}
+	}
+
+	sort.Slice(nodePools, func(i, j int) bool {
+		return nodePools[i].Name < nodePools[j].Name
+	})
+
+	return nodePools, nil
+}
+
+// addApps counts the apps of the cluster and lists those not deployed.
+func (r *runner) addApps(ctx context.Context, d *description, clusterNames []string) error {
+	resource, err := r.appService.Get(ctx, app.GetOptions{Namespace: d.Namespace})
+	if app.IsNoResources(err) {
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	collection, ok := resource.(*app.Collection)
+	if !ok {
+		return nil
+	}
+
+	for _, item := range collection.Items {
+		if !app.IsClusterApp(item.CR, d.Name, clusterNames) {
+			continue
+		}
+
+		d.objects[objectKey("App", item.CR.Name)] = true
+		d.Apps++
+		if item.CR.Status.Release.Status == app.StatusDeployed {
+			d.DeployedApps++
+			continue
+		}
+
+		d.AppsNotDeployed = append(d.AppsNotDeployed, *getAppStatus(item.CR))
+	}
+
+	sort.Slice(d.AppsNotDeployed, func(i, j int) bool {
+		return d.AppsNotDeployed[i].Name < d.AppsNotDeployed[j].Name
+	})
+
+	return nil
+}
+
+// getEvents returns the most recent events of the cluster and of the
+// resources belonging to it, like its apps, control plane and node pools.
+// Events of other resources are only included if these are named with the
+// cluster name as prefix, and not with the name of another cluster in the
+// namespace with a longer name.
+func (r *runner) getEvents(ctx context.Context, d *description, clusterNames []string) ([]event, error) {
+	list := &corev1.EventList{}
+	err := r.ctrlClient.List(ctx, list, runtimeclient.InNamespace(d.Namespace))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var events []event
+	for _, e := range list.Items {
+		name := e.InvolvedObject.Name
+		if !d.objects[objectKey(e.InvolvedObject.Kind, name)] && !isNamedAfterCluster(name, d.Name, clusterNames) {
+			continue
+		}
+
+		events = append(events, event{
+			Type:     e.Type,
+			Reason:   e.Reason,
+			Object:   fmt.Sprintf("%s/%s", e.InvolvedObject.Kind, name),
+			Message:  strings.TrimSpace(e.Message),
+			Count:    e.Count,
+			LastSeen: getLastSeen(e),
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[j].LastSeen.Before(&events[i].LastSeen)
+	})
+	if len(events) > r.flag.Events {
+		events = events[:r.flag.Events]
+	}
+
+	return events, nil
+}
+
+// isNamedAfterCluster tells whether the name has the cluster name as
+// prefix, and not the name of one of the other clusters with a longer name.
+func isNamedAfterCluster(name, clusterName string, clusterNames []string) bool {
+	if !strings.HasPrefix(name, clusterName+"-") {
+		return false
+	}
+	for _, other := range clusterNames {
+		if len(other) > len(clusterName) && (name == other || strings.HasPrefix(name, other+"-")) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func objectKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+func getCAPIConditions(conditions capi.Conditions) []condition {
+	var result []condition
+	for _, c := range conditions {
+		result = append(result, condition{
+			Type:               string(c.Type),
+			Status:             string(c.Status),
+			Severity:           string(c.Severity),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime,
+		})
+	}
+
+	return result
+}
+
+// getInfrastructure returns the infrastructure cluster referenced by the
+// cluster, with the conditions of the AWSCluster or AzureCluster if known.
+func getInfrastructure(c *cluster.Cluster) *infrastructure {
+	ref := c.Cluster.Spec.InfrastructureRef
+	if ref == nil && c.AWSCluster == nil && c.AzureCluster == nil {
+		return nil
+	}
+
+	infra := &infrastructure{
+		Ready: c.Cluster.Status.InfrastructureReady,
+	}
+	if ref != nil {
+		infra.Kind = ref.Kind
+		infra.Name = ref.Name
+	}
+
+	switch {
+	case c.AWSCluster != nil:
+		infra.Kind = "AWSCluster"
+		infra.Name = c.AWSCluster.Name
+		for _, cond := range c.AWSCluster.Status.Cluster.Conditions {
+			infra.Conditions = append(infra.Conditions, condition{
+				Type:               cond.Condition,
+				Status:             string(corev1.ConditionTrue),
+				LastTransitionTime: cond.LastTransitionTime,
+			})
+		}
+	case c.AzureCluster != nil:
+		infra.Kind = "AzureCluster"
+		infra.Name = c.AzureCluster.Name
+		infra.Ready = c.AzureCluster.Status.Ready
+		infra.Conditions = getCAPIConditions(c.AzureCluster.Status.Conditions)
+	}
+
+	return infra
+}
+
+func getAppStatus(appCR *applicationv1alpha1.App) *appStatus {
+	if appCR == nil {
+		return nil
+	}
+
+	version := appCR.Status.Version
+	if version == "" {
+		version = appCR.Spec.Version
+	}
+
+	return &appStatus{
+		Name:    appCR.Name,
+		Version: version,
+		Status:  appCR.Status.Release.Status,
+		Reason:  strings.TrimSpace(appCR.Status.Release.Reason),
+	}
+}
+
+func getReplicas(desired *int32, current int32) int32 {
+	if desired != nil {
+		return *desired
+	}
+
+	return current
+}
+
+func getLastSeen(e corev1.Event) metav1.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp
+	case !e.EventTime.IsZero():
+		return metav1.NewTime(e.EventTime.Time)
+	default:
+		return e.FirstTimestamp
+	}
+}
@@ -0,0 +1,35 @@
+package cluster
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const (
+	flagEvents = "events"
+)
+
+type flag struct {
+	Events int
+
+	print *genericclioptions.PrintFlags
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&f.Events, flagEvents, 10, "Number of recent events to show. Use 0 to leave out the events.")
+
+	f.print = genericclioptions.NewPrintFlags("")
+
+	// Merging current command flags and config flags,
+	// to be able to override kubectl-specific ones.
+	f.print.AddFlags(cmd)
+}
+
+func (f *flag) Validate() error {
+	if f.Events < 0 {
+		return microerror.Maskf(invalidFlagError, "--%s must not be negative", flagEvents)
+	}
+
+	return nil
+}
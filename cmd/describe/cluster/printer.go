@@ -0,0 +1,279 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+)
+
+const (
+	naValue = "n/a"
+
+	// indent is the indentation of the tables of a section.
+	indent = "  "
+)
+
+func (r *runner) printOutput(d *description) error {
+	switch *r.flag.print.OutputFormat {
+	case output.TypeJSON:
+		data, err := json.MarshalIndent(d, "", "    ")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprintln(r.stdout, string(data))
+
+	case output.TypeYAML:
+		data, err := yaml.Marshal(d)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprint(r.stdout, string(data))
+
+	case output.TypeDefault:
+		err := printDescription(r.stdout, d, r.flag.Events > 0)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+	default:
+		return microerror.Maskf(invalidFlagError, "output format %q is not supported, use %q or %q", *r.flag.print.OutputFormat, output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
+
+// printDescription prints the cluster in the style of 'kubectl describe'.
+func printDescription(out io.Writer, d *description, withEvents bool) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s\n", d.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", d.Namespace)
+	fmt.Fprintf(w, "Organization:\t%s\n", valueOrNA(d.Organization))
+	fmt.Fprintf(w, "Description:\t%s\n", valueOrNA(d.Description))
+	fmt.Fprintf(w, "Release:\t%s\n", valueOrNA(d.Release))
+	fmt.Fprintf(w, "Scheduled Update:\t%s\n", valueOrNA(d.scheduledUpdateSummary))
+	fmt.Fprintf(w, "Created:\t%s\n", formatTime(d.Created))
+	fmt.Fprintf(w, "Phase:\t%s\n", valueOrNA(d.Phase))
+	fmt.Fprintf(w, "Control Plane Ready:\t%t\n", d.ControlPlaneReady)
+	if d.Infrastructure != nil {
+		fmt.Fprintf(w, "Infrastructure:\t%s/%s\n", d.Infrastructure.Kind, d.Infrastructure.Name)
+		fmt.Fprintf(w, "Infrastructure Ready:\t%t\n", d.Infrastructure.Ready)
+	}
+	fmt.Fprintf(w, "Cluster App:\t%s\n", formatAppStatus(d.ClusterApp))
+	fmt.Fprintf(w, "Default Apps App:\t%s\n", formatAppStatus(d.DefaultAppsApp))
+	fmt.Fprintf(w, "Apps:\t%d (%d deployed)\n", d.Apps, d.DeployedApps)
+	err := w.Flush()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// Only the AWSCluster and AzureCluster conditions are known.
+	var infrastructureConditions *metav1.Table
+	if d.Infrastructure != nil && len(d.Infrastructure.Conditions) > 0 {
+		infrastructureConditions = getConditionTable(d.Infrastructure.Conditions)
+	}
+
+	var events *metav1.Table
+	if withEvents {
+		events = getEventTable(d.Events)
+	}
+
+	sections := []struct {
+		title string
+		table *metav1.Table
+	}{
+		{"Conditions", getConditionTable(d.Conditions)},
+		{"Infrastructure Conditions", infrastructureConditions},
+		{"Node Pools", getNodePoolTable(d.NodePools)},
+		{"Apps Not Deployed", getAppStatusTable(d.AppsNotDeployed)},
+		{"Events", events},
+	}
+
+	for _, section := range sections {
+		if section.table == nil {
+			continue
+		}
+
+		err = printSection(out, section.title, section.table)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+// printSection prints the table indented below the title, or <none> if
+// the table is empty.
+func printSection(out io.Writer, title string, table *metav1.Table) error {
+	if len(table.Rows) == 0 {
+		fmt.Fprintf(out, "\n%s: <none>\n", title)
+		return nil
+	}
+
+	fmt.Fprintf(out, "\n%s:\n", title)
+
+	var buf bytes.Buffer
+	printer := printers.NewTablePrinter(printers.PrintOptions{})
+	err := printer.PrintObj(table, &buf)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		fmt.Fprintf(out, "%s%s\n", indent, strings.TrimRight(line, " "))
+	}
+
+	return nil
+}
+
+func getConditionTable(conditions []condition) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Type", Type: "string"},
+			{Name: "Status", Type: "string"},
+			{Name: "Severity", Type: "string"},
+			{Name: "Reason", Type: "string"},
+			{Name: "Last Transition", Type: "string"},
+			{Name: "Message", Type: "string"},
+		},
+	}
+
+	for _, c := range conditions {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []interface{}{
+				c.Type,
+				c.Status,
+				valueOrNA(c.Severity),
+				valueOrNA(c.Reason),
+				formatTime(c.LastTransitionTime),
+				valueOrNA(c.Message),
+			},
+		})
+	}
+
+	return table
+}
+
+func getNodePoolTable(nodePools []nodePool) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Kind", Type: "string"},
+			{Name: "Phase", Type: "string"},
+			{Name: "Nodes Desired", Type: "integer"},
+			{Name: "Nodes Ready", Type: "integer"},
+		},
+	}
+
+	for _, np := range nodePools {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []interface{}{
+				np.Name,
+				np.Kind,
+				valueOrNA(np.Phase),
+				np.Replicas,
+				np.Ready,
+			},
+		})
+	}
+
+	return table
+}
+
+func getAppStatusTable(apps []appStatus) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Version", Type: "string"},
+			{Name: "Status", Type: "string"},
+			{Name: "Reason", Type: "string"},
+		},
+	}
+
+	for _, a := range apps {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []interface{}{
+				a.Name,
+				valueOrNA(a.Version),
+				valueOrNA(a.Status),
+				valueOrNA(a.Reason),
+			},
+		})
+	}
+
+	return table
+}
+
+func getEventTable(events []event) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Last Seen", Type: "string"},
+			{Name: "Type", Type: "string"},
+			{Name: "Reason", Type: "string"},
+			{Name: "Object", Type: "string"},
+			{Name: "Count", Type: "string"},
+			{Name: "Message", Type: "string"},
+		},
+	}
+
+	for _, e := range events {
+		count := naValue
+		if e.Count > 0 {
+			count = strconv.Itoa(int(e.Count))
+		}
+
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []interface{}{
+				formatTime(e.LastSeen),
+				e.Type,
+				e.Reason,
+				e.Object,
+				count,
+				e.Message,
+			},
+		})
+	}
+
+	return table
+}
+
+func formatAppStatus(a *appStatus) string {
+	if a == nil {
+		return naValue
+	}
+
+	s := fmt.Sprintf("%s %s, %s", a.Name, valueOrNA(a.Version), valueOrNA(a.Status))
+	if a.Reason != "" {
+		s = fmt.Sprintf("%s (%s)", s, a.Reason)
+	}
+
+	return s
+}
+
+func formatTime(t metav1.Time) string {
+	if t.IsZero() {
+		return naValue
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return naValue
+	}
+
+	return value
+}
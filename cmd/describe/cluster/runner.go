@@ -0,0 +1,157 @@
+package cluster
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/nodepool"
+)
+
+type runner struct {
+	commonConfig *commonconfig.CommonConfig
+	flag         *flag
+	logger       micrologger.Logger
+
+	provider        string
+	service         cluster.Interface
+	nodepoolService nodepool.Interface
+	appService      app.Interface
+	// ctrlClient is used to list the events of the cluster.
+	ctrlClient runtimeclient.Client
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	var err error
+
+	if len(args) < 1 {
+		return microerror.Maskf(invalidFlagError, "the name of the cluster must be given")
+	}
+
+	{
+		if r.provider == "" {
+			r.provider, err = r.commonConfig.GetProviderFromConfig(ctx, "")
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+		err = r.getServices()
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	options := cluster.GetOptions{
+		Name:           strings.ToLower(args[0]),
+		Provider:       r.provider,
+		FallbackToCapi: true,
+	}
+	options.Namespace, _, err = r.commonConfig.GetNamespace()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	resource, err := r.service.Get(ctx, options)
+	if cluster.IsNotFound(err) {
+		return microerror.Maskf(notFoundError, "A cluster with name '%s' cannot be found.\n", options.Name)
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	c, ok := resource.(*cluster.Cluster)
+	if !ok || c.Cluster == nil {
+		return microerror.Maskf(notFoundError, "A cluster with name '%s' cannot be found.\n", options.Name)
+	}
+
+	d, err := r.describe(ctx, c)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.printOutput(d)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// getNodepoolProvider returns the provider to look up the node pools with,
+// as the node pool service only tells AWS vintage and CAPI apart.
+func (r *runner) getNodepoolProvider() string {
+	if r.provider == key.ProviderAWS {
+		return key.ProviderAWS
+	}
+
+	return key.ProviderDefault
+}
+
+func (r *runner) getServices() error {
+	if r.service != nil && r.nodepoolService != nil && r.appService != nil && r.ctrlClient != nil {
+		return nil
+	}
+
+	client, err := r.commonConfig.GetClient(r.logger)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if r.ctrlClient == nil {
+		r.ctrlClient = client.CtrlClient()
+	}
+
+	if r.service == nil {
+		r.service = cluster.New(cluster.Config{
+			Client: client.CtrlClient(),
+		})
+	}
+
+	if r.nodepoolService == nil {
+		r.nodepoolService, err = nodepool.New(nodepool.Config{
+			Client: client.CtrlClient(),
+		})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if r.appService == nil {
+		r.appService, err = app.New(app.Config{
+			Client: client.CtrlClient(),
+		})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
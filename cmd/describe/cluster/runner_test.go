@@ -0,0 +1,274 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	goflag "flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/nodepool"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/scheme"
+	testapp "github.com/giantswarm/kubectl-gs/v5/test/app"
+	"github.com/giantswarm/kubectl-gs/v5/test/goldenfile"
+	"github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
+)
+
+var update = goflag.Bool("update", false, "update .golden reference test files")
+
+var created = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+// Test_run uses golden files.
+//
+// go test ./cmd/describe/cluster -run Test_run -update
+func Test_run(t *testing.T) {
+	// Scheduled updates are also shown in local time.
+	defer func(local *time.Location) {
+		time.Local = local
+	}(time.Local)
+	time.Local = time.UTC
+
+	storage := []runtime.Object{
+		newCluster("a01"),
+		testapp.NewClusterApp("a01-cluster", "default", "a01"),
+		testapp.NewClusterApp("a01-default-apps", "default", "a01"),
+		testapp.NewClusterApp("a01-coredns", "default", "a01"),
+		testapp.WithReleaseStatus(testapp.NewClusterApp("a01-kyverno", "default", "a01"), "failed", "  Helm install failed\n"),
+		testapp.WithReleaseStatus(testapp.NewClusterApp("a01-trivy", "default", "a01"), "", ""),
+		testapp.WithReleaseStatus(testapp.NewClusterApp("a02-coredns", "default", "a02"), "failed", "Helm install failed"),
+		newMachineDeployment("a01-md01", "a01", 3, 2),
+		newMachineDeployment("a01-md00", "a01", 1, 1),
+		newMachineDeployment("a02-md00", "a02", 1, 1),
+		newMachineDeployment("workers", "a01", 2, 2),
+		// Cluster a01-ext is named with the name of cluster a01 as prefix.
+		newCluster("a01-ext"),
+		newMachineDeployment("a01-ext-md00", "a01-ext", 1, 1),
+		newEvent("a01.1", "Cluster", "a01", "Normal", "Provisioned", 1),
+		newEvent("workers.1", "MachineDeployment", "workers", "Normal", "SuccessfulCreate", 5),
+		newEvent("a01-ext.1", "Cluster", "a01-ext", "Normal", "Provisioned", 6),
+		newEvent("a01-ext-md00.1", "MachineDeployment", "a01-ext-md00", "Normal", "SuccessfulCreate", 7),
+		newEvent("a01-kyverno.1", "App", "a01-kyverno", "Warning", "InstallFailed", 3),
+		newEvent("a01-md01.1", "MachineDeployment", "a01-md01", "Normal", "SuccessfulCreate", 2),
+		newEvent("a02.1", "Cluster", "a02", "Normal", "Provisioned", 4),
+	}
+
+	testCases := []struct {
+		name               string
+		args               []string
+		events             int
+		outputType         string
+		expectedGoldenFile string
+		errorMatcher       func(error) bool
+	}{
+		{
+			name:               "case 0: describe a cluster",
+			args:               []string{"a01"},
+			events:             10,
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_describe_cluster.golden",
+		},
+		{
+			name:               "case 1: describe a cluster, with the most recent event only",
+			args:               []string{"a01"},
+			events:             1,
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_describe_cluster_one_event.golden",
+		},
+		{
+			name:               "case 2: describe a cluster, with json output",
+			args:               []string{"a01"},
+			events:             10,
+			outputType:         output.TypeJSON,
+			expectedGoldenFile: "run_describe_cluster_json_output.golden",
+		},
+		{
+			name:         "case 3: describe a cluster which does not exist",
+			args:         []string{"b01"},
+			events:       10,
+			outputType:   output.TypeDefault,
+			errorMatcher: IsNotFound,
+		},
+		{
+			name:         "case 4: describe a cluster, with unsupported output",
+			args:         []string{"a01"},
+			events:       10,
+			outputType:   output.TypeName,
+			errorMatcher: IsInvalidFlag,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			flag := &flag{
+				Events: tc.events,
+				print:  genericclioptions.NewPrintFlags("").WithDefaultOutput(tc.outputType),
+			}
+
+			out := new(bytes.Buffer)
+			ctrlClient := newFakeClient(t, storage...)
+			nodepoolService, err := nodepool.New(nodepool.Config{Client: ctrlClient})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			appService, err := app.New(app.Config{Client: ctrlClient})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			runner := &runner{
+				commonConfig:    commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(kubeconfig.CreateFakeKubeConfig())),
+				flag:            flag,
+				provider:        key.ProviderCAPA,
+				service:         cluster.New(cluster.Config{Client: ctrlClient}),
+				nodepoolService: nodepoolService,
+				appService:      appService,
+				ctrlClient:      ctrlClient,
+				stdout:          out,
+			}
+
+			err = runner.run(context.TODO(), nil, tc.args)
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			var expectedResult []byte
+			{
+				gf := goldenfile.New("testdata", tc.expectedGoldenFile)
+				if *update {
+					err = gf.Update(out.Bytes())
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+					expectedResult = out.Bytes()
+				} else {
+					expectedResult, err = gf.Read()
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+				}
+			}
+
+			diff := cmp.Diff(string(expectedResult), out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func newFakeClient(t *testing.T, object ...runtime.Object) runtimeclient.Client {
+	clientScheme, err := scheme.NewScheme()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	return fake.NewClientBuilder().WithScheme(clientScheme).WithRuntimeObjects(object...).Build()
+}
+
+func newCluster(name string) *capi.Cluster {
+	return &capi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+			Labels: map[string]string{
+				label.Organization:   "acme",
+				label.ReleaseVersion: "25.0.0",
+			},
+			Annotations: map[string]string{
+				annotation.ClusterDescription:          "Production",
+				annotation.UpdateScheduleTargetRelease: "25.1.0",
+				annotation.UpdateScheduleTargetTime:    "03 Feb 26 08:00 UTC",
+			},
+		},
+		Spec: capi.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{
+				Kind: "AWSCluster",
+				Name: name,
+			},
+		},
+		Status: capi.ClusterStatus{
+			Phase:               "Provisioned",
+			InfrastructureReady: true,
+			ControlPlaneReady:   false,
+			Conditions: capi.Conditions{
+				{
+					Type:               capi.ReadyCondition,
+					Status:             corev1.ConditionFalse,
+					Severity:           capi.ConditionSeverityWarning,
+					Reason:             "ScalingUp",
+					Message:            "Scaling up control plane to 3 replicas",
+					LastTransitionTime: metav1.NewTime(created.Add(time.Hour)),
+				},
+				{
+					Type:               capi.InfrastructureReadyCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(created.Add(time.Minute)),
+				},
+			},
+		},
+	}
+}
+
+func newMachineDeployment(name, cluster string, replicas, ready int32) *capi.MachineDeployment {
+	return &capi.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				capi.ClusterNameLabel: cluster,
+			},
+		},
+		Spec: capi.MachineDeploymentSpec{
+			ClusterName: cluster,
+			Replicas:    &replicas,
+		},
+		Status: capi.MachineDeploymentStatus{
+			Phase:         "Running",
+			Replicas:      replicas,
+			ReadyReplicas: ready,
+		},
+	}
+}
+
+func newEvent(name, kind, objectName, eventType, reason string, hoursAfterCreation int) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      kind,
+			Name:      objectName,
+			Namespace: "default",
+		},
+		Type:          eventType,
+		Reason:        reason,
+		Message:       fmt.Sprintf("%s %s", kind, reason),
+		Count:         int32(hoursAfterCreation),
+		LastTimestamp: metav1.NewTime(created.Add(time.Duration(hoursAfterCreation) * time.Hour)),
+	}
+}
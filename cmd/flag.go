@@ -3,22 +3,27 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
 )
 
 const (
 	flagDebug               = "debug"
 	flagDisableVersionCheck = "disable-version-check"
+	flagRefreshInstallation = "refresh-installation-info"
 )
 
 type flag struct {
 	config              genericclioptions.RESTClientGetter
 	disableVersionCheck bool
+	installationCache   installation.CacheConfig
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
 	// This value is ignored. The real value is handled inside 'main.go'.
 	cmd.PersistentFlags().Bool(flagDebug, false, "Toggle debug mode, for seeing full error output.")
 	cmd.PersistentFlags().BoolVar(&f.disableVersionCheck, flagDisableVersionCheck, false, "Disable self-update version check.")
+	cmd.PersistentFlags().BoolVar(&f.installationCache.Refresh, flagRefreshInstallation, false, "Fetch the installation info from Athena again, instead of using the info cached for up to 24 hours.")
 
 	f.config = genericclioptions.NewConfigFlags(true)
 	f.config.(*genericclioptions.ConfigFlags).AddFlags(cmd.PersistentFlags())
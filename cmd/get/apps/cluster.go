@@ -0,0 +1,192 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/organization"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/pluralize"
+)
+
+// clusterApp is an app of one of the clusters given with --cluster-name.
+type clusterApp struct {
+	app.App
+	Cluster string
+}
+
+// clusterApps lists the apps of the clusters given with --cluster-name. These
+// live in the namespace of the organization owning the cluster, which is
+// looked up when neither --organization nor --namespace are given.
+func (r *runner) clusterApps(ctx context.Context, options app.GetOptions) error {
+	namespaces, err := r.getClusterNamespaces(ctx, options.Namespace)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var apps []clusterApp
+	// clusterNames caches the names of the clusters per namespace, to tell
+	// the apps of clusters with similar names apart.
+	clusterNames := map[string][]string{}
+	for _, namespace := range namespaces {
+		appResource, err := r.service.Get(ctx, app.GetOptions{Namespace: namespace})
+		if app.IsNoResources(err) {
+			continue
+		} else if app.IsNoMatch(err) {
+			r.printNoMatchOutput()
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		for _, item := range appResource.(*app.Collection).Items {
+			if item.CR.Spec.KubeConfig.InCluster && !r.flag.IncludeInCluster {
+				continue
+			}
+
+			names, ok := clusterNames[item.CR.Namespace]
+			if !ok {
+				names, err = r.service.GetClusterNames(ctx, item.CR.Namespace)
+				if err != nil {
+					return microerror.Mask(err)
+				}
+				clusterNames[item.CR.Namespace] = names
+			}
+
+			for _, cluster := range r.flag.Cluster {
+				if app.IsClusterApp(item.CR, cluster, names) {
+					apps = append(apps, clusterApp{App: item, Cluster: cluster})
+					break
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(apps, func(i, j int) bool {
+		if apps[i].Cluster != apps[j].Cluster {
+			return apps[i].Cluster < apps[j].Cluster
+		}
+		if apps[i].CR.Namespace != apps[j].CR.Namespace {
+			return apps[i].CR.Namespace < apps[j].CR.Namespace
+		}
+		return apps[i].CR.Name < apps[j].CR.Name
+	})
+
+	if !output.IsOutputDefault(r.flag.print.OutputFormat) {
+		collection := &app.Collection{}
+		for _, a := range apps {
+			collection.Items = append(collection.Items, a.App)
+		}
+
+		err = r.printOutput(collection)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	if len(apps) == 0 {
+		fmt.Fprintf(r.stdout, "No apps of %s '%s' found.\n", pluralize.Pluralize("cluster", len(r.flag.Cluster)), strings.Join(r.flag.Cluster, "', '"))
+		return nil
+	}
+
+	err = r.printClusterAppsTable(apps)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// getClusterNamespaces returns the namespaces to look for the apps of the
+// clusters in.
+func (r *runner) getClusterNamespaces(ctx context.Context, namespace string) ([]string, error) {
+	if r.flag.Organization != "" {
+		err := r.getOrganizationService()
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		org, err := r.organizationService.Get(ctx, organization.GetOptions{Name: r.flag.Organization})
+		if organization.IsNotFound(err) {
+			return nil, microerror.Maskf(notFoundError, "An organization '%s' cannot be found.\n", r.flag.Organization)
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		orgNamespace := org.(*organization.Organization).Organization.Status.Namespace
+		if orgNamespace == "" {
+			return nil, microerror.Maskf(notFoundError, "The namespace of organization '%s' is not known yet.\n", r.flag.Organization)
+		}
+
+		return []string{orgNamespace}, nil
+	}
+
+	if r.flag.AllNamespaces {
+		return []string{metav1.NamespaceAll}, nil
+	}
+
+	_, overridden, err := r.commonConfig.GetNamespace()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if overridden {
+		return []string{namespace}, nil
+	}
+
+	err = r.getOrganizationService()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	orgs, err := r.organizationService.Get(ctx, organization.GetOptions{})
+	if organization.IsNoResources(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var namespaces []string
+	for _, org := range orgs.(*organization.Collection).Items {
+		if org.Organization.Status.Namespace != "" {
+			namespaces = append(namespaces, org.Organization.Status.Namespace)
+		}
+	}
+
+	return namespaces, nil
+}
+
+// printClusterAppsTable prints the apps of the clusters. Across all
+// namespaces, the apps are grouped by cluster.
+func (r *runner) printClusterAppsTable(apps []clusterApp) error {
+	table := getTable(&app.Collection{})
+	if r.flag.AllNamespaces {
+		table.ColumnDefinitions = append([]metav1.TableColumnDefinition{{Name: "Cluster", Type: "string"}}, table.ColumnDefinitions...)
+	}
+
+	for _, a := range apps {
+		row := getAppRow(a.App)
+		if r.flag.AllNamespaces {
+			row.Cells = append([]interface{}{a.Cluster}, row.Cells...)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	printer := printers.NewTablePrinter(printers.PrintOptions{
+		WithNamespace: r.flag.AllNamespaces,
+	})
+	err := printer.PrintObj(table, r.stdout)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
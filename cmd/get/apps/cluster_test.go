@@ -0,0 +1,168 @@
+package apps
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	securityv1alpha1 "github.com/giantswarm/organization-operator/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/organization"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	testapp "github.com/giantswarm/kubectl-gs/v5/test/app"
+	"github.com/giantswarm/kubectl-gs/v5/test/goldenfile"
+	"github.com/giantswarm/kubectl-gs/v5/test/kubeclient"
+	"github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
+)
+
+// Test_run_clusterApps uses golden files.
+//
+// go test ./cmd/get/apps -run Test_run_clusterApps -update
+func Test_run_clusterApps(t *testing.T) {
+	testCases := []struct {
+		name               string
+		flags              flag
+		expectedGoldenFile string
+		errorMatcher       func(error) bool
+	}{
+		{
+			name:               "case 0: get the apps of a cluster, looking up its organization",
+			flags:              flag{Cluster: []string{"a01"}},
+			expectedGoldenFile: "run_get_cluster_apps.golden",
+		},
+		{
+			name:               "case 1: get the apps of a cluster of an organization, including in-cluster apps",
+			flags:              flag{Cluster: []string{"a01"}, Organization: "acme", IncludeInCluster: true},
+			expectedGoldenFile: "run_get_cluster_apps_in_cluster.golden",
+		},
+		{
+			name:               "case 2: get the apps of clusters in all namespaces",
+			flags:              flag{Cluster: []string{"b01", "a01"}, AllNamespaces: true},
+			expectedGoldenFile: "run_get_cluster_apps_all_namespaces.golden",
+		},
+		{
+			name:               "case 3: get the apps of a cluster without apps",
+			flags:              flag{Cluster: []string{"c01"}},
+			expectedGoldenFile: "run_get_cluster_apps_none.golden",
+		},
+		{
+			name:               "case 4: get the apps of a cluster named with the name of another cluster as prefix",
+			flags:              flag{Cluster: []string{"a01-ext"}, IncludeInCluster: true},
+			expectedGoldenFile: "run_get_cluster_apps_longer_name.golden",
+		},
+		{
+			name:         "case 5: get the apps of a cluster of an unknown organization",
+			flags:        flag{Cluster: []string{"a01"}, Organization: "unknown"},
+			errorMatcher: IsNotFound,
+		},
+	}
+
+	apps := []runtime.Object{
+		testapp.WithInCluster(testapp.NewClusterApp("a01", "org-acme", "")),
+		testapp.WithInCluster(testapp.NewClusterApp("a01-default-apps", "org-acme", "")),
+		testapp.NewClusterApp("a01-coredns", "org-acme", "a01"),
+		testapp.NewClusterApp("a01-ingress-nginx", "org-acme", "a01"),
+		testapp.NewClusterApp("a02-coredns", "org-acme", "a02"),
+		testapp.NewClusterApp("b01-coredns", "org-beta", "b01"),
+		// Cluster a01-ext has apps named with the name of cluster a01 as
+		// prefix, without cluster label.
+		testapp.WithInCluster(testapp.NewClusterApp("a01-ext", "org-acme", "")),
+		testapp.WithInCluster(testapp.NewClusterApp("a01-ext-default-apps", "org-acme", "")),
+		newCluster("a01", "org-acme"),
+		newCluster("a01-ext", "org-acme"),
+		newCluster("b01", "org-beta"),
+	}
+	orgs := []runtime.Object{
+		newOrganization("acme"),
+		newOrganization("beta"),
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			flag := &tc.flags
+			flag.print = genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault)
+
+			out := new(bytes.Buffer)
+			runner := &runner{
+				commonConfig:        commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(kubeconfig.CreateFakeKubeConfig())),
+				flag:                flag,
+				service:             testapp.NewService(t, apps...),
+				organizationService: newOrganizationService(t, orgs...),
+				stdout:              out,
+			}
+
+			err := runner.run(context.TODO(), nil, nil)
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			var expectedResult []byte
+			{
+				gf := goldenfile.New("testdata", tc.expectedGoldenFile)
+				if *update {
+					err = gf.Update(out.Bytes())
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+					expectedResult = out.Bytes()
+				} else {
+					expectedResult, err = gf.Read()
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+				}
+			}
+
+			diff := cmp.Diff(string(expectedResult), out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func newOrganizationService(t *testing.T, object ...runtime.Object) organization.Interface {
+	client := kubeclient.FakeK8sClient(object...)
+	client.AddSubjectAccess(true)
+
+	service, err := organization.New(organization.Config{
+		Client: client,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	return service
+}
+
+func newCluster(name, namespace string) *capi.Cluster {
+	return &capi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+func newOrganization(name string) *securityv1alpha1.Organization {
+	return &securityv1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: securityv1alpha1.OrganizationStatus{
+			Namespace: "org-" + name,
+		},
+	}
+}
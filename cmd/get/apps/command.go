@@ -12,6 +12,7 @@ import (
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
 )
 
@@ -27,13 +28,53 @@ Output columns:
 - NAME: Name of the app.
 - VERSION: Version of the app.
 - LAST DEPLOYED: When the app was last deployed.
-- STATUS: Status of the app.`
+- STATUS: Status of the app.
+
+With --history, the versions an app has been deployed with are listed
+instead. The history is read from the Helm release secrets in the cluster
+the app is deployed to. If they cannot be read, the versions recorded by
+'kubectl gs update app' are shown, with a note explaining why.
+
+With --outdated, the deployed version of each app is compared with the latest
+version in its catalog, taken from the AppCatalogEntry CRs or the catalog's
+index.yaml. Each app is classified as a patch, minor or major version behind,
+and the results are summarized per cluster and organization. Use
+--output json or --output yaml for further processing.
+
+With --tree, the apps are grouped by the bundles and apps they are managed by,
+as given by their giantswarm.io/managed-by label. The ROLLED UP column shows
+the first status other than "deployed" found among the children of an app.
+
+With --cluster-name, only the apps of the given workload clusters are listed. They
+are looked up in the namespace of the given --organization, or in the given
+--namespace. Otherwise all organization namespaces are searched. Apps deployed
+to the management cluster itself, like the cluster app, are left out unless
+--include-in-cluster is given. With --all-namespaces, a CLUSTER column is added
+and the apps are grouped by cluster.`
 
 	examples = `  # List all apps for the current namespace
   kubectl gs get apps
   
   # Get one app by its name
-  kubectl gs get app coredns`
+  kubectl gs get app coredns
+
+  # Watch the apps in the current namespace for changes
+  kubectl gs get apps --watch
+
+  # Show the versions an app has been deployed with
+  kubectl gs get app coredns --history
+
+  # Report the outdated apps of all workload clusters
+  kubectl gs get apps --outdated --all-namespaces
+
+  # Show the apps grouped by the bundles they belong to
+  kubectl gs get apps --tree
+
+  # List the apps of a workload cluster of an organization
+  kubectl gs get apps --cluster-name a01 --organization acme
+
+  # List the apps of several workload clusters, grouped by cluster
+  kubectl gs get apps --cluster-name a01,b01 --all-namespaces`
 )
 
 type Config struct {
@@ -87,6 +128,7 @@ func New(config Config) (*cobra.Command, error) {
 		RunE:    r.Run,
 		PreRunE: middleware.Compose(
 			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
 		),
 	}
 
@@ -1,22 +1,50 @@
 package apps
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 const (
-	flagAllNamespaces = "all-namespaces"
+	flagAllNamespaces    = "all-namespaces"
+	flagClusterName      = "cluster-name"
+	flagIncludeInCluster = "include-in-cluster"
+	flagOrganization     = "organization"
+	flagHistory          = "history"
+	flagOutdated         = "outdated"
+	flagTree             = "tree"
+	flagWatch            = "watch"
+	flagWatchOnly        = "watch-only"
 )
 
 type flag struct {
-	AllNamespaces bool
+	AllNamespaces    bool
+	Cluster          []string
+	IncludeInCluster bool
+	Organization     string
+	History          bool
+	Outdated         bool
+	Tree             bool
+	Watch            bool
+	WatchOnly        bool
 
 	print *genericclioptions.PrintFlags
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&f.AllNamespaces, flagAllNamespaces, "A", false, "If present, list the requested object(s) across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().BoolVarP(&f.Watch, flagWatch, "w", false, "After listing/getting the requested object(s), watch for changes.")
+	cmd.Flags().BoolVar(&f.WatchOnly, flagWatchOnly, false, "Watch for changes to the requested object(s), without listing/getting first.")
+	cmd.Flags().BoolVar(&f.History, flagHistory, false, "Display the versions the app has been deployed with.")
+	cmd.Flags().StringSliceVarP(&f.Cluster, flagClusterName, "c", nil, "List the apps of the given workload cluster(s) only.")
+	cmd.Flags().StringVar(&f.Organization, flagOrganization, "", fmt.Sprintf("Organization owning the cluster(s) given with --%s.", flagClusterName))
+	cmd.Flags().BoolVar(&f.IncludeInCluster, flagIncludeInCluster, false, fmt.Sprintf("With --%s, also list the apps deployed to the management cluster for the cluster, like the cluster app.", flagClusterName))
+	cmd.Flags().BoolVar(&f.Tree, flagTree, false, "Group the apps by the bundles and apps they are managed by, rolling up the status of the children.")
+	cmd.Flags().BoolVar(&f.Outdated, flagOutdated, false, "Compare the deployed versions with the latest versions in the catalogs, and summarize how far the apps are behind.")
 
 	f.print = genericclioptions.NewPrintFlags("")
 
@@ -26,5 +54,37 @@ func (f *flag) Init(cmd *cobra.Command) {
 }
 
 func (f *flag) Validate() error {
+	var modes []string
+	for _, mode := range []struct {
+		name string
+		set  bool
+	}{
+		{flagHistory, f.History},
+		{flagOutdated, f.Outdated},
+		{flagTree, f.Tree},
+	} {
+		if mode.set {
+			modes = append(modes, "--"+mode.name)
+		}
+	}
+	if len(modes) > 1 {
+		return microerror.Maskf(invalidFlagError, "only one of %s can be given", strings.Join(modes, ", "))
+	}
+	if len(modes) > 0 && (f.Watch || f.WatchOnly) {
+		return microerror.Maskf(invalidFlagError, "%s cannot be combined with --%s or --%s", modes[0], flagWatch, flagWatchOnly)
+	}
+	if len(f.Cluster) > 0 && (len(modes) > 0 || f.Watch || f.WatchOnly) {
+		return microerror.Maskf(invalidFlagError, "--%s cannot be combined with %s", flagClusterName, strings.Join(append(modes, "--"+flagWatch, "--"+flagWatchOnly), ", "))
+	}
+	if f.Organization != "" && len(f.Cluster) == 0 {
+		return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagOrganization, flagClusterName)
+	}
+	if f.IncludeInCluster && len(f.Cluster) == 0 {
+		return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagIncludeInCluster, flagClusterName)
+	}
+	if f.History && f.AllNamespaces {
+		return microerror.Maskf(invalidFlagError, "--%s cannot be combined with --%s", flagHistory, flagAllNamespaces)
+	}
+
 	return nil
 }
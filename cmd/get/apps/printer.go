@@ -1,17 +1,23 @@
 package apps
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/giantswarm/microerror"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/yaml"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
 )
 
+const naValue = "n/a"
+
 func (r *runner) printOutput(appResource app.Resource) error {
 	var (
 		err      error
@@ -23,6 +29,7 @@ func (r *runner) printOutput(appResource app.Resource) error {
 	case output.IsOutputDefault(r.flag.print.OutputFormat):
 		resource = getTable(appResource)
 		printOptions := printers.PrintOptions{
+			NoHeaders:     r.noHeaders,
 			WithNamespace: r.flag.AllNamespaces,
 		}
 		printer = printers.NewTablePrinter(printOptions)
@@ -51,6 +58,185 @@ func (r *runner) printOutput(appResource app.Resource) error {
 	return nil
 }
 
+func (r *runner) printHistoryOutput(history *app.History) error {
+	switch *r.flag.print.OutputFormat {
+	case output.TypeJSON:
+		data, err := json.MarshalIndent(history, "", "    ")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprintf(r.stdout, "%s\n", data)
+
+	case output.TypeYAML:
+		data, err := yaml.Marshal(history)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprint(r.stdout, string(data))
+
+	case output.TypeDefault:
+		table := &metav1.Table{
+			ColumnDefinitions: []metav1.TableColumnDefinition{
+				{Name: "Revision", Type: "string"},
+				{Name: "Version", Type: "string"},
+				{Name: "Deployed", Type: "string", Format: "date-time"},
+				{Name: "Status", Type: "string"},
+			},
+		}
+
+		for _, entry := range history.Entries {
+			table.Rows = append(table.Rows, getHistoryRow(entry))
+		}
+
+		printer := printers.NewTablePrinter(printers.PrintOptions{})
+		err := printer.PrintObj(table, r.stdout)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if history.Source == app.HistorySourceAnnotation {
+			fmt.Fprintf(r.stderr, "\nThe history has been reconstructed from the versions recorded by 'kubectl gs update app', as the Helm release history is not available: %s\n", history.Warning)
+		}
+
+	default:
+		return microerror.Maskf(invalidFlagError, "--%s supports the output formats %s and %s only", flagHistory, output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
+
+func getHistoryRow(entry app.HistoryEntry) metav1.TableRow {
+	deployed := naValue
+	if entry.Deployed != nil {
+		deployed = entry.Deployed.UTC().Format(time.RFC3339)
+	}
+
+	status := entry.Status
+	if status == "" {
+		status = naValue
+	}
+
+	return metav1.TableRow{
+		Cells: []interface{}{
+			strconv.Itoa(entry.Revision),
+			entry.Version,
+			deployed,
+			status,
+		},
+	}
+}
+
+func (r *runner) printOutdatedOutput(report *app.OutdatedReport) error {
+	switch *r.flag.print.OutputFormat {
+	case output.TypeJSON:
+		data, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprintf(r.stdout, "%s\n", data)
+
+	case output.TypeYAML:
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprint(r.stdout, string(data))
+
+	case output.TypeDefault:
+		printer := printers.NewTablePrinter(printers.PrintOptions{})
+
+		tables := []*metav1.Table{
+			getOutdatedTable(report, r.flag.AllNamespaces),
+			getOutdatedSummaryTable(report.Clusters, true),
+			getOutdatedSummaryTable(report.Organizations, false),
+		}
+		for i, table := range tables {
+			if i > 0 {
+				fmt.Fprintln(r.stdout)
+			}
+
+			err := printer.PrintObj(table, r.stdout)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+	default:
+		return microerror.Maskf(invalidFlagError, "--%s supports the output formats %s and %s only", flagOutdated, output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
+
+func getOutdatedTable(report *app.OutdatedReport, withNamespace bool) *metav1.Table {
+	table := &metav1.Table{}
+	if withNamespace {
+		table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{Name: "Namespace", Type: "string"})
+	}
+	table.ColumnDefinitions = append(table.ColumnDefinitions,
+		metav1.TableColumnDefinition{Name: "Name", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Cluster", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Version", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Latest", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Behind", Type: "string"},
+	)
+
+	for _, a := range report.Apps {
+		var cells []interface{}
+		if withNamespace {
+			cells = append(cells, a.Namespace)
+		}
+		cells = append(cells,
+			a.Name,
+			valueOrNA(a.Cluster),
+			a.Version,
+			valueOrNA(a.LatestVersion),
+			string(a.Behind),
+		)
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: cells})
+	}
+
+	return table
+}
+
+func getOutdatedSummaryTable(summaries []app.OutdatedSummary, withCluster bool) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Organization", Type: "string"},
+		},
+	}
+	if withCluster {
+		table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{Name: "Cluster", Type: "string"})
+	}
+	table.ColumnDefinitions = append(table.ColumnDefinitions,
+		metav1.TableColumnDefinition{Name: "Apps", Type: "integer"},
+		metav1.TableColumnDefinition{Name: "Outdated", Type: "integer"},
+		metav1.TableColumnDefinition{Name: "Patch", Type: "integer"},
+		metav1.TableColumnDefinition{Name: "Minor", Type: "integer"},
+		metav1.TableColumnDefinition{Name: "Major", Type: "integer"},
+		metav1.TableColumnDefinition{Name: "Unknown", Type: "integer"},
+	)
+
+	for _, s := range summaries {
+		cells := []interface{}{valueOrNA(s.Organization)}
+		if withCluster {
+			cells = append(cells, valueOrNA(s.Cluster))
+		}
+		cells = append(cells, s.Apps, s.Outdated(), s.Patch, s.Minor, s.Major, s.Unknown)
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: cells})
+	}
+
+	return table
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return naValue
+	}
+
+	return value
+}
+
 func (r *runner) printNoMatchOutput() {
 	fmt.Fprintf(r.stdout, "No App CRD found.\n")
 	fmt.Fprintf(r.stdout, "Please check you are accessing a management cluster\n\n")
@@ -0,0 +1,103 @@
+package apps
+
+import (
+	"bytes"
+	goflag "flag"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/test/goldenfile"
+)
+
+var update = goflag.Bool("update", false, "update .golden reference test files")
+
+// Test_printOutdatedOutput uses golden files.
+//
+// go test ./cmd/get/apps -run Test_printOutdatedOutput -update
+func Test_printOutdatedOutput(t *testing.T) {
+	testCases := []struct {
+		name               string
+		outputType         string
+		allNamespaces      bool
+		expectedGoldenFile string
+	}{
+		{
+			name:               "case 0: print outdated apps, with table output",
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "print_outdated_table_output.golden",
+		},
+		{
+			name:               "case 1: print outdated apps of all namespaces, with table output",
+			outputType:         output.TypeDefault,
+			allNamespaces:      true,
+			expectedGoldenFile: "print_outdated_all_namespaces_table_output.golden",
+		},
+		{
+			name:               "case 2: print outdated apps, with JSON output",
+			outputType:         output.TypeJSON,
+			expectedGoldenFile: "print_outdated_json_output.golden",
+		},
+	}
+
+	report := &app.OutdatedReport{
+		Apps: []app.OutdatedApp{
+			{Namespace: "org-acme", Name: "a01-coredns", Cluster: "a01", Organization: "acme", Version: "1.2.3", LatestVersion: "1.2.4", Behind: app.LagPatch},
+			{Namespace: "org-acme", Name: "a01-ingress", Cluster: "a01", Organization: "acme", Version: "1.9.0", LatestVersion: "2.0.0", Behind: app.LagMajor},
+			{Namespace: "org-acme", Name: "a02-coredns", Cluster: "a02", Organization: "acme", Version: "1.2.4", LatestVersion: "1.2.4", Behind: app.LagNone},
+			{Namespace: "org-acme", Name: "a02-unlisted", Cluster: "a02", Organization: "acme", Version: "0.1.0", Behind: app.LagUnknown, Reason: "no version found in the catalog"},
+		},
+		Clusters: []app.OutdatedSummary{
+			{Organization: "acme", Cluster: "a01", Apps: 2, Patch: 1, Major: 1},
+			{Organization: "acme", Cluster: "a02", Apps: 2, Unknown: 1},
+		},
+		Organizations: []app.OutdatedSummary{
+			{Organization: "acme", Apps: 4, Patch: 1, Major: 1, Unknown: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			flag := &flag{
+				AllNamespaces: tc.allNamespaces,
+				Outdated:      true,
+				print:         genericclioptions.NewPrintFlags("").WithDefaultOutput(tc.outputType),
+			}
+			out := new(bytes.Buffer)
+			runner := &runner{
+				flag:   flag,
+				stdout: out,
+			}
+
+			err := runner.printOutdatedOutput(report)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			var expectedResult []byte
+			{
+				gf := goldenfile.New("testdata", tc.expectedGoldenFile)
+				if *update {
+					err = gf.Update(out.Bytes())
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+					expectedResult = out.Bytes()
+				} else {
+					expectedResult, err = gf.Read()
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+				}
+			}
+
+			diff := cmp.Diff(string(expectedResult), out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
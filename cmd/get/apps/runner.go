@@ -5,14 +5,19 @@ import (
 	"io"
 	"strings"
 
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
+	"github.com/giantswarm/kubectl-gs/v5/cmd/get/apps/tree"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/organization"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
 )
 
@@ -22,7 +27,12 @@ type runner struct {
 	logger       micrologger.Logger
 	fs           afero.Fs
 
-	service app.Interface
+	service             app.Interface
+	organizationService organization.Interface
+
+	// noHeaders is set once the first table has been printed in watch mode,
+	// so that subsequent rows line up below it.
+	noHeaders bool
 
 	stdout io.Writer
 	stderr io.Writer
@@ -73,12 +83,36 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		}
 	}
 
+	options := app.GetOptions{
+		Namespace: namespace,
+		Name:      name,
+	}
+
+	if r.flag.Watch || r.flag.WatchOnly {
+		return r.watch(ctx, options)
+	}
+
+	if len(r.flag.Cluster) > 0 {
+		if name != "" {
+			return microerror.Maskf(invalidFlagError, "--%s cannot be combined with the name of an app", flagClusterName)
+		}
+		return r.clusterApps(ctx, options)
+	}
+
+	if r.flag.History {
+		return r.history(ctx, options)
+	}
+
+	if r.flag.Outdated {
+		return r.outdated(ctx, options)
+	}
+
+	if r.flag.Tree {
+		return r.tree(ctx, options)
+	}
+
 	var appResource app.Resource
 	{
-		options := app.GetOptions{
-			Namespace: namespace,
-			Name:      name,
-		}
 		appResource, err = r.service.Get(ctx, options)
 		if app.IsNotFound(err) {
 			return microerror.Maskf(notFoundError, "An app '%s/%s' cannot be found.\n", options.Namespace, options.Name)
@@ -101,12 +135,175 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	return nil
 }
 
+func (r *runner) history(ctx context.Context, options app.GetOptions) error {
+	if options.Name == "" {
+		return microerror.Maskf(invalidFlagError, "--%s requires the name of an app", flagHistory)
+	}
+
+	history, err := r.service.GetHistory(ctx, options)
+	if app.IsNotFound(err) {
+		return microerror.Maskf(notFoundError, "An app '%s/%s' cannot be found.\n", options.Namespace, options.Name)
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.printHistoryOutput(history)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) outdated(ctx context.Context, options app.GetOptions) error {
+	report, err := r.service.GetOutdated(ctx, options)
+	if app.IsNotFound(err) {
+		return microerror.Maskf(notFoundError, "An app '%s/%s' cannot be found.\n", options.Namespace, options.Name)
+	} else if app.IsNoMatch(err) {
+		r.printNoMatchOutput()
+		return nil
+	} else if app.IsNoResources(err) && output.IsOutputDefault(r.flag.print.OutputFormat) {
+		r.printNoResourcesOutput()
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.printOutdatedOutput(report)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) tree(ctx context.Context, options app.GetOptions) error {
+	// The owners and children of the app are needed as well, so all apps
+	// of the namespace are listed.
+	appResource, err := r.service.Get(ctx, app.GetOptions{Namespace: options.Namespace})
+	if app.IsNoMatch(err) {
+		r.printNoMatchOutput()
+		return nil
+	} else if app.IsNoResources(err) && options.Name == "" && output.IsOutputDefault(r.flag.print.OutputFormat) {
+		r.printNoResourcesOutput()
+		return nil
+	} else if err != nil && !app.IsNoResources(err) {
+		return microerror.Mask(err)
+	}
+
+	var apps []*applicationv1alpha1.App
+	if collection, ok := appResource.(*app.Collection); ok {
+		for _, item := range collection.Items {
+			apps = append(apps, item.CR)
+		}
+	}
+
+	nodes := app.BuildTree(apps)
+	if options.Name != "" {
+		node := app.FindTreeNode(nodes, options.Name)
+		if node == nil {
+			return microerror.Maskf(notFoundError, "An app '%s/%s' cannot be found.\n", options.Namespace, options.Name)
+		}
+		nodes = []*app.TreeNode{node}
+	}
+
+	err = tree.Print(r.stdout, *r.flag.print.OutputFormat, nodes, r.flag.AllNamespaces)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) watch(ctx context.Context, options app.GetOptions) error {
+	events, err := r.service.Watch(ctx, options)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !r.flag.WatchOnly {
+		appResource, err := r.service.Get(ctx, options)
+		switch {
+		case app.IsNotFound(err):
+			return microerror.Maskf(notFoundError, "An app '%s/%s' cannot be found.\n", options.Namespace, options.Name)
+		case app.IsNoResources(err):
+			// There is nothing to list yet, but apps may still be created
+			// while watching.
+		case err != nil:
+			return microerror.Mask(err)
+		default:
+			err = r.printWatchEvent(watch.Added, appResource)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	for event := range events {
+		if event.Type == watch.Error {
+			return microerror.Mask(event.Err)
+		}
+
+		err = r.printWatchEvent(event.Type, event.Resource)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *runner) printWatchEvent(eventType watch.EventType, appResource app.Resource) error {
+	if !output.IsOutputDefault(r.flag.print.OutputFormat) {
+		err := output.PrintWatchEvent(r.stdout, r.flag.print, eventType, appResource.Object())
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err := r.printOutput(appResource)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	r.noHeaders = true
+
+	return nil
+}
+
+func (r *runner) getOrganizationService() error {
+	if r.organizationService != nil {
+		return nil
+	}
+
+	client, err := r.commonConfig.GetClient(r.logger)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.organizationService, err = organization.New(organization.Config{
+		Client: client,
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
 func (r *runner) getService() error {
 	if r.service != nil {
 		return nil
 	}
 
-	client, err := r.commonConfig.GetClient(r.logger)
+	var client k8sclient.Interface
+	var err error
+	if r.flag.Watch || r.flag.WatchOnly {
+		client, err = r.commonConfig.GetWatchingClient(r.logger)
+	} else {
+		client, err = r.commonConfig.GetClient(r.logger)
+	}
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -0,0 +1,109 @@
+// Package tree prints apps grouped by their owners, as built by
+// app.BuildTree. It is shared by 'get apps --tree' and 'get cluster --apps'.
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+)
+
+const naValue = "n/a"
+
+// Print prints the tree as a table, or as JSON or YAML.
+func Print(out io.Writer, outputFormat string, nodes []*app.TreeNode, withNamespace bool) error {
+	switch outputFormat {
+	case output.TypeJSON:
+		data, err := json.MarshalIndent(nodes, "", "    ")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprintf(out, "%s\n", data)
+
+	case output.TypeYAML:
+		data, err := yaml.Marshal(nodes)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprint(out, string(data))
+
+	case output.TypeDefault:
+		printer := printers.NewTablePrinter(printers.PrintOptions{})
+		err := printer.PrintObj(GetTable(nodes, withNamespace), out)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+	default:
+		return microerror.Maskf(invalidFlagError, "the tree view supports the output formats %s and %s only", output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
+
+// GetTable returns the tree as a table, with the names of the apps indented
+// below their owners.
+func GetTable(nodes []*app.TreeNode, withNamespace bool) *metav1.Table {
+	table := &metav1.Table{}
+	if withNamespace {
+		table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{Name: "Namespace", Type: "string"})
+	}
+	table.ColumnDefinitions = append(table.ColumnDefinitions,
+		metav1.TableColumnDefinition{Name: "Name", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Version", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Status", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Rolled Up", Type: "string"},
+	)
+
+	var addRows func(children []*app.TreeNode, lastSiblings []bool)
+	addRows = func(children []*app.TreeNode, lastSiblings []bool) {
+		for i, child := range children {
+			childLastSiblings := append(append([]bool{}, lastSiblings...), i == len(children)-1)
+
+			table.Rows = append(table.Rows, getRow(child, childLastSiblings, withNamespace))
+			addRows(child.Children, childLastSiblings)
+		}
+	}
+	for _, root := range nodes {
+		table.Rows = append(table.Rows, getRow(root, nil, withNamespace))
+		addRows(root.Children, nil)
+	}
+
+	return table
+}
+
+func getRow(node *app.TreeNode, lastSiblings []bool, withNamespace bool) metav1.TableRow {
+	rolledUp := "-"
+	if node.Descendants > 0 {
+		rolledUp = fmt.Sprintf("%s (%d/%d deployed)", valueOrNA(node.RolledUpStatus), node.DeployedDescendants, node.Descendants)
+	}
+
+	var cells []interface{}
+	if withNamespace {
+		cells = append(cells, node.Namespace)
+	}
+	cells = append(cells,
+		output.TreePrefix(lastSiblings)+node.Name,
+		node.Version,
+		valueOrNA(node.Status),
+		rolledUp,
+	)
+
+	return metav1.TableRow{Cells: cells}
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return naValue
+	}
+
+	return value
+}
@@ -0,0 +1,97 @@
+package tree
+
+import (
+	"bytes"
+	goflag "flag"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/test/goldenfile"
+)
+
+var update = goflag.Bool("update", false, "update .golden reference test files")
+
+// Test_Print uses golden files.
+//
+// go test ./cmd/get/apps/tree -run Test_Print -update
+func Test_Print(t *testing.T) {
+	testCases := []struct {
+		name               string
+		outputType         string
+		withNamespace      bool
+		expectedGoldenFile string
+	}{
+		{
+			name:               "case 0: print tree, with table output",
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "print_tree_table_output.golden",
+		},
+		{
+			name:               "case 1: print tree, with namespace",
+			outputType:         output.TypeDefault,
+			withNamespace:      true,
+			expectedGoldenFile: "print_tree_table_output_with_namespace.golden",
+		},
+		{
+			name:               "case 2: print tree, with YAML output",
+			outputType:         output.TypeYAML,
+			expectedGoldenFile: "print_tree_yaml_output.golden",
+		},
+	}
+
+	nodes := []*app.TreeNode{
+		{
+			Namespace: "org-acme", Name: "a01", Version: "1.0.0", Status: "deployed", RolledUpStatus: "failed", Descendants: 4, DeployedDescendants: 3,
+			Children: []*app.TreeNode{
+				{
+					Namespace: "org-acme", Name: "a01-default-apps", Version: "1.0.0", Status: "deployed", RolledUpStatus: "failed", Descendants: 3, DeployedDescendants: 2,
+					Children: []*app.TreeNode{
+						{
+							Namespace: "org-acme", Name: "a01-security-bundle", Version: "1.2.0", Status: "deployed", RolledUpStatus: "failed", Descendants: 1,
+							Children: []*app.TreeNode{
+								{Namespace: "org-acme", Name: "a01-kyverno", Version: "0.17.0", Status: "failed", RolledUpStatus: "failed"},
+							},
+						},
+						{Namespace: "org-acme", Name: "a01-coredns", Version: "1.21.0", Status: "deployed", RolledUpStatus: "deployed"},
+					},
+				},
+			},
+		},
+		{Namespace: "org-acme", Name: "hello-world", Version: "2.0.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			err := Print(out, tc.outputType, nodes, tc.withNamespace)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			var expectedResult []byte
+			{
+				gf := goldenfile.New("testdata", tc.expectedGoldenFile)
+				if *update {
+					err = gf.Update(out.Bytes())
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+					expectedResult = out.Bytes()
+				} else {
+					expectedResult, err = gf.Read()
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+				}
+			}
+
+			diff := cmp.Diff(string(expectedResult), out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
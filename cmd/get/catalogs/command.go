@@ -12,6 +12,7 @@ import (
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
 )
 
@@ -46,7 +47,10 @@ Output columns:
   kubectl gs get catalogs
 
   # List all available apps for a catalog
-  kubectl gs get catalog giantswarm`
+  kubectl gs get catalog giantswarm
+
+  # Watch the catalogs in the current namespace for changes
+  kubectl gs get catalogs --watch`
 )
 
 type Config struct {
@@ -100,6 +104,7 @@ func New(config Config) (*cobra.Command, error) {
 		RunE:    r.Run,
 		PreRunE: middleware.Compose(
 			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
 		),
 	}
 
@@ -8,11 +8,15 @@ import (
 const (
 	flagAllNamespaces = "all-namespaces"
 	flagMaxColWidth   = "max-col-width"
+	flagWatch         = "watch"
+	flagWatchOnly     = "watch-only"
 )
 
 type flag struct {
 	AllNamespaces bool
 	MaxColWidth   uint
+	Watch         bool
+	WatchOnly     bool
 
 	print *genericclioptions.PrintFlags
 }
@@ -20,6 +24,8 @@ type flag struct {
 func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&f.AllNamespaces, flagAllNamespaces, "A", false, "If present, list the requested object(s) across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
 	cmd.Flags().UintVar(&f.MaxColWidth, flagMaxColWidth, 80, "maximum column width for output table")
+	cmd.Flags().BoolVarP(&f.Watch, flagWatch, "w", false, "After listing/getting the requested object(s), watch for changes.")
+	cmd.Flags().BoolVar(&f.WatchOnly, flagWatchOnly, false, "Watch for changes to the requested object(s), without listing/getting first.")
 
 	f.print = genericclioptions.NewPrintFlags("")
 
@@ -24,7 +24,9 @@ func (r *runner) printOutput(catalogResource catalogdata.Resource, maxColWidth u
 	switch {
 	case output.IsOutputDefault(r.flag.print.OutputFormat):
 		resource = getTable(catalogResource, maxColWidth)
-		printOptions := printers.PrintOptions{}
+		printOptions := printers.PrintOptions{
+			NoHeaders: r.noHeaders,
+		}
 		printer = printers.NewTablePrinter(printOptions)
 	case output.IsOutputName(r.flag.print.OutputFormat):
 		resource = catalogResource.Object()
@@ -6,12 +6,14 @@ import (
 	"io"
 	"strings"
 
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	catalogdata "github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/catalog"
@@ -26,6 +28,10 @@ type runner struct {
 
 	service catalogdata.Interface
 
+	// noHeaders is set once the first table has been printed in watch mode,
+	// so that subsequent rows line up below it.
+	noHeaders bool
+
 	stdout io.Writer
 	stderr io.Writer
 }
@@ -84,15 +90,19 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		}
 	}
 
+	options := catalogdata.GetOptions{
+		AllNamespaces: r.flag.AllNamespaces,
+		Name:          name,
+		Namespace:     namespace,
+		LabelSelector: labelSelector,
+	}
+
+	if r.flag.Watch || r.flag.WatchOnly {
+		return r.watch(ctx, options)
+	}
+
 	var catalogResource catalogdata.Resource
 	{
-
-		options := catalogdata.GetOptions{
-			AllNamespaces: r.flag.AllNamespaces,
-			Name:          name,
-			Namespace:     namespace,
-			LabelSelector: labelSelector,
-		}
 		catalogResource, err = r.service.Get(ctx, options)
 		if catalogdata.IsNotFound(err) {
 			return microerror.Maskf(notFoundError, "A catalog '%s/%s' cannot be found.\n", options.Namespace, options.Name)
@@ -115,12 +125,75 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	return nil
 }
 
+func (r *runner) watch(ctx context.Context, options catalogdata.GetOptions) error {
+	events, err := r.service.Watch(ctx, options)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !r.flag.WatchOnly {
+		catalogResource, err := r.service.Get(ctx, options)
+		switch {
+		case catalogdata.IsNotFound(err):
+			return microerror.Maskf(notFoundError, "A catalog '%s/%s' cannot be found.\n", options.Namespace, options.Name)
+		case catalogdata.IsNoResources(err):
+			// There is nothing to list yet, but catalogs may still be
+			// created while watching.
+		case err != nil:
+			return microerror.Mask(err)
+		default:
+			err = r.printWatchEvent(watch.Added, catalogResource)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	for event := range events {
+		if event.Type == watch.Error {
+			return microerror.Mask(event.Err)
+		}
+
+		err = r.printWatchEvent(event.Type, event.Resource)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *runner) printWatchEvent(eventType watch.EventType, catalogResource catalogdata.Resource) error {
+	if !output.IsOutputDefault(r.flag.print.OutputFormat) {
+		err := output.PrintWatchEvent(r.stdout, r.flag.print, eventType, catalogResource.Object())
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err := r.printOutput(catalogResource, r.flag.MaxColWidth)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	r.noHeaders = true
+
+	return nil
+}
+
 func (r *runner) getService() error {
 	if r.service != nil {
 		return nil
 	}
 
-	client, err := r.commonConfig.GetClient(r.logger)
+	var client k8sclient.Interface
+	var err error
+	if r.flag.Watch || r.flag.WatchOnly {
+		client, err = r.commonConfig.GetWatchingClient(r.logger)
+	} else {
+		client, err = r.commonConfig.GetClient(r.logger)
+	}
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -11,7 +11,9 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
 )
 
@@ -29,20 +31,32 @@ Output columns:
 - CONDITION: Latest condition reported for the cluster. Can be "CREATING", "CREATED", "UPDATING", "UPDATED", "DELETING".
 - RELEASE: Workload cluster release used by the cluster.
 - ORGANIZATION: Organization owning the cluster.
-- DESCRIPTION: User friendly description for the cluster.`
+- DESCRIPTION: User friendly description for the cluster.
+
+With --apps, the apps of the given cluster are listed instead, grouped by the
+bundles and apps they are managed by. The ROLLED UP column shows the first
+status other than "deployed" found among the children of an app, so a
+failing child is visible at a glance.`
 
 	examples = `  # List all clusters you have access to
   kubectl gs get clusters
 
   # Get one specific cluster by its name
-  kubectl gs get clusters f83ir`
+  kubectl gs get clusters f83ir
+
+  # Watch one specific cluster while it is being updated
+  kubectl gs get clusters f83ir --watch
+
+  # Show the apps of a cluster as a tree
+  kubectl gs get cluster f83ir --apps`
 )
 
 type Config struct {
 	Logger     micrologger.Logger
 	FileSystem afero.Fs
 
-	ConfigFlags *genericclioptions.RESTClientGetter
+	ConfigFlags       *genericclioptions.RESTClientGetter
+	InstallationCache *installation.CacheConfig
 
 	Stderr io.Writer
 	Stdout io.Writer
@@ -69,7 +83,8 @@ func New(config Config) (*cobra.Command, error) {
 
 	r := &runner{
 		commonConfig: &commonconfig.CommonConfig{
-			ConfigFlags: config.ConfigFlags,
+			ConfigFlags:       config.ConfigFlags,
+			InstallationCache: config.InstallationCache,
 		},
 		flag:   f,
 		logger: config.Logger,
@@ -89,6 +104,7 @@ func New(config Config) (*cobra.Command, error) {
 		RunE:    r.Run,
 		PreRunE: middleware.Compose(
 			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
 		),
 	}
 
@@ -1,22 +1,32 @@
 package clusters
 
 import (
+	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 const (
 	flagAllNamespaces = "all-namespaces"
+	flagApps          = "apps"
+	flagWatch         = "watch"
+	flagWatchOnly     = "watch-only"
 )
 
 type flag struct {
 	AllNamespaces bool
+	Apps          bool
+	Watch         bool
+	WatchOnly     bool
 
 	print *genericclioptions.PrintFlags
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&f.AllNamespaces, flagAllNamespaces, "A", false, "If present, list the requested object(s) across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().BoolVarP(&f.Watch, flagWatch, "w", false, "After listing/getting the requested object(s), watch for changes.")
+	cmd.Flags().BoolVar(&f.WatchOnly, flagWatchOnly, false, "Watch for changes to the requested object(s), without listing/getting first.")
+	cmd.Flags().BoolVar(&f.Apps, flagApps, false, "Display the apps of the cluster, grouped by the bundles and apps they are managed by.")
 
 	f.print = genericclioptions.NewPrintFlags("")
 
@@ -26,5 +36,12 @@ func (f *flag) Init(cmd *cobra.Command) {
 }
 
 func (f *flag) Validate() error {
+	if f.Apps && (f.Watch || f.WatchOnly) {
+		return microerror.Maskf(invalidFlagError, "--%s cannot be combined with --%s or --%s", flagApps, flagWatch, flagWatchOnly)
+	}
+	if f.Apps && f.AllNamespaces {
+		return microerror.Maskf(invalidFlagError, "--%s cannot be combined with --%s", flagApps, flagAllNamespaces)
+	}
+
 	return nil
 }
@@ -32,6 +32,7 @@ func (r *runner) printOutput(clusterResource cluster.Resource) error {
 		}
 
 		printOptions := printers.PrintOptions{
+			NoHeaders:     r.noHeaders,
 			WithNamespace: r.flag.AllNamespaces,
 		}
 		printer = printers.NewTablePrinter(printOptions)
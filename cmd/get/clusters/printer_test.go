@@ -28,6 +28,12 @@ var update = goflag.Bool("update", false, "update .golden reference test files")
 //
 // go test ./cmd/get/clusters -run Test_printOutput -update
 func Test_printOutput(t *testing.T) {
+	// Scheduled updates are also shown in local time.
+	defer func(local *time.Location) {
+		time.Local = local
+	}(time.Local)
+	time.Local = time.FixedZone("CET", 60*60)
+
 	testCases := []struct {
 		name               string
 		clusterRes         cluster.Resource
@@ -119,6 +125,17 @@ func Test_printOutput(t *testing.T) {
 			outputType:         output.TypeName,
 			expectedGoldenFile: "print_single_aws_cluster_name_output.golden",
 		},
+		{
+			name: "case 8: print list of AWS clusters with scheduled updates, with table output",
+			clusterRes: newClusterCollection(
+				*withScheduledUpdate(newAWSCluster("1sad2", "12.0.0", "test", "test cluster 1", label.ServicePriorityHighest, time.Now(), nil), "12.1.0", "01 Jan 22 23:30 UTC"),
+				*newAWSCluster("2a03f", "11.0.0", "test", "test cluster 2", label.ServicePriorityMedium, time.Now(), nil),
+				*withScheduledUpdate(newAWSCluster("asd29", "10.5.0", "test", "test cluster 3", label.ServicePriorityLowest, time.Now(), nil), "11.0.0", "tomorrow"),
+			),
+			provider:           key.ProviderAWS,
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "print_list_of_aws_clusters_with_scheduled_updates_table_output.golden",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -244,6 +261,13 @@ func newAWSCluster(id, release, org, description, servicePriority string, creati
 	return c
 }
 
+func withScheduledUpdate(c *cluster.Cluster, targetRelease, targetTime string) *cluster.Cluster {
+	c.Cluster.Annotations[annotation.UpdateScheduleTargetRelease] = targetRelease
+	c.Cluster.Annotations[annotation.UpdateScheduleTargetTime] = targetTime
+
+	return c
+}
+
 func newClusterCollection(clusters ...cluster.Cluster) *cluster.Collection {
 	collection := &cluster.Collection{
 		Items: clusters,
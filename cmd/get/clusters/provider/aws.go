@@ -20,6 +20,7 @@ func GetAWSTable(clusterResource cluster.Resource) *metav1.Table {
 		{Name: "Condition", Type: "string"},
 		{Name: "Release", Type: "string"},
 		{Name: "Service Priority", Type: "string"},
+		{Name: "Scheduled Update", Type: "string"},
 		{Name: "Organization", Type: "string"},
 		{Name: "Description", Type: "string"},
 	}
@@ -48,6 +49,7 @@ func getAWSClusterRow(c cluster.Cluster) metav1.TableRow {
 			getLatestAWSCondition(c.AWSCluster.Status.Cluster.Conditions),
 			c.AWSCluster.Labels[label.ReleaseVersion],
 			getClusterServicePriority(c.Cluster),
+			getClusterScheduledUpdate(c.Cluster),
 			c.AWSCluster.Labels[label.Organization],
 			c.AWSCluster.Spec.Cluster.Description,
 		},
@@ -30,6 +30,7 @@ func GetCommonClusterTable(clusterResource cluster.Resource) *metav1.Table {
 		{Name: "Phase", Type: "string"},
 		{Name: "Release", Type: "string"},
 		{Name: "Service Priority", Type: "string"},
+		{Name: "Scheduled Update", Type: "string"},
 		{Name: "Organization", Type: "string"},
 		{Name: "Description", Type: "string"},
 	}
@@ -89,6 +90,17 @@ func getClusterServicePriority(res *capi.Cluster) string {
 	return servicePriority
 }
 
+// getClusterScheduledUpdate returns the release version and time of a
+// scheduled update.
+func getClusterScheduledUpdate(res *capi.Cluster) string {
+	scheduledUpdate := cluster.FormatScheduledUpdate(res)
+	if scheduledUpdate == "" {
+		return naValue
+	}
+
+	return scheduledUpdate
+}
+
 func getCommonClusterRow(c cluster.Cluster) metav1.TableRow {
 	if c.Cluster == nil {
 		return metav1.TableRow{}
@@ -101,6 +113,7 @@ func getCommonClusterRow(c cluster.Cluster) metav1.TableRow {
 			c.Cluster.Status.Phase,
 			c.Cluster.Labels[label.ReleaseVersion],
 			getClusterServicePriority(c.Cluster),
+			getClusterScheduledUpdate(c.Cluster),
 			c.Cluster.Labels[label.Organization],
 			getClusterDescription(c.Cluster),
 		},
@@ -19,6 +19,7 @@ func GetOpenStackTable(clusterResource cluster.Resource) *metav1.Table {
 		{Name: "Cluster Version", Type: "string"},
 		{Name: "Preinstalled Apps Version", Type: "string"},
 		{Name: "Service Priority", Type: "string"},
+		{Name: "Scheduled Update", Type: "string"},
 		{Name: "Organization", Type: "string"},
 		{Name: "Description", Type: "string"},
 	}
@@ -58,6 +59,7 @@ func getOpenStackClusterRow(c cluster.Cluster) metav1.TableRow {
 			clusterAppVersion,
 			defaultAppsAppVersion,
 			getClusterServicePriority(c.Cluster),
+			getClusterScheduledUpdate(c.Cluster),
 			getClusterOrganization(c.Cluster),
 			getClusterDescription(c.Cluster),
 		},
@@ -2,16 +2,22 @@ package clusters
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"strings"
 
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
+	"github.com/giantswarm/kubectl-gs/v5/cmd/get/apps/tree"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
 )
@@ -22,8 +28,13 @@ type runner struct {
 	logger       micrologger.Logger
 	fs           afero.Fs
 
-	provider string
-	service  cluster.Interface
+	provider   string
+	service    cluster.Interface
+	appService app.Interface
+
+	// noHeaders is set once the first table has been printed in watch mode,
+	// so that subsequent rows line up below it.
+	noHeaders bool
 
 	stdout io.Writer
 	stderr io.Writer
@@ -62,27 +73,35 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		}
 	}
 
-	var resource cluster.Resource
+	options := cluster.GetOptions{
+		Provider:       r.provider,
+		FallbackToCapi: true,
+	}
 	{
-		options := cluster.GetOptions{
-			Provider:       r.provider,
-			FallbackToCapi: true,
+		if len(args) > 0 {
+			options.Name = strings.ToLower(args[0])
 		}
-		{
-			if len(args) > 0 {
-				options.Name = strings.ToLower(args[0])
-			}
 
-			if r.flag.AllNamespaces {
-				options.Namespace = metav1.NamespaceAll
-			} else {
-				options.Namespace, _, err = r.commonConfig.GetNamespace()
-				if err != nil {
-					return microerror.Mask(err)
-				}
+		if r.flag.AllNamespaces {
+			options.Namespace = metav1.NamespaceAll
+		} else {
+			options.Namespace, _, err = r.commonConfig.GetNamespace()
+			if err != nil {
+				return microerror.Mask(err)
 			}
 		}
+	}
+
+	if r.flag.Watch || r.flag.WatchOnly {
+		return r.watch(ctx, options)
+	}
 
+	if r.flag.Apps && options.Name == "" {
+		return microerror.Maskf(invalidFlagError, "--%s requires the name of a cluster", flagApps)
+	}
+
+	var resource cluster.Resource
+	{
 		resource, err = r.service.Get(ctx, options)
 		if cluster.IsNotFound(err) {
 			return microerror.Maskf(notFoundError, "A cluster with name '%s' cannot be found.\n", options.Name)
@@ -95,6 +114,15 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		}
 	}
 
+	if r.flag.Apps {
+		namespace := options.Namespace
+		if c, ok := resource.(*cluster.Cluster); ok && c.Cluster != nil {
+			namespace = c.Cluster.Namespace
+		}
+
+		return r.printApps(ctx, options.Name, namespace)
+	}
+
 	err = r.printOutput(resource)
 	if err != nil {
 		return microerror.Mask(err)
@@ -103,12 +131,133 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	return nil
 }
 
+// printApps prints the apps of the cluster as a tree.
+func (r *runner) printApps(ctx context.Context, clusterName, namespace string) error {
+	err := r.getAppService()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	appResource, err := r.appService.Get(ctx, app.GetOptions{Namespace: namespace})
+	if err != nil && !app.IsNoResources(err) {
+		return microerror.Mask(err)
+	}
+
+	clusterNames, err := r.appService.GetClusterNames(ctx, namespace)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var apps []*applicationv1alpha1.App
+	if collection, ok := appResource.(*app.Collection); ok {
+		for _, item := range collection.Items {
+			if app.IsClusterApp(item.CR, clusterName, clusterNames) {
+				apps = append(apps, item.CR)
+			}
+		}
+	}
+	if len(apps) == 0 && output.IsOutputDefault(r.flag.print.OutputFormat) {
+		fmt.Fprintf(r.stdout, "No apps of cluster '%s' found.\n", clusterName)
+		return nil
+	}
+
+	err = tree.Print(r.stdout, *r.flag.print.OutputFormat, app.BuildTree(apps), false)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) watch(ctx context.Context, options cluster.GetOptions) error {
+	events, err := r.service.Watch(ctx, options)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !r.flag.WatchOnly {
+		resource, err := r.service.Get(ctx, options)
+		switch {
+		case cluster.IsNotFound(err):
+			return microerror.Maskf(notFoundError, "A cluster with name '%s' cannot be found.\n", options.Name)
+		case cluster.IsNoResources(err):
+			// There is nothing to list yet, but clusters may still be
+			// created while watching.
+		case err != nil:
+			return microerror.Mask(err)
+		default:
+			err = r.printWatchEvent(watch.Added, resource)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	for event := range events {
+		if event.Type == watch.Error {
+			return microerror.Mask(event.Err)
+		}
+
+		err = r.printWatchEvent(event.Type, event.Resource)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *runner) printWatchEvent(eventType watch.EventType, resource cluster.Resource) error {
+	if !output.IsOutputDefault(r.flag.print.OutputFormat) {
+		err := output.PrintWatchEvent(r.stdout, r.flag.print, eventType, resource.Object())
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err := r.printOutput(resource)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	r.noHeaders = true
+
+	return nil
+}
+
+func (r *runner) getAppService() error {
+	if r.appService != nil {
+		return nil
+	}
+
+	client, err := r.commonConfig.GetClient(r.logger)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.appService, err = app.New(app.Config{
+		Client: client.CtrlClient(),
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
 func (r *runner) getService() error {
 	if r.service != nil {
 		return nil
 	}
 
-	client, err := r.commonConfig.GetClient(r.logger)
+	var client k8sclient.Interface
+	var err error
+	if r.flag.Watch || r.flag.WatchOnly {
+		client, err = r.commonConfig.GetWatchingClient(r.logger)
+	} else {
+		client, err = r.commonConfig.GetClient(r.logger)
+	}
 	if err != nil {
 		return microerror.Mask(err)
 	}
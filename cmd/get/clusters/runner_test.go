@@ -20,6 +20,7 @@ import (
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/scheme"
+	testapp "github.com/giantswarm/kubectl-gs/v5/test/app"
 	"github.com/giantswarm/kubectl-gs/v5/test/goldenfile"
 	"github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
 )
@@ -32,6 +33,7 @@ func Test_run(t *testing.T) {
 		name               string
 		storage            []runtime.Object
 		args               []string
+		apps               bool
 		expectedGoldenFile string
 		errorMatcher       func(error) bool
 	}{
@@ -79,6 +81,21 @@ func Test_run(t *testing.T) {
 			args:         []string{"f930q"},
 			errorMatcher: IsNotFound,
 		},
+		{
+			name: "case 5: get the apps of a cluster",
+			storage: []runtime.Object{
+				newcapiCluster("1sad2", "10.5.0", "some-org", "test cluster 3", label.ServicePriorityHighest, time.Now(), nil),
+				newAWSClusterResource("1sad2", "10.5.0", "some-org", "test cluster 3", time.Now(), nil),
+				testapp.NewClusterApp("1sad2", "default", "1sad2"),
+				testapp.WithLabel(testapp.NewClusterApp("1sad2-default-apps", "default", "1sad2"), label.ManagedBy, "cluster"),
+				testapp.WithLabel(testapp.NewClusterApp("1sad2-security-bundle", "default", "1sad2"), label.ManagedBy, "1sad2-default-apps"),
+				testapp.WithReleaseStatus(testapp.WithLabel(testapp.NewClusterApp("1sad2-kyverno", "default", "1sad2"), label.ManagedBy, "security-bundle"), "failed", ""),
+				testapp.NewClusterApp("f930q-coredns", "default", "f930q"),
+			},
+			args:               []string{"1sad2"},
+			apps:               true,
+			expectedGoldenFile: "run_get_cluster_apps.golden",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -87,6 +104,7 @@ func Test_run(t *testing.T) {
 
 			fakeKubeConfig := kubeconfig.CreateFakeKubeConfig()
 			flag := &flag{
+				Apps:  tc.apps,
 				print: genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault),
 			}
 			out := new(bytes.Buffer)
@@ -94,6 +112,7 @@ func Test_run(t *testing.T) {
 			runner := &runner{
 				commonConfig: commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(fakeKubeConfig)),
 				service:      newClusterService(t, tc.storage...),
+				appService:   testapp.NewService(t, tc.storage...),
 				flag:         flag,
 				stdout:       out,
 				provider:     key.ProviderAWS,
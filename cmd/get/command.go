@@ -18,6 +18,7 @@ import (
 	"github.com/giantswarm/kubectl-gs/v5/cmd/get/orgs"
 	"github.com/giantswarm/kubectl-gs/v5/cmd/get/releases"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
 )
 
 const (
@@ -29,7 +30,8 @@ type Config struct {
 	Logger     micrologger.Logger
 	FileSystem afero.Fs
 
-	ConfigFlags *genericclioptions.RESTClientGetter
+	ConfigFlags       *genericclioptions.RESTClientGetter
+	InstallationCache *installation.CacheConfig
 
 	Stderr io.Writer
 	Stdout io.Writer
@@ -110,7 +112,8 @@ func New(config Config) (*cobra.Command, error) {
 			Logger:     config.Logger,
 			FileSystem: config.FileSystem,
 
-			ConfigFlags: config.ConfigFlags,
+			ConfigFlags:       config.ConfigFlags,
+			InstallationCache: config.InstallationCache,
 
 			Stderr: config.Stderr,
 			Stdout: config.Stdout,
@@ -128,7 +131,8 @@ func New(config Config) (*cobra.Command, error) {
 			Logger:     config.Logger,
 			FileSystem: config.FileSystem,
 
-			ConfigFlags: config.ConfigFlags,
+			ConfigFlags:       config.ConfigFlags,
+			InstallationCache: config.InstallationCache,
 
 			Stderr: config.Stderr,
 			Stdout: config.Stdout,
@@ -146,9 +150,10 @@ func New(config Config) (*cobra.Command, error) {
 			Logger:     config.Logger,
 			FileSystem: config.FileSystem,
 
-			ConfigFlags: config.ConfigFlags,
-			Stderr:      config.Stderr,
-			Stdout:      config.Stdout,
+			ConfigFlags:       config.ConfigFlags,
+			InstallationCache: config.InstallationCache,
+			Stderr:            config.Stderr,
+			Stdout:            config.Stdout,
 		}
 
 		releasesCmd, err = releases.New(c)
@@ -11,7 +11,9 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
 )
 
@@ -31,20 +33,40 @@ Output columns:
 - NODES MIN/MAX: Node pool autoscaler settings (if supported).
 - NODES DESIRED: The total number of nodes that the node pool should have.
 - NODES READY: The number of nodes in the node pool that are actually ready.
-- DESCRIPTION: User friendly description for the node pool.`
+- DESCRIPTION: User friendly description for the node pool.
+
+With --wide, the nodes are also looked up in the workload clusters, using
+the client certificate contexts created with
+'kubectl gs login <management-cluster> --workload-cluster <cluster>'.
+Clusters without such a context are skipped with a warning. The additional
+columns are:
+
+- NODES FOUND: The number of nodes of the node pool found in the workload cluster.
+- FOUND READY: The number of those nodes which are ready.
+- INSTANCE TYPES: The instance types of those nodes.
+- MISMATCH: Where the nodes found differ from the desired and ready nodes.
+
+The nodes found are listed in a second table below.`
 
 	examples = `  # List all node pools you have access to
   kubectl gs get nodepools
 
   # Get one specific nodepool by its name
-  kubectl gs get nodepool 3f01a`
+  kubectl gs get nodepool 3f01a
+
+  # Watch the node pools of one cluster for changes
+  kubectl gs get nodepools --cluster-name f83ir --watch
+
+  # Compare the node pools of one cluster with the nodes in the cluster
+  kubectl gs get nodepools --cluster-name f83ir --wide`
 )
 
 type Config struct {
 	Logger     micrologger.Logger
 	FileSystem afero.Fs
 
-	ConfigFlags *genericclioptions.RESTClientGetter
+	ConfigFlags       *genericclioptions.RESTClientGetter
+	InstallationCache *installation.CacheConfig
 
 	Stderr io.Writer
 	Stdout io.Writer
@@ -71,7 +93,8 @@ func New(config Config) (*cobra.Command, error) {
 
 	r := &runner{
 		commonConfig: &commonconfig.CommonConfig{
-			ConfigFlags: config.ConfigFlags,
+			ConfigFlags:       config.ConfigFlags,
+			InstallationCache: config.InstallationCache,
 		},
 		flag:   f,
 		logger: config.Logger,
@@ -91,6 +114,7 @@ func New(config Config) (*cobra.Command, error) {
 		RunE:    r.Run,
 		PreRunE: middleware.Compose(
 			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
 		),
 	}
 
@@ -28,3 +28,12 @@ var notFoundError = &microerror.Error{
 func IsNotFound(err error) bool {
 	return microerror.Cause(err) == notFoundError
 }
+
+var noClientCertContextError = &microerror.Error{
+	Kind: "noClientCertContextError",
+}
+
+// IsNoClientCertContext asserts noClientCertContextError.
+func IsNoClientCertContext(err error) bool {
+	return microerror.Cause(err) == noClientCertContextError
+}
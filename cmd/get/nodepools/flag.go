@@ -1,18 +1,27 @@
 package nodepools
 
 import (
+	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
 )
 
 const (
 	flagAllNamespaces = "all-namespaces"
 	flagClusterName   = "cluster-name"
+	flagWatch         = "watch"
+	flagWatchOnly     = "watch-only"
+	flagWide          = "wide"
 )
 
 type flag struct {
 	AllNamespaces bool
 	ClusterName   string
+	Watch         bool
+	WatchOnly     bool
+	Wide          bool
 
 	print *genericclioptions.PrintFlags
 }
@@ -20,6 +29,9 @@ type flag struct {
 func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&f.AllNamespaces, flagAllNamespaces, "A", false, "If present, list the requested object(s) across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
 	cmd.Flags().StringVarP(&f.ClusterName, flagClusterName, "c", "", "Only show node pools of the cluster with this name")
+	cmd.Flags().BoolVarP(&f.Watch, flagWatch, "w", false, "After listing/getting the requested object(s), watch for changes.")
+	cmd.Flags().BoolVar(&f.WatchOnly, flagWatchOnly, false, "Watch for changes to the requested object(s), without listing/getting first.")
+	cmd.Flags().BoolVar(&f.Wide, flagWide, false, "Also show the nodes found in the workload clusters, using their client certificate contexts.")
 
 	f.print = genericclioptions.NewPrintFlags("")
 
@@ -29,5 +41,12 @@ func (f *flag) Init(cmd *cobra.Command) {
 }
 
 func (f *flag) Validate() error {
+	if f.Wide && (f.Watch || f.WatchOnly) {
+		return microerror.Maskf(invalidFlagError, "--%s cannot be combined with --%s or --%s", flagWide, flagWatch, flagWatchOnly)
+	}
+	if f.Wide && !output.IsOutputDefault(f.print.OutputFormat) {
+		return microerror.Maskf(invalidFlagError, "--%s can only be used with the default table output", flagWide)
+	}
+
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/printers"
 
@@ -32,8 +33,12 @@ func (r *runner) printOutput(npResource nodepool.Resource) error {
 		case key.ProviderDefault:
 			resource = provider.GetCAPITable(npResource)
 		}
+		if table, ok := resource.(*metav1.Table); ok && r.flag.Wide {
+			provider.AddNodeColumns(table, npResource)
+		}
 
 		printOptions := printers.PrintOptions{
+			NoHeaders:     r.noHeaders,
 			WithNamespace: r.flag.AllNamespaces,
 		}
 		printer = printers.NewTablePrinter(printOptions)
@@ -60,6 +65,32 @@ func (r *runner) printOutput(npResource nodepool.Resource) error {
 		return microerror.Mask(err)
 	}
 
+	if r.flag.Wide {
+		err = r.printNodes(npResource)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+// printNodes lists the nodes found for the node pools below the node pool
+// table.
+func (r *runner) printNodes(npResource nodepool.Resource) error {
+	table := provider.GetNodeTable(npResource)
+	if len(table.Rows) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(r.stdout)
+
+	printer := printers.NewTablePrinter(printers.PrintOptions{})
+	err := printer.PrintObj(table, r.stdout)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/nodepool"
+)
+
+// AddNodeColumns adds the nodes found in the workload clusters to the rows
+// of a node pool table, flagging where they differ from the replicas
+// reported by CAPI.
+func AddNodeColumns(table *metav1.Table, npResource nodepool.Resource) {
+	table.ColumnDefinitions = append(table.ColumnDefinitions,
+		metav1.TableColumnDefinition{Name: "Nodes Found", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Found Ready", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Instance Types", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Mismatch", Type: "string"},
+	)
+
+	nodePools := map[runtime.Object]*nodepool.Nodepool{}
+	for _, np := range nodepool.Nodepools(npResource) {
+		nodePools[np.Object()] = np
+	}
+
+	for i, row := range table.Rows {
+		np, ok := nodePools[row.Object.Object]
+		if !ok || np.Nodes == nil {
+			table.Rows[i].Cells = append(row.Cells, naValue, naValue, naValue, naValue)
+			continue
+		}
+
+		instanceTypes := naValue
+		if types := np.Nodes.InstanceTypes(); len(types) > 0 {
+			instanceTypes = strings.Join(types, ",")
+		}
+
+		table.Rows[i].Cells = append(row.Cells,
+			fmt.Sprint(len(np.Nodes.Items)),
+			fmt.Sprint(np.Nodes.Ready()),
+			instanceTypes,
+			getNodeMismatch(np),
+		)
+	}
+}
+
+// GetNodeTable lists the nodes found for each node pool in the workload
+// clusters.
+func GetNodeTable(npResource nodepool.Resource) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Node Pool", Type: "string"},
+			{Name: "Cluster Name", Type: "string"},
+			{Name: "Node", Type: "string"},
+			{Name: "Ready", Type: "string"},
+			{Name: "Instance Type", Type: "string"},
+		},
+	}
+
+	for _, np := range nodepool.Nodepools(npResource) {
+		if np.Nodes == nil {
+			continue
+		}
+
+		for _, node := range np.Nodes.Items {
+			instanceType := node.InstanceType
+			if instanceType == "" {
+				instanceType = naValue
+			}
+
+			table.Rows = append(table.Rows, metav1.TableRow{
+				Cells: []interface{}{
+					np.Object().(metav1.Object).GetName(),
+					np.ClusterName(),
+					node.Name,
+					fmt.Sprint(node.Ready),
+					instanceType,
+				},
+			})
+		}
+	}
+
+	return table
+}
+
+// getNodeMismatch compares the desired and ready replicas of the node pool
+// with the nodes found in the workload cluster.
+func getNodeMismatch(np *nodepool.Nodepool) string {
+	var desiredReplicas *int32
+	var readyReplicas int32
+	switch {
+	case np.MachineDeployment != nil:
+		desiredReplicas = np.MachineDeployment.Spec.Replicas
+		readyReplicas = np.MachineDeployment.Status.ReadyReplicas
+	case np.MachinePool != nil:
+		desiredReplicas = np.MachinePool.Spec.Replicas
+		readyReplicas = np.MachinePool.Status.ReadyReplicas
+	default:
+		return naValue
+	}
+
+	var mismatches []string
+	if found := len(np.Nodes.Items); desiredReplicas != nil && int(*desiredReplicas) != found {
+		mismatches = append(mismatches, fmt.Sprintf("%d desired, %d found", *desiredReplicas, found))
+	}
+	if found := np.Nodes.Ready(); int(readyReplicas) != found {
+		mismatches = append(mismatches, fmt.Sprintf("%d ready, %d found ready", readyReplicas, found))
+	}
+	if len(mismatches) == 0 {
+		return "-"
+	}
+
+	return strings.Join(mismatches, "; ")
+}
@@ -2,17 +2,24 @@ package nodepools
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"sort"
 	"strings"
 
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/clientcmd"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/nodepool"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
 )
 
@@ -25,6 +32,15 @@ type runner struct {
 	provider string
 	service  nodepool.Interface
 
+	// wcClients are the clients of the workload clusters by cluster name,
+	// created from their client certificate contexts. A nil client means
+	// that the cluster cannot be accessed.
+	wcClients map[string]runtimeclient.Client
+
+	// noHeaders is set once the first table has been printed in watch mode,
+	// so that subsequent rows line up below it.
+	noHeaders bool
+
 	stdout io.Writer
 	stderr io.Writer
 }
@@ -62,27 +78,31 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		}
 	}
 
-	var resource nodepool.Resource
+	options := nodepool.GetOptions{
+		Provider:    r.provider,
+		ClusterName: r.flag.ClusterName,
+	}
 	{
-		options := nodepool.GetOptions{
-			Provider:    r.provider,
-			ClusterName: r.flag.ClusterName,
+		if len(args) > 0 {
+			options.Name = strings.ToLower(args[0])
 		}
-		{
-			if len(args) > 0 {
-				options.Name = strings.ToLower(args[0])
-			}
 
-			if r.flag.AllNamespaces {
-				options.Namespace = metav1.NamespaceAll
-			} else {
-				options.Namespace, _, err = r.commonConfig.GetNamespace()
-				if err != nil {
-					return microerror.Mask(err)
-				}
+		if r.flag.AllNamespaces {
+			options.Namespace = metav1.NamespaceAll
+		} else {
+			options.Namespace, _, err = r.commonConfig.GetNamespace()
+			if err != nil {
+				return microerror.Mask(err)
 			}
 		}
+	}
 
+	if r.flag.Watch || r.flag.WatchOnly {
+		return r.watch(ctx, options)
+	}
+
+	var resource nodepool.Resource
+	{
 		resource, err = r.service.Get(ctx, options)
 		if nodepool.IsNotFound(err) {
 			return microerror.Maskf(notFoundError, "A node pool with name '%s' cannot be found.\n", options.Name)
@@ -95,6 +115,10 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		}
 	}
 
+	if r.flag.Wide {
+		r.addNodes(ctx, resource)
+	}
+
 	err = r.printOutput(resource)
 	if err != nil {
 		return microerror.Mask(err)
@@ -103,12 +127,150 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	return nil
 }
 
+// addNodes adds the nodes found in the workload clusters to the node pools.
+// Clusters which cannot be accessed are reported, but do not fail the
+// command, as their node pools are still listed.
+func (r *runner) addNodes(ctx context.Context, resource nodepool.Resource) {
+	byCluster := map[string][]*nodepool.Nodepool{}
+	var clusterNames []string
+	for _, np := range nodepool.Nodepools(resource) {
+		clusterName := np.ClusterName()
+		if _, ok := byCluster[clusterName]; !ok {
+			clusterNames = append(clusterNames, clusterName)
+		}
+		byCluster[clusterName] = append(byCluster[clusterName], np)
+	}
+	sort.Strings(clusterNames)
+
+	for _, clusterName := range clusterNames {
+		wcClient, err := r.getWCClient(clusterName)
+		if IsNoClientCertContext(err) {
+			fmt.Fprintf(r.stderr, "No client certificate context found for cluster '%s', so its nodes are not shown. Create one with 'kubectl gs login <management-cluster> --workload-cluster %s'.\n", clusterName, clusterName)
+			continue
+		} else if err != nil {
+			fmt.Fprintf(r.stderr, "The workload cluster '%s' cannot be accessed, so its nodes are not shown: %s\n", clusterName, err)
+			continue
+		}
+
+		err = nodepool.AddNodes(ctx, wcClient, byCluster[clusterName])
+		if err != nil {
+			fmt.Fprintf(r.stderr, "The nodes of workload cluster '%s' cannot be listed: %s\n", clusterName, err)
+		}
+	}
+}
+
+// getWCClient returns a client for the workload cluster, using its client
+// certificate context created from the current management cluster context.
+func (r *runner) getWCClient(clusterName string) (runtimeclient.Client, error) {
+	if r.wcClients == nil {
+		r.wcClients = map[string]runtimeclient.Client{}
+	}
+	if wcClient, ok := r.wcClients[clusterName]; ok {
+		if wcClient == nil {
+			return nil, microerror.Mask(noClientCertContextError)
+		}
+		return wcClient, nil
+	}
+
+	config, err := r.commonConfig.GetConfigAccess().GetStartingConfig()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	mcContextName := r.commonConfig.GetContextOverride()
+	if mcContextName == "" {
+		mcContextName = config.CurrentContext
+	}
+
+	contextName, ok := kubeconfig.FindClientCertContext(config, mcContextName, clusterName)
+	if !ok {
+		r.wcClients[clusterName] = nil
+		return nil, microerror.Mask(noClientCertContextError)
+	}
+
+	restConfig, err := clientcmd.NewNonInteractiveClientConfig(*config, contextName, &clientcmd.ConfigOverrides{}, r.commonConfig.GetConfigAccess()).ClientConfig()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	wcClient, err := runtimeclient.New(restConfig, runtimeclient.Options{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	r.wcClients[clusterName] = wcClient
+
+	return wcClient, nil
+}
+
+func (r *runner) watch(ctx context.Context, options nodepool.GetOptions) error {
+	events, err := r.service.Watch(ctx, options)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !r.flag.WatchOnly {
+		resource, err := r.service.Get(ctx, options)
+		switch {
+		case nodepool.IsNotFound(err):
+			return microerror.Maskf(notFoundError, "A node pool with name '%s' cannot be found.\n", options.Name)
+		case nodepool.IsNoResources(err):
+			// There is nothing to list yet, but node pools may still be
+			// created while watching.
+		case err != nil:
+			return microerror.Mask(err)
+		default:
+			err = r.printWatchEvent(watch.Added, resource)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	for event := range events {
+		if event.Type == watch.Error {
+			return microerror.Mask(event.Err)
+		}
+
+		err = r.printWatchEvent(event.Type, event.Resource)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *runner) printWatchEvent(eventType watch.EventType, resource nodepool.Resource) error {
+	if !output.IsOutputDefault(r.flag.print.OutputFormat) {
+		err := output.PrintWatchEvent(r.stdout, r.flag.print, eventType, resource.Object())
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err := r.printOutput(resource)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	r.noHeaders = true
+
+	return nil
+}
+
 func (r *runner) getService() error {
 	if r.service != nil {
 		return nil
 	}
 
-	client, err := r.commonConfig.GetClient(r.logger)
+	var client k8sclient.Interface
+	var err error
+	if r.flag.Watch || r.flag.WatchOnly {
+		client, err = r.commonConfig.GetWatchingClient(r.logger)
+	} else {
+		client, err = r.commonConfig.GetClient(r.logger)
+	}
 	if err != nil {
 		return microerror.Mask(err)
 	}
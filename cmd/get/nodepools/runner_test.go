@@ -3,15 +3,25 @@ package nodepools
 import (
 	"bytes"
 	"context"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/label"
 	"github.com/giantswarm/microerror"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/ptr"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
 
 	"github.com/giantswarm/kubectl-gs/v5/internal/key"
@@ -174,6 +184,127 @@ func Test_run(t *testing.T) {
 	}
 }
 
+// Test_run_wide uses golden files.
+//
+// go test ./cmd/get/nodepools -run Test_run_wide -update
+func Test_run_wide(t *testing.T) {
+	// The nodes found are compared with the desired replicas of the spec.
+	withReplicas := func(md *capi.MachineDeployment, replicas int32) *capi.MachineDeployment {
+		md.Spec.Replicas = ptr.To(replicas)
+		return md
+	}
+
+	storage := []runtime.Object{
+		// Node pool 1sad2 is being scaled up from 2 to 3 nodes.
+		withReplicas(newcapiMachineDeployment("1sad2", "s921a", "10.5.0", time.Now(), 2, 1), 3),
+		newAWSMachineDeployment("1sad2", "s921a", "10.5.0", "test nodepool 3", time.Now(), 1, 3),
+		withReplicas(newcapiMachineDeployment("f930q", "s921a", "11.0.0", time.Now(), 1, 1), 1),
+		newAWSMachineDeployment("f930q", "s921a", "11.0.0", "test nodepool 4", time.Now(), 1, 3),
+		withReplicas(newcapiMachineDeployment("9f012", "29sa0", "9.0.0", time.Now(), 1, 1), 1),
+		newAWSMachineDeployment("9f012", "29sa0", "9.0.0", "test nodepool 5", time.Now(), 1, 1),
+	}
+
+	clientScheme, err := scheme.NewScheme()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", microerror.Pretty(err, true))
+	}
+	wcClient := fake.NewClientBuilder().WithScheme(clientScheme).WithRuntimeObjects(
+		newNode("ip-10-0-0-1", "1sad2", true, "m5.xlarge"),
+		newNode("ip-10-0-0-2", "1sad2", false, "m5.2xlarge"),
+		newNode("ip-10-0-0-3", "", true, "m5.xlarge"),
+	).Build()
+
+	// The kubeconfig only has the management cluster context, so there is
+	// no client certificate context for cluster 29sa0.
+	cf := genericclioptions.NewConfigFlags(true)
+	cf.KubeConfig = ptr.To[string](filepath.Join(t.TempDir(), "config.yaml"))
+	err = clientcmd.WriteToFile(clientcmdapi.Config{
+		CurrentContext: "gs-test",
+		Clusters:       map[string]*clientcmdapi.Cluster{"gs-test": {Server: "https://api.test.example.com"}},
+		AuthInfos:      map[string]*clientcmdapi.AuthInfo{"gs-user-test": {}},
+		Contexts:       map[string]*clientcmdapi.Context{"gs-test": {Cluster: "gs-test", AuthInfo: "gs-user-test"}},
+	}, *cf.KubeConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	flag := &flag{
+		print: genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault),
+		Wide:  true,
+	}
+
+	out := new(bytes.Buffer)
+	errOut := new(bytes.Buffer)
+	runner := &runner{
+		commonConfig: commonconfig.New(cf),
+		service:      newClusterService(t, storage...),
+		flag:         flag,
+		stdout:       out,
+		stderr:       errOut,
+		provider:     key.ProviderAWS,
+		wcClients: map[string]runtimeclient.Client{
+			"s921a": wcClient,
+		},
+	}
+
+	err = runner.run(context.TODO(), nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !strings.Contains(errOut.String(), "No client certificate context found for cluster '29sa0'") {
+		t.Fatalf("expected a warning about cluster 29sa0, got: %q", errOut.String())
+	}
+
+	var expectedResult []byte
+	{
+		gf := goldenfile.New("testdata", "run_get_nodepools_wide.golden")
+		if *update {
+			err = gf.Update(out.Bytes())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			expectedResult = out.Bytes()
+		} else {
+			expectedResult, err = gf.Read()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+		}
+	}
+
+	diff := cmp.Diff(string(expectedResult), out.String())
+	if diff != "" {
+		t.Fatalf("value not expected, got:\n %s", diff)
+	}
+}
+
+func newNode(name, machineDeployment string, ready bool, instanceType string) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	n := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				corev1.LabelInstanceTypeStable: instanceType,
+			},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: status},
+			},
+		},
+	}
+	if machineDeployment != "" {
+		n.Labels[label.MachineDeployment] = machineDeployment
+	}
+
+	return n
+}
+
 func newClusterService(t *testing.T, object ...runtime.Object) *nodepool.Service {
 	clientScheme, err := scheme.NewScheme()
 	if err != nil {
@@ -15,6 +15,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
 )
 
@@ -34,7 +35,10 @@ Output columns:
   kubectl gs get organizations
 
   # Get one specific organization
-  kubectl gs organization acme`
+  kubectl gs organization acme
+
+  # Watch for organizations being created or deleted
+  kubectl gs get organizations --watch-only`
 )
 
 var (
@@ -92,6 +96,7 @@ func New(config Config) (*cobra.Command, error) {
 		RunE:    r.Run,
 		PreRunE: middleware.Compose(
 			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
 		),
 	}
 
@@ -5,11 +5,22 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
+const (
+	flagWatch     = "watch"
+	flagWatchOnly = "watch-only"
+)
+
 type flag struct {
+	Watch     bool
+	WatchOnly bool
+
 	print *genericclioptions.PrintFlags
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&f.Watch, flagWatch, "w", false, "After listing/getting the requested object(s), watch for changes.")
+	cmd.Flags().BoolVar(&f.WatchOnly, flagWatchOnly, false, "Watch for changes to the requested object(s), without listing/getting first.")
+
 	f.print = genericclioptions.NewPrintFlags("")
 
 	// Merging current command flags and config flags,
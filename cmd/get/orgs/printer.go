@@ -49,7 +49,7 @@ func (r *runner) printOutput(orgResource organization.Resource) error {
 
 		resource = table
 		printOptions := printers.PrintOptions{
-			NoHeaders:        false,
+			NoHeaders:        r.noHeaders,
 			WithNamespace:    false,
 			WithKind:         true,
 			Wide:             true,
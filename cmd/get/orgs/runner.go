@@ -5,10 +5,12 @@ import (
 	"io"
 	"strings"
 
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/organization"
@@ -23,6 +25,10 @@ type runner struct {
 
 	service organization.Interface
 
+	// noHeaders is set once the first table has been printed in watch mode,
+	// so that subsequent rows line up below it.
+	noHeaders bool
+
 	stdout io.Writer
 	stderr io.Writer
 }
@@ -53,15 +59,19 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		}
 	}
 
-	var resource organization.Resource
+	options := organization.GetOptions{}
 	{
-		options := organization.GetOptions{}
-		{
-			if len(args) > 0 {
-				options.Name = strings.ToLower(args[0])
-			}
+		if len(args) > 0 {
+			options.Name = strings.ToLower(args[0])
 		}
+	}
+
+	if r.flag.Watch || r.flag.WatchOnly {
+		return r.watch(ctx, options)
+	}
 
+	var resource organization.Resource
+	{
 		resource, err = r.service.Get(ctx, options)
 		if organization.IsNotFound(err) {
 			return microerror.Maskf(notFoundError, "An organization with name '%s' cannot be found.\n", options.Name)
@@ -82,12 +92,75 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	return nil
 }
 
+func (r *runner) watch(ctx context.Context, options organization.GetOptions) error {
+	events, err := r.service.Watch(ctx, options)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !r.flag.WatchOnly {
+		resource, err := r.service.Get(ctx, options)
+		switch {
+		case organization.IsNotFound(err):
+			return microerror.Maskf(notFoundError, "An organization with name '%s' cannot be found.\n", options.Name)
+		case organization.IsNoResources(err):
+			// There is nothing to list yet, but organizations may still be
+			// created while watching.
+		case err != nil:
+			return microerror.Mask(err)
+		default:
+			err = r.printWatchEvent(watch.Added, resource)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	for event := range events {
+		if event.Type == watch.Error {
+			return microerror.Mask(event.Err)
+		}
+
+		err = r.printWatchEvent(event.Type, event.Resource)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *runner) printWatchEvent(eventType watch.EventType, resource organization.Resource) error {
+	if !output.IsOutputDefault(r.flag.print.OutputFormat) {
+		err := output.PrintWatchEvent(r.stdout, r.flag.print, eventType, resource.Object())
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err := r.printOutput(resource)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	r.noHeaders = true
+
+	return nil
+}
+
 func (r *runner) getService() error {
 	if r.service != nil {
 		return nil
 	}
 
-	client, err := r.commonConfig.GetClient(r.logger)
+	var client k8sclient.Interface
+	var err error
+	if r.flag.Watch || r.flag.WatchOnly {
+		client, err = r.commonConfig.GetWatchingClient(r.logger)
+	} else {
+		client, err = r.commonConfig.GetClient(r.logger)
+	}
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -11,7 +11,9 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
 )
 
@@ -26,14 +28,24 @@ const (
   kubectl gs get releases
 
   # Get one specific release by its tagged version
-  kubectl gs get release v1.4.2`
+  kubectl gs get release v1.4.2
+
+  # Compare the components and apps of two releases
+  kubectl gs get releases --diff aws-29.1.0 aws-30.0.0
+
+  # Compare two releases, formatted as a markdown table
+  kubectl gs get releases --diff aws-29.1.0 aws-30.0.0 --output markdown
+
+  # Watch for new releases
+  kubectl gs get releases --watch-only`
 )
 
 type Config struct {
 	Logger     micrologger.Logger
 	FileSystem afero.Fs
 
-	ConfigFlags *genericclioptions.RESTClientGetter
+	ConfigFlags       *genericclioptions.RESTClientGetter
+	InstallationCache *installation.CacheConfig
 
 	Stderr io.Writer
 	Stdout io.Writer
@@ -60,7 +72,8 @@ func New(config Config) (*cobra.Command, error) {
 
 	r := &runner{
 		commonConfig: &commonconfig.CommonConfig{
-			ConfigFlags: config.ConfigFlags,
+			ConfigFlags:       config.ConfigFlags,
+			InstallationCache: config.InstallationCache,
 		},
 		flag:   f,
 		logger: config.Logger,
@@ -76,10 +89,11 @@ func New(config Config) (*cobra.Command, error) {
 		Long:    longDescription,
 		Example: examples,
 		Aliases: []string{alias},
-		Args:    cobra.MaximumNArgs(1),
+		Args:    cobra.MaximumNArgs(2),
 		RunE:    r.Run,
 		PreRunE: middleware.Compose(
 			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
 		),
 	}
 
@@ -1,18 +1,36 @@
 package releases
 
 import (
+	"fmt"
+
+	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+)
+
+const (
+	flagActiveOnly = "active-only"
+	flagDiff       = "diff"
+	flagWatch      = "watch"
+	flagWatchOnly  = "watch-only"
 )
 
 type flag struct {
 	ActiveOnly bool
+	Diff       bool
+	Watch      bool
+	WatchOnly  bool
 
 	print *genericclioptions.PrintFlags
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
-	cmd.Flags().BoolVar(&f.ActiveOnly, "active-only", false, "Only show active releases")
+	cmd.Flags().BoolVar(&f.ActiveOnly, flagActiveOnly, false, "Only show active releases")
+	cmd.Flags().BoolVar(&f.Diff, flagDiff, false, fmt.Sprintf("Compare the components and apps of two releases, given as arguments. Supports the %q, %q and %q output formats besides the default table.", output.TypeJSON, output.TypeYAML, output.TypeMarkdown))
+	cmd.Flags().BoolVarP(&f.Watch, flagWatch, "w", false, "After listing/getting the requested object(s), watch for changes.")
+	cmd.Flags().BoolVar(&f.WatchOnly, flagWatchOnly, false, "Watch for changes to the requested object(s), without listing/getting first.")
 
 	f.print = genericclioptions.NewPrintFlags("")
 
@@ -22,5 +40,21 @@ func (f *flag) Init(cmd *cobra.Command) {
 }
 
 func (f *flag) Validate() error {
+	if f.Diff {
+		switch *f.print.OutputFormat {
+		case output.TypeDefault, output.TypeJSON, output.TypeYAML, output.TypeMarkdown:
+		default:
+			return microerror.Maskf(invalidFlagError, "--%s does not support the output format %q", flagDiff, *f.print.OutputFormat)
+		}
+		if f.ActiveOnly {
+			return microerror.Maskf(invalidFlagError, "--%s and --%s are mutually exclusive", flagDiff, flagActiveOnly)
+		}
+		if f.Watch || f.WatchOnly {
+			return microerror.Maskf(invalidFlagError, "--%s cannot be combined with --%s or --%s", flagDiff, flagWatch, flagWatchOnly)
+		}
+	} else if output.IsOutputMarkdown(f.print.OutputFormat) {
+		return microerror.Maskf(invalidFlagError, "the output format %q requires --%s", output.TypeMarkdown, flagDiff)
+	}
+
 	return nil
 }
@@ -1,6 +1,7 @@
 package releases
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/yaml"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/release"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
@@ -59,6 +61,7 @@ func (r *runner) printOutput(rResource release.Resource) error {
 
 		resource = table
 		printOptions := printers.PrintOptions{
+			NoHeaders:     r.noHeaders,
 			WithNamespace: false,
 		}
 		printer = printers.NewTablePrinter(printOptions)
@@ -166,3 +169,35 @@ func getReleaseStatus(status releasev1alpha1.ReleaseState) string {
 
 	return strings.ToUpper(status.String())
 }
+
+func (r *runner) printDiffOutput(d *release.Diff) error {
+	switch *r.flag.print.OutputFormat {
+	case output.TypeJSON:
+		data, err := json.MarshalIndent(d, "", "    ")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprintf(r.stdout, "%s\n", data)
+
+	case output.TypeYAML:
+		data, err := yaml.Marshal(d)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprint(r.stdout, string(data))
+
+	case output.TypeMarkdown:
+		fmt.Fprint(r.stdout, d.Markdown())
+
+	default:
+		table := d.Table(true)
+
+		printer := printers.NewTablePrinter(printers.PrintOptions{})
+		err := printer.PrintObj(table, r.stdout)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package releases
+
+import (
+	"bytes"
+	goflag "flag"
+	"testing"
+
+	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/release"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/test/goldenfile"
+)
+
+var update = goflag.Bool("update", false, "update .golden reference test files")
+
+// Test_printDiffOutput uses golden files.
+//
+// go test ./cmd/get/releases -run Test_printDiffOutput -update
+func Test_printDiffOutput(t *testing.T) {
+	testCases := []struct {
+		name               string
+		outputType         string
+		expectedGoldenFile string
+	}{
+		{
+			name:               "case 0: print release diff, with table output",
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "print_release_diff_table_output.golden",
+		},
+		{
+			name:               "case 1: print release diff, with JSON output",
+			outputType:         output.TypeJSON,
+			expectedGoldenFile: "print_release_diff_json_output.golden",
+		},
+		{
+			name:               "case 2: print release diff, with YAML output",
+			outputType:         output.TypeYAML,
+			expectedGoldenFile: "print_release_diff_yaml_output.golden",
+		},
+		{
+			name:               "case 3: print release diff, with markdown output",
+			outputType:         output.TypeMarkdown,
+			expectedGoldenFile: "print_release_diff_markdown_output.golden",
+		},
+	}
+
+	from := newRelease("aws-25.0.0",
+		[]releasev1alpha1.ReleaseSpecComponent{
+			{Name: "flatcar", Version: "3815.2.0"},
+			{Name: "kubernetes", Version: "1.25.16"},
+			{Name: "os-tooling", Version: "1.0.0"},
+		},
+		[]releasev1alpha1.ReleaseSpecApp{
+			{Name: "cilium", Version: "0.9.0"},
+			{Name: "coredns", Version: "1.21.0"},
+		},
+	)
+	to := newRelease("aws-26.0.0",
+		[]releasev1alpha1.ReleaseSpecComponent{
+			{Name: "flatcar", Version: "3815.2.0"},
+			{Name: "kubernetes", Version: "1.26.11"},
+		},
+		[]releasev1alpha1.ReleaseSpecApp{
+			{Name: "cilium", Version: "1.0.0"},
+			{Name: "coredns", Version: "1.20.0"},
+			{Name: "observability-bundle", Version: "1.2.0"},
+		},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			flag := &flag{
+				Diff:  true,
+				print: genericclioptions.NewPrintFlags("").WithDefaultOutput(tc.outputType),
+			}
+			out := new(bytes.Buffer)
+			runner := &runner{
+				flag:   flag,
+				stdout: out,
+			}
+
+			err := runner.printDiffOutput(release.Compare(from, to))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			var expectedResult []byte
+			{
+				gf := goldenfile.New("testdata", tc.expectedGoldenFile)
+				if *update {
+					err = gf.Update(out.Bytes())
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+					expectedResult = out.Bytes()
+				} else {
+					expectedResult, err = gf.Read()
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+				}
+			}
+
+			diff := cmp.Diff(string(expectedResult), out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func newRelease(name string, components []releasev1alpha1.ReleaseSpecComponent, apps []releasev1alpha1.ReleaseSpecApp) *releasev1alpha1.Release {
+	return &releasev1alpha1.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: releasev1alpha1.ReleaseSpec{
+			Components: components,
+			Apps:       apps,
+		},
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"io"
 	"strings"
 
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/release"
@@ -25,6 +27,10 @@ type runner struct {
 	provider string
 	service  release.Interface
 
+	// noHeaders is set once the first table has been printed in watch mode,
+	// so that subsequent rows line up below it.
+	noHeaders bool
+
 	stdout io.Writer
 	stderr io.Writer
 }
@@ -62,19 +68,33 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		}
 	}
 
-	var resource release.Resource
-	{
-		options := release.GetOptions{
-			Provider:   r.provider,
-			Namespace:  metav1.NamespaceAll,
-			ActiveOnly: r.flag.ActiveOnly,
+	if r.flag.Diff {
+		if len(args) != 2 {
+			return microerror.Maskf(invalidFlagError, "--%s requires exactly two release versions as arguments", flagDiff)
 		}
-		{
-			if len(args) > 0 {
-				options.Name = strings.ToLower(args[0])
-			}
+
+		return r.runDiff(ctx, strings.ToLower(args[0]), strings.ToLower(args[1]))
+	} else if len(args) > 1 {
+		return microerror.Maskf(invalidFlagError, "only one release version can be given, unless --%s is used", flagDiff)
+	}
+
+	options := release.GetOptions{
+		Provider:   r.provider,
+		Namespace:  metav1.NamespaceAll,
+		ActiveOnly: r.flag.ActiveOnly,
+	}
+	{
+		if len(args) > 0 {
+			options.Name = strings.ToLower(args[0])
 		}
+	}
 
+	if r.flag.Watch || r.flag.WatchOnly {
+		return r.watch(ctx, options)
+	}
+
+	var resource release.Resource
+	{
 		resource, err = r.service.Get(ctx, options)
 		if release.IsNotFound(err) {
 			return microerror.Maskf(notFoundError, "A release with name '%s' cannot be found.\n", options.Name)
@@ -95,12 +115,111 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	return nil
 }
 
+func (r *runner) runDiff(ctx context.Context, fromName, toName string) error {
+	from, err := r.getRelease(ctx, fromName)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	to, err := r.getRelease(ctx, toName)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.printDiffOutput(release.Compare(from.CR, to.CR))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) getRelease(ctx context.Context, name string) (*release.Release, error) {
+	options := release.GetOptions{
+		Provider:  r.provider,
+		Namespace: metav1.NamespaceAll,
+		Name:      name,
+	}
+
+	resource, err := r.service.Get(ctx, options)
+	if release.IsNotFound(err) {
+		return nil, microerror.Maskf(notFoundError, "A release with name '%s' cannot be found.\n", name)
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return resource.(*release.Release), nil
+}
+
+func (r *runner) watch(ctx context.Context, options release.GetOptions) error {
+	events, err := r.service.Watch(ctx, options)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !r.flag.WatchOnly {
+		resource, err := r.service.Get(ctx, options)
+		switch {
+		case release.IsNotFound(err):
+			return microerror.Maskf(notFoundError, "A release with name '%s' cannot be found.\n", options.Name)
+		case release.IsNoResources(err):
+			// There is nothing to list yet, but releases may still be
+			// created while watching.
+		case err != nil:
+			return microerror.Mask(err)
+		default:
+			err = r.printWatchEvent(watch.Added, resource)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	for event := range events {
+		if event.Type == watch.Error {
+			return microerror.Mask(event.Err)
+		}
+
+		err = r.printWatchEvent(event.Type, event.Resource)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *runner) printWatchEvent(eventType watch.EventType, resource release.Resource) error {
+	if !output.IsOutputDefault(r.flag.print.OutputFormat) {
+		err := output.PrintWatchEvent(r.stdout, r.flag.print, eventType, resource.Object())
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err := r.printOutput(resource)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	r.noHeaders = true
+
+	return nil
+}
+
 func (r *runner) getService() error {
 	if r.service != nil {
 		return nil
 	}
 
-	client, err := r.commonConfig.GetClient(r.logger)
+	var client k8sclient.Interface
+	var err error
+	if r.flag.Watch || r.flag.WatchOnly {
+		client, err = r.commonConfig.GetWatchingClient(r.logger)
+	} else {
+		client, err = r.commonConfig.GetClient(r.logger)
+	}
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -13,9 +13,11 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/oidc"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/tokencache"
 )
 
 type authInfo struct {
@@ -30,7 +32,7 @@ type authInfo struct {
 
 // storeMCCredentials stores the installation's CA certificate, and
 // updates the kubeconfig with the configuration for the k8s api access.
-func storeMCCredentials(k8sConfigAccess clientcmd.ConfigAccess, i *installation.Installation, authResult authInfo, internalAPI bool, switchContext bool) error {
+func storeMCCredentials(k8sConfigAccess clientcmd.ConfigAccess, i *installation.Installation, authResult authInfo, internalAPI bool, switchContext bool, execCredential bool) error {
 	config, err := k8sConfigAccess.GetStartingConfig()
 	if err != nil {
 		return microerror.Mask(err)
@@ -46,19 +48,7 @@ func storeMCCredentials(k8sConfigAccess clientcmd.ConfigAccess, i *installation.
 			initialUser = clientcmdapi.NewAuthInfo()
 		}
 
-		if len(authResult.clientID) > 0 {
-			initialUser.AuthProvider = &clientcmdapi.AuthProviderConfig{
-				Name: "oidc",
-				Config: map[string]string{
-					ClientID:     authResult.clientID,
-					IDToken:      authResult.token,
-					Issuer:       i.AuthURL,
-					RefreshToken: authResult.refreshToken,
-				},
-			}
-		} else {
-			initialUser.Token = authResult.token
-		}
+		configureMCUser(initialUser, i, authResult, execCredential)
 
 		// Add user information to config.
 		config.AuthInfos[kUsername] = initialUser
@@ -111,6 +101,33 @@ func storeMCCredentials(k8sConfigAccess clientcmd.ConfigAccess, i *installation.
 	return nil
 }
 
+// configureMCUser sets the credentials of a management cluster kubeconfig
+// user. OIDC logins either use the oidc auth provider, or the exec credential
+// plugin implemented by 'kubectl gs credential'.
+func configureMCUser(user *clientcmdapi.AuthInfo, i *installation.Installation, authResult authInfo, execCredential bool) {
+	if len(authResult.clientID) < 1 {
+		user.Token = authResult.token
+		return
+	}
+
+	if execCredential {
+		user.AuthProvider = nil
+		user.Exec = kubeconfig.NewCredentialExecConfig(i.AuthURL, authResult.clientID)
+		return
+	}
+
+	user.Exec = nil
+	user.AuthProvider = &clientcmdapi.AuthProviderConfig{
+		Name: "oidc",
+		Config: map[string]string{
+			ClientID:     authResult.clientID,
+			IDToken:      authResult.token,
+			Issuer:       i.AuthURL,
+			RefreshToken: authResult.refreshToken,
+		},
+	}
+}
+
 func VerifyIDTokenWithKubernetesAPI(idToken, apiServerURL string, caData []byte) error {
 	config := &rest.Config{
 		Host:        apiServerURL,
@@ -140,7 +157,7 @@ func VerifyIDTokenWithKubernetesAPI(idToken, apiServerURL string, caData []byte)
 
 // printMCCredentials saves the installation's CA certificate, and
 // writes the configuration for the k8s api access into a separate file.
-func printMCCredentials(k8sConfigAccess clientcmd.ConfigAccess, i *installation.Installation, authResult authInfo, fs afero.Fs, internalAPI bool, filePath string) error {
+func printMCCredentials(k8sConfigAccess clientcmd.ConfigAccess, i *installation.Installation, authResult authInfo, fs afero.Fs, internalAPI bool, filePath string, execCredential bool) error {
 	kUsername := fmt.Sprintf("gs-%s-%s", authResult.username, i.Codename)
 	contextName := kubeconfig.GenerateKubeContextName(i.Codename)
 	clusterName := fmt.Sprintf("gs-%s", i.Codename)
@@ -155,21 +172,7 @@ func printMCCredentials(k8sConfigAccess clientcmd.ConfigAccess, i *installation.
 	}
 
 	authInfo := clientcmdapi.NewAuthInfo()
-	{
-		if len(authResult.clientID) > 0 {
-			authInfo.AuthProvider = &clientcmdapi.AuthProviderConfig{
-				Name: "oidc",
-				Config: map[string]string{
-					ClientID:     authResult.clientID,
-					IDToken:      authResult.token,
-					Issuer:       i.AuthURL,
-					RefreshToken: authResult.refreshToken,
-				},
-			}
-		} else {
-			authInfo.Token = authResult.token
-		}
-	}
+	configureMCUser(authInfo, i, authResult, execCredential)
 
 	kubeconfig := clientcmdapi.Config{
 		APIVersion: "v1",
@@ -260,6 +263,27 @@ func switchContext(ctx context.Context, k8sConfigAccess clientcmd.ConfigAccess,
 			authProvider.Config[RefreshToken] = rToken
 			authProvider.Config[IDToken] = idToken
 		}
+	} else if authType == kubeconfig.AuthTypeExec {
+		exec, _ := kubeconfig.GetExecConfig(config, newContextName)
+		if issuer, clientID, ok := kubeconfig.GetCredentialExecParams(exec); ok {
+			if isContextAlreadySelected {
+				return microerror.Mask(contextAlreadySelectedError)
+			}
+
+			// Make sure the cached tokens are still usable, so that the
+			// credential plugin doesn't fail on the next request.
+			cacheDir, err := key.GetCacheDir()
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			_, _, err = tokencache.GetFreshToken(ctx, cacheDir, issuer, clientID)
+			if tokencache.IsNotFound(err) {
+				return microerror.Mask(newLoginRequiredError)
+			} else if err != nil {
+				return microerror.Mask(tokenRenewalFailedError)
+			}
+		}
 	} else if authType == kubeconfig.AuthTypeUnknown {
 		return microerror.Maskf(incorrectConfigurationError, "There is no authentication configuration for the '%s' context", newContextName)
 	}
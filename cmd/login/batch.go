@@ -0,0 +1,218 @@
+package login
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/errorprinter"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+)
+
+// batchEntry is an installation listed in the file given with --all-from.
+type batchEntry struct {
+	// Identifier is the URL, code name or context name as listed.
+	Identifier string
+
+	installation *installation.Installation
+	err          error
+}
+
+// loginFromFile logs in to all installations listed in a file. The
+// installations are resolved concurrently. Installations sharing an
+// authentication provider are logged in to with a single login.
+func (r *runner) loginFromFile(ctx context.Context, path string) error {
+	identifiers, err := readInstallationList(path)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	entries := r.resolveInstallations(ctx, identifiers)
+
+	var issuers []string
+	entriesByIssuer := map[string][]*batchEntry{}
+	for _, entry := range entries {
+		if entry.err != nil {
+			continue
+		}
+
+		issuer := entry.installation.AuthURL
+		if _, ok := entriesByIssuer[issuer]; !ok {
+			issuers = append(issuers, issuer)
+		}
+		entriesByIssuer[issuer] = append(entriesByIssuer[issuer], entry)
+	}
+
+	tokenOverride := r.commonConfig.GetTokenOverride()
+	for _, issuer := range issuers {
+		group := entriesByIssuer[issuer]
+
+		var codenames []string
+		for _, entry := range group {
+			codenames = append(codenames, entry.installation.Codename)
+		}
+		fmt.Fprintf(r.stdout, "Logging in to %s.\n", strings.Join(codenames, ", "))
+
+		authResult, err := r.authenticate(ctx, tokenOverride, group[0].installation)
+		if err != nil {
+			for _, entry := range group {
+				entry.err = err
+			}
+			continue
+		}
+
+		for _, entry := range group {
+			entry.err = r.storeCredentials(entry.installation, authResult)
+		}
+	}
+
+	err = r.printBatchReport(entries)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var failed int
+	for _, entry := range entries {
+		if entry.err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return microerror.Maskf(batchLoginFailedError, "Logging in to %d of %d installations failed.", failed, len(entries))
+	}
+
+	return nil
+}
+
+// resolveInstallations looks up the installation info of all entries
+// concurrently. Code names and context names are resolved through the API
+// server URL of the existing context.
+func (r *runner) resolveInstallations(ctx context.Context, identifiers []string) []*batchEntry {
+	config, configErr := r.commonConfig.GetConfigAccess().GetStartingConfig()
+
+	entries := make([]*batchEntry, len(identifiers))
+
+	var wg sync.WaitGroup
+	for idx, identifier := range identifiers {
+		entry := &batchEntry{Identifier: identifier}
+		entries[idx] = entry
+
+		path := identifier
+		if isContext, _ := kubeconfig.IsKubeContext(identifier); isContext || kubeconfig.IsCodeName(identifier) {
+			if configErr != nil {
+				entry.err = microerror.Mask(configErr)
+				continue
+			}
+
+			contextName := identifier
+			if !isContext {
+				contextName = kubeconfig.GenerateKubeContextName(identifier)
+			}
+
+			server, ok := kubeconfig.GetClusterServer(config, contextName)
+			if !ok {
+				entry.err = microerror.Maskf(contextDoesNotExistError, "There is no context named '%s'. Please list the Management API URL instead.", contextName)
+				continue
+			}
+			path = server
+		}
+
+		wg.Add(1)
+		go func(entry *batchEntry, path string) {
+			defer wg.Done()
+
+			i, err := r.getInstallation(ctx, path)
+			if installation.IsUnknownUrlType(err) {
+				entry.err = microerror.Maskf(unknownUrlError, "'%s' is not a valid Giant Swarm Management API URL.", path)
+				return
+			} else if err != nil {
+				entry.err = microerror.Mask(err)
+				return
+			}
+
+			entry.installation = i
+		}(entry, path)
+	}
+
+	wg.Wait()
+
+	return entries
+}
+
+func (r *runner) getInstallation(ctx context.Context, path string) (*installation.Installation, error) {
+	if r.installationGetter != nil {
+		return r.installationGetter(ctx, path)
+	}
+
+	i, err := r.commonConfig.NewInstallation(ctx, path, "")
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return i, nil
+}
+
+func (r *runner) printBatchReport(entries []*batchEntry) error {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "INSTALLATION\tCONTEXT\tRESULT")
+	for _, entry := range entries {
+		contextName := "n/a"
+		if entry.installation != nil {
+			contextName = kubeconfig.GenerateKubeContextName(entry.installation.Codename)
+		}
+
+		result := "Logged in"
+		if entry.err != nil {
+			result = fmt.Sprintf("Failed: %s", errorprinter.FormatSingleLine(entry.err))
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Identifier, contextName, result)
+	}
+
+	err := w.Flush()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	fmt.Fprintf(r.stdout, "\n%s", buf.String())
+	fmt.Fprint(r.stdout, color.YellowString("\nThe current context was not changed. To switch to one of these contexts, use 'kubectl gs login <context>'.\n"))
+
+	return nil
+}
+
+// readInstallationList reads the installations from a file, skipping empty
+// lines and comments starting with '#'.
+func readInstallationList(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, microerror.Maskf(invalidFlagError, "--%s: %s", flagAllFrom, err.Error())
+	}
+
+	var identifiers []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		identifiers = append(identifiers, strings.ToLower(line))
+	}
+
+	if len(identifiers) < 1 {
+		return nil, microerror.Maskf(invalidFlagError, "--%s: the file %s does not list any installations", flagAllFrom, path)
+	}
+
+	return identifiers, nil
+}
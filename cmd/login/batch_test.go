@@ -0,0 +1,171 @@
+package login
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/ptr"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
+	kubeconfigpkg "github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	testoidc "github.com/giantswarm/kubectl-gs/v5/test/oidc"
+)
+
+func Test_loginFromFile(t *testing.T) {
+	alpha := testoidc.NewServer(testoidc.MockOidcServerConfig{ClientID: clientID, InstallationCodename: "alpha"})
+	beta := testoidc.NewServer(testoidc.MockOidcServerConfig{ClientID: clientID, InstallationCodename: "beta"})
+	for _, s := range []*testoidc.MockOidcServer{alpha, beta} {
+		err := s.Start(t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Stop()
+	}
+
+	// sharedIssuer resolves the installations alpha and beta, which share
+	// the authentication provider of alpha.
+	sharedIssuer := func(ctx context.Context, path string) (*installation.Installation, error) {
+		i := CreateTestInstallationWithIssuer(alpha.Issuer())
+		i.Codename = strings.Split(strings.TrimPrefix(path, "https://api."), ".")[0]
+		return i, nil
+	}
+
+	testCases := []struct {
+		name string
+
+		list               string
+		installationGetter func(ctx context.Context, path string) (*installation.Installation, error)
+
+		expectError      *microerror.Error
+		expectedContexts []string
+		expectedLogins   int
+		expectedOutput   []string
+	}{
+		{
+			name:             "case 0: installations with different issuers, by URL and code name",
+			list:             "# Management clusters\n\n" + alpha.Issuer() + "\nbeta\n",
+			expectedContexts: []string{"gs-alpha", "gs-beta"},
+			expectedLogins:   2,
+			expectedOutput: []string{
+				"Logging in to alpha.\n",
+				"Logging in to beta.\n",
+				"gs-alpha   Logged in\n",
+				"\nbeta ",
+				"gs-beta    Logged in\n",
+			},
+		},
+		{
+			name:               "case 1: installations sharing an issuer",
+			list:               "https://api.alpha.example.com\nhttps://api.beta.example.com\n",
+			installationGetter: sharedIssuer,
+			expectedContexts:   []string{"gs-alpha", "gs-beta"},
+			expectedLogins:     1,
+			expectedOutput: []string{
+				"Logging in to alpha, beta.\n",
+				"https://api.alpha.example.com   gs-alpha   Logged in\n",
+				"https://api.beta.example.com    gs-beta    Logged in\n",
+			},
+		},
+		{
+			name:             "case 2: installations which cannot be resolved",
+			list:             alpha.Issuer() + "\ndelta\nexample.com\n",
+			expectError:      batchLoginFailedError,
+			expectedContexts: []string{"gs-alpha"},
+			expectedLogins:   1,
+			expectedOutput: []string{
+				"Failed: Context does not exist: There is no context named 'gs-delta'.",
+				"Failed: Unknown url: 'example.com' is not a valid Giant Swarm Management API URL.",
+			},
+		},
+		{
+			name:        "case 3: empty list",
+			list:        "# Nothing here\n",
+			expectError: invalidFlagError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configDir := t.TempDir()
+			listPath := configDir + "/installations"
+			err := os.WriteFile(listPath, []byte(tc.list), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cf := genericclioptions.NewConfigFlags(true)
+			cf.KubeConfig = ptr.To[string](fmt.Sprintf("%s/config.yaml", configDir))
+
+			out := new(bytes.Buffer)
+			r := runner{
+				commonConfig: commonconfig.New(cf),
+				flag: &flag{
+					AllFrom:      listPath,
+					DeviceAuth:   true,
+					LoginTimeout: 60 * time.Second,
+				},
+				installationGetter: tc.installationGetter,
+				stdout:             out,
+				stderr:             out,
+				fs:                 afero.NewBasePathFs(afero.NewOsFs(), configDir),
+			}
+
+			startConfig := createValidTestConfig("", false)
+			startConfig.Clusters["gs-beta"] = &clientcmdapi.Cluster{Server: beta.Issuer()}
+			startConfig.Contexts["gs-beta"] = &clientcmdapi.Context{Cluster: "gs-beta", AuthInfo: "gs-user-beta"}
+			startConfig.AuthInfos["gs-user-beta"] = &clientcmdapi.AuthInfo{Token: "token"}
+
+			k8sConfigAccess := r.commonConfig.GetConfigAccess()
+			err = clientcmd.ModifyConfig(k8sConfigAccess, *startConfig, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			r.setLoginOptions(ctx, &[]string{})
+
+			err = r.run(ctx, nil, nil)
+			if err != nil {
+				if microerror.Cause(err) != tc.expectError {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+			} else if tc.expectError != nil {
+				t.Fatalf("unexpected success")
+			}
+
+			config, err := k8sConfigAccess.GetStartingConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.CurrentContext != startConfig.CurrentContext {
+				t.Fatalf("expected to keep context %s, got context %s", startConfig.CurrentContext, config.CurrentContext)
+			}
+			for _, contextName := range tc.expectedContexts {
+				if _, exists := kubeconfigpkg.GetAuthProvider(config, contextName); !exists {
+					t.Fatalf("expected context %s to use the oidc auth provider", contextName)
+				}
+			}
+
+			outStr := out.String()
+			if logins := strings.Count(outStr, "Open this URL in the browser to log in"); logins != tc.expectedLogins {
+				t.Fatalf("expected %d logins, got %d:\n%s", tc.expectedLogins, logins, outStr)
+			}
+			for _, expected := range tc.expectedOutput {
+				if !strings.Contains(outStr, expected) {
+					t.Fatalf("output does not contain expected string:\nvalue: %s\nexpected string: %s\n", outStr, expected)
+				}
+			}
+		})
+	}
+}
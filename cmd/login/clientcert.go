@@ -2,32 +2,18 @@ package login
 
 import (
 	"context"
-	"crypto/rand"
-	"crypto/rsa"
-	"crypto/sha256"
-	"crypto/x509"
-	"crypto/x509/pkix"
-	"encoding/pem"
 	"fmt"
-	"math"
-	"math/big"
 	"strings"
 	"sync"
-	"time"
 
 	"dario.cat/mergo"
-	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
-	"github.com/giantswarm/backoff"
 	"github.com/giantswarm/k8smetadata/pkg/label"
 	"github.com/giantswarm/microerror"
 	"github.com/spf13/afero"
-	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/giantswarm/kubectl-gs/v5/internal/key"
-	kgslabel "github.com/giantswarm/kubectl-gs/v5/internal/label"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/clientcert"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/organization"
@@ -35,15 +21,6 @@ import (
 	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
 )
 
-const (
-	credentialRetryTimeout    = 1 * time.Second
-	credentialMaxRetryTimeout = 10 * time.Second
-
-	credentialKeyCertCRT = "crt"
-	credentialKeyCertKey = "key"
-	credentialKeyCertCA  = "ca"
-)
-
 type clientCertConfig struct {
 	provider            string
 	clusterName         string
@@ -73,199 +50,8 @@ type clientCertCredentialConfig struct {
 	filePath      string
 	proxy         bool
 	proxyPort     int
-}
-
-func generateClientCertUID() string {
-	hash := sha256.New()
-	_, _ = hash.Write([]byte(time.Now().String()))
-
-	uid := fmt.Sprintf("%x", hash.Sum(nil))
-
-	return uid[:16]
-}
-
-func generateClientCert(config clientCertConfig) (*clientcert.ClientCert, error) {
-	clientCertUID := generateClientCertUID()
-	clientCertName := fmt.Sprintf("%s-%s", config.clusterName, clientCertUID)
-	var clientCertCNPrefix string
-	{
-		if config.cnPrefix != "" {
-			clientCertCNPrefix = config.cnPrefix
-		} else {
-			clientCertCNPrefix = clientCertUID
-		}
-	}
-	commonName := fmt.Sprintf("%s.%s.k8s.%s", clientCertCNPrefix, config.clusterName, config.clusterBasePath)
-
-	certConfig := &corev1alpha1.CertConfig{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      clientCertName,
-			Namespace: config.clusterNamespace,
-			Labels: map[string]string{
-				kgslabel.CertOperatorVersion: config.certOperatorVersion,
-				kgslabel.Certificate:         clientCertUID,
-				label.Cluster:                config.clusterName,
-				label.Organization:           config.organizationName,
-			},
-		},
-		Spec: corev1alpha1.CertConfigSpec{
-			Cert: corev1alpha1.CertConfigSpecCert{
-				AllowBareDomains:    true,
-				ClusterComponent:    clientCertUID,
-				ClusterID:           config.clusterName,
-				CommonName:          commonName,
-				DisableRegeneration: true,
-				Organizations:       config.groups,
-				TTL:                 config.ttl,
-			},
-			VersionBundle: corev1alpha1.CertConfigSpecVersionBundle{
-				Version: config.certOperatorVersion,
-			},
-		},
-	}
-
-	r := &clientcert.ClientCert{
-		CertConfig: certConfig,
-	}
-
-	return r, nil
-}
-
-func createCert(ctx context.Context, clientCertService clientcert.Interface, config clientCertConfig) (*clientcert.ClientCert, error) {
-	clientCert, err := generateClientCert(config)
-	if err != nil {
-		return nil, microerror.Mask(err)
-	}
-	return clientCert, nil
-}
-
-// fetchCredential tries to fetch the client certificate credential
-// for a couple of times, until the resource is fetched, or until the timeout is reached.
-func fetchCredential(ctx context.Context, provider string, clientCertService clientcert.Interface, clientCert *clientcert.ClientCert) (*corev1.Secret, error) {
-	var secret *corev1.Secret
-	var err error
-
-	o := func() error {
-		secret, err = clientCertService.GetCredential(ctx, clientCert.CertConfig.GetNamespace(), clientCert.CertConfig.Name)
-		if clientcert.IsNotFound(err) {
-			// Client certificate credential has not been created yet, try again until it is.
-			return microerror.Mask(err)
-		} else if err != nil {
-			return backoff.Permanent(microerror.Mask(err))
-		}
-
-		return nil
-	}
-	b := backoff.NewConstant(credentialMaxRetryTimeout, credentialRetryTimeout)
-
-	err = backoff.Retry(o, b)
-	if clientcert.IsNotFound(err) {
-		if provider == key.ProviderAzure {
-			// Try in default namespace for legacy azure clusters.
-			secret, err = clientCertService.GetCredential(ctx, metav1.NamespaceDefault, clientCert.CertConfig.Name)
-		}
-		if clientcert.IsNotFound(err) {
-			return nil, microerror.Maskf(credentialRetrievalTimedOut, "failed to get the client certificate credential on time")
-		}
-	} else if err != nil {
-		return nil, microerror.Mask(err)
-	}
-
-	return secret, nil
-}
-
-func generateCredential(ctx context.Context, ca *corev1.Secret, config clientCertConfig) (*corev1.Secret, error) {
-	var caPEM, certPEM, keyPEM []byte
-	{
-		// Get the WCs CA data
-		caPEM = ca.Data["tls.crt"]
-		caCert, err := getCert(caPEM)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-		caPrivKeyPEM := ca.Data["tls.key"]
-		caPrivKey, err := getPrivKey(caPrivKeyPEM)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-
-		// Create the Certificate
-		exp, err := time.ParseDuration(config.ttl)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-		serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
-		if err != nil {
-			return nil, microerror.Maskf(invalidConfigError, "Failed to generate client certificate serial number.")
-		}
-		var clientCertCNPrefix string
-		{
-			if config.cnPrefix != "" {
-				clientCertCNPrefix = config.cnPrefix
-			} else {
-				clientCertCNPrefix = serial.String()
-			}
-		}
-		certificate := &x509.Certificate{
-			SerialNumber:       serial,
-			SignatureAlgorithm: x509.SHA256WithRSA,
-			Subject: pkix.Name{
-				Organization: config.groups,
-				CommonName:   fmt.Sprintf("%s.%s.%s", clientCertCNPrefix, config.clusterName, config.clusterBasePath),
-			},
-			NotBefore:   time.Now(),
-			NotAfter:    time.Now().Add(exp),
-			KeyUsage:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement,
-			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
-		}
-
-		// Create the key
-		certPrivKey, err := rsa.GenerateKey(rand.Reader, 2048)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-		keyPEM = getPrivKeyPEM(certPrivKey)
-
-		// Sign the certificate
-		certBytes, err := x509.CreateCertificate(rand.Reader, certificate, caCert, &certPrivKey.PublicKey, caPrivKey)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-		certPEM = getCertPEM(certBytes)
-	}
-	secret := &corev1.Secret{
-		Data: map[string][]byte{
-			credentialKeyCertCA:  caPEM,
-			credentialKeyCertCRT: certPEM,
-			credentialKeyCertKey: keyPEM,
-		},
-		Type: corev1.SecretTypeTLS,
-	}
-	return secret, nil
-}
-
-func getCertPEM(cert []byte) []byte {
-	return pem.EncodeToMemory(&pem.Block{
-		Type:  "CERTIFICATE",
-		Bytes: cert,
-	})
-}
-
-func getCert(certPEM []byte) (*x509.Certificate, error) {
-	block, _ := pem.Decode(certPEM)
-	return x509.ParseCertificate(block.Bytes)
-}
-
-func getPrivKeyPEM(key *rsa.PrivateKey) []byte {
-	return pem.EncodeToMemory(&pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(key),
-	})
-}
-
-func getPrivKey(keyPEM []byte) (*rsa.PrivateKey, error) {
-	block, _ := pem.Decode(keyPEM)
-	return x509.ParsePKCS1PrivateKey(block.Bytes)
+	// certInfo is stored in the context, to renew the certificate without a new login.
+	certInfo *kubeconfig.ClientCertInfo
 }
 
 // storeWCClientCertCredentials saves the created client certificate credentials into the kubectl config.
@@ -328,6 +114,18 @@ func storeWCClientCertCredentials(k8sConfigAccess clientcmd.ConfigAccess, c clie
 		context.Cluster = clusterName
 		context.AuthInfo = userName
 
+		if c.certInfo != nil {
+			certInfo := *c.certInfo
+			certInfo.MCContextName = mcContextName
+
+			err = kubeconfig.SetClientCertInfo(context, certInfo)
+			if err != nil {
+				return "", contextExists, microerror.Mask(err)
+			}
+		} else {
+			delete(context.Extensions, kubeconfig.ClientCertExtensionName)
+		}
+
 		// Add context configuration to config.
 		config.Contexts[contextName] = context
 
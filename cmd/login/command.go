@@ -11,7 +11,9 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
 )
 
@@ -61,6 +63,36 @@ Management cluster:
 
   kubectl gs login mymc
 
+Management cluster, using the client-go credential plugin instead of the
+oidc auth provider, so that kubectl, Helm and Flux can renew tokens on their own:
+
+  kubectl gs login mymc --` + flagExecCredential + `
+
+Management cluster, without user interaction, e.g. in CI pipelines. Either
+with the client credentials of a Dex client, or by exchanging the OIDC token
+of the CI system for a Dex token. The tokens cannot be renewed, so log in
+again once they expire:
+
+  export ` + envClientID + `=ci-client
+  export ` + envClientSecret + `=...
+  kubectl gs login mymc --` + flagClientCredentials + `
+
+  kubectl gs login mymc --` + flagClientCredentials + ` --` + flagClientCredentialsFile + ` /path/to/credentials.yaml
+
+  kubectl gs login mymc --` + flagTokenExchange + ` /path/to/ci-id-token --` + flagConnectorID + ` github-actions
+
+All management clusters listed in a file, one URL, code name or context name
+per line. Installations with the same authentication provider share one login.
+The current context is kept:
+
+  kubectl gs login --` + flagAllFrom + ` ~/installations.txt
+
+Keep each context in its own file in a directory, instead of in the
+kubeconfig. The KUBECONFIG value to use the files is printed. Use
+'kubectl gs contexts' to list, prune and select the files:
+
+  kubectl gs login mymc --` + flagKubeconfigDir + ` ~/.kube/kubeconfig.d
+
 Workload cluster:
 
   kubectl gs login https://api.example.g8s.test.eu-west-1.aws.gigantic.io
@@ -83,7 +115,8 @@ type Config struct {
 	Logger     micrologger.Logger
 	FileSystem afero.Fs
 
-	ConfigFlags *genericclioptions.RESTClientGetter
+	ConfigFlags       *genericclioptions.RESTClientGetter
+	InstallationCache *installation.CacheConfig
 
 	Stderr io.Writer
 	Stdout io.Writer
@@ -114,7 +147,8 @@ func New(config Config) (*cobra.Command, error) {
 		fs:     config.FileSystem,
 
 		commonConfig: &commonconfig.CommonConfig{
-			ConfigFlags: config.ConfigFlags,
+			ConfigFlags:       config.ConfigFlags,
+			InstallationCache: config.InstallationCache,
 		},
 		stderr: config.Stderr,
 		stdout: config.Stdout,
@@ -128,6 +162,7 @@ func New(config Config) (*cobra.Command, error) {
 		RunE:    r.Run,
 		PreRunE: middleware.Compose(
 			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
 		),
 	}
 
@@ -119,15 +119,6 @@ func IsAuthResponseTimedOut(err error) bool {
 	return microerror.Cause(err) == authResponseTimedOutError
 }
 
-var credentialRetrievalTimedOut = &microerror.Error{
-	Kind: "credentialRetrievalTimedOut",
-}
-
-// IsCredentialRetrievalTimedOut asserts credentialRetrievalTimedOut.
-func IsCredentialRetrievalTimedOut(err error) bool {
-	return microerror.Cause(err) == credentialRetrievalTimedOut
-}
-
 var organizationNotFoundError = &microerror.Error{
 	Kind: "organizationNotFoundError",
 }
@@ -253,3 +244,21 @@ var deviceAuthError = &microerror.Error{
 func IsDeviceAuthError(err error) bool {
 	return microerror.Cause(err) == deviceAuthError
 }
+
+var nonInteractiveAuthError = &microerror.Error{
+	Kind: "nonInteractiveAuthError",
+}
+
+// IsNonInteractiveAuthError asserts nonInteractiveAuthError.
+func IsNonInteractiveAuthError(err error) bool {
+	return microerror.Cause(err) == nonInteractiveAuthError
+}
+
+var batchLoginFailedError = &microerror.Error{
+	Kind: "batchLoginFailedError",
+}
+
+// IsBatchLoginFailed asserts batchLoginFailedError.
+func IsBatchLoginFailed(err error) bool {
+	return microerror.Cause(err) == batchLoginFailedError
+}
@@ -35,7 +35,19 @@ const (
 
 	flagDeviceAuth = "device-auth"
 
-	envKeepContext = "KUBECTL_GS_LOGIN_KEEP_CONTEXT"
+	flagExecCredential = "exec-credential"
+
+	flagClientCredentials     = "client-credentials"
+	flagClientCredentialsFile = "client-credentials-file"
+	flagTokenExchange         = "token-exchange"
+
+	flagAllFrom = "all-from"
+
+	flagKubeconfigDir = "kubeconfig-dir"
+
+	envKeepContext  = "KUBECTL_GS_LOGIN_KEEP_CONTEXT"
+	envClientID     = "KUBECTL_GS_LOGIN_CLIENT_ID"
+	envClientSecret = "KUBECTL_GS_LOGIN_CLIENT_SECRET"
 )
 
 var (
@@ -67,6 +79,16 @@ type flag struct {
 	LoginTimeout time.Duration
 
 	DeviceAuth bool
+
+	ExecCredential bool
+
+	ClientCredentials     bool
+	ClientCredentialsFile string
+	TokenExchange         string
+
+	AllFrom string
+
+	KubeconfigDir string
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
@@ -83,7 +105,7 @@ func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&f.WCName, flagWCName, "", "For client certificate creation. Specify the name of a workload cluster to work with. If omitted, a management cluster will be accessed.")
 	cmd.Flags().StringVar(&f.WCOrganization, flagWCOrganization, "", fmt.Sprintf("For client certificate creation. Organization that owns the workload cluster. Requires --%s.", flagWCName))
 	cmd.Flags().StringSliceVar(&f.WCCertGroups, flagWCCertGroups, nil, fmt.Sprintf("For client certificate creation. RBAC group name to be encoded into the X.509 field \"O\". Requires --%s.", flagWCName))
-	cmd.Flags().StringVar(&f.WCCertTTL, flagWCCertTTL, "1h", fmt.Sprintf(`For client certificate creation. How long the client certificate should live for. Valid time units are "ms", "s", "m", "h". Requires --%s.`, flagWCName))
+	cmd.Flags().StringVar(&f.WCCertTTL, flagWCCertTTL, "1h", fmt.Sprintf(`For client certificate creation. How long the client certificate should live for. Valid time units are "ms", "s", "m", "h". The certificate is renewed automatically once expired. Requires --%s.`, flagWCName))
 	cmd.Flags().StringVar(&f.WCCertCNPrefix, flagWCCertCNPrefix, "", fmt.Sprintf(`For client certificate creation. Prefix for the name encoded in the X.509 field "CN". Requires --%s.`, flagWCName))
 	cmd.Flags().BoolVar(&f.WCInsecureNamespace, flagWCInsecureNamespace, false, fmt.Sprintf(`For client certificate creation. Allow using an insecure namespace for creating the client certificate. Requires --%s.`, flagWCName))
 
@@ -98,6 +120,16 @@ func (f *flag) Init(cmd *cobra.Command) {
 
 	cmd.Flags().BoolVar(&f.DeviceAuth, flagDeviceAuth, false, "Use device authentication flow to log in")
 
+	cmd.Flags().BoolVar(&f.ExecCredential, flagExecCredential, false, "Configure the management cluster context to obtain OIDC tokens through 'kubectl gs credential' (client-go credential plugin), instead of the deprecated oidc auth provider.")
+
+	cmd.Flags().BoolVar(&f.ClientCredentials, flagClientCredentials, false, fmt.Sprintf("Log in without user interaction, using the OAuth2 client credentials of a Dex client. The client ID and secret are read from the %s and %s environment variables, or from the file given with --%s.", envClientID, envClientSecret, flagClientCredentialsFile))
+	cmd.Flags().StringVar(&f.ClientCredentialsFile, flagClientCredentialsFile, "", fmt.Sprintf("Path to a YAML file with the 'client-id' and 'client-secret' to use with --%s.", flagClientCredentials))
+	cmd.Flags().StringVar(&f.TokenExchange, flagTokenExchange, "", fmt.Sprintf("Log in without user interaction, by exchanging the OIDC ID token of a CI system, read from the file at this path, for a Dex token. Requires --%s, naming the Dex connector which trusts the CI system.", flagConnectorID))
+
+	cmd.Flags().StringVar(&f.AllFrom, flagAllFrom, "", "Log in to all management clusters listed in this file, one URL, code name or context name per line. Installations with the same authentication provider share one login. The current context is kept.")
+
+	cmd.Flags().StringVar(&f.KubeconfigDir, flagKubeconfigDir, "", "Write each context to its own file in this directory, e.g. ~/.kube/kubeconfig.d, instead of into the kubeconfig. The KUBECONFIG value to use the files is printed. Use 'kubectl gs contexts' to manage the files.")
+
 	_ = cmd.Flags().MarkHidden(flagWCInsecureNamespace)
 	_ = cmd.Flags().MarkHidden("namespace")
 }
@@ -116,6 +148,35 @@ func (f *flag) Validate() error {
 		return microerror.Maskf(invalidFlagError, `--%s cannot be negative or zero`, flagLoginTimeout)
 	}
 
+	if f.ClientCredentials && f.TokenExchange != "" {
+		return microerror.Maskf(invalidFlagError, "--%s and --%s cannot be combined", flagClientCredentials, flagTokenExchange)
+	}
+	if f.DeviceAuth && (f.ClientCredentials || f.TokenExchange != "") {
+		return microerror.Maskf(invalidFlagError, "--%s cannot be combined with --%s or --%s", flagDeviceAuth, flagClientCredentials, flagTokenExchange)
+	}
+	if f.ClientCredentialsFile != "" && !f.ClientCredentials {
+		return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagClientCredentialsFile, flagClientCredentials)
+	}
+	if f.TokenExchange != "" && f.ConnectorID == "" {
+		return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagTokenExchange, flagConnectorID)
+	}
+	// The credential plugin renews tokens with the refresh token, which
+	// is not issued for these grants.
+	if f.ExecCredential && (f.ClientCredentials || f.TokenExchange != "") {
+		return microerror.Maskf(invalidFlagError, "--%s cannot be combined with --%s or --%s, as no refresh token is issued to renew the tokens", flagExecCredential, flagClientCredentials, flagTokenExchange)
+	}
+
+	if f.AllFrom != "" && f.WCName != "" {
+		return microerror.Maskf(invalidFlagError, "--%s and --%s cannot be combined", flagAllFrom, flagWCName)
+	}
+	if f.AllFrom != "" && f.SelfContained != "" {
+		return microerror.Maskf(invalidFlagError, "--%s and --%s cannot be combined", flagAllFrom, flagSelfContained)
+	}
+
+	if f.KubeconfigDir != "" && f.SelfContained != "" {
+		return microerror.Maskf(invalidFlagError, "--%s and --%s cannot be combined", flagKubeconfigDir, flagSelfContained)
+	}
+
 	keepContextEnvVar := viper.GetString(envKeepContext)
 	if keepContextEnvVar != "" && keepContextEnvVar != "true" && keepContextEnvVar != "false" {
 		return microerror.Maskf(invalidFlagError, "KUBECTL_GS_LOGIN_KEEP_CONTEXT environment variable must be either 'true' or 'false'")
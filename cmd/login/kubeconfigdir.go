@@ -0,0 +1,119 @@
+package login
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+)
+
+// runWithKubeconfigDir logs in like run, but keeps each context in its own
+// file in the directory given with --kubeconfig-dir. The login works on a
+// temporary kubeconfig merged from these files. Afterwards, the contexts which
+// were added or changed are written to their files, even if the login failed
+// partially.
+func (r *runner) runWithKubeconfigDir(ctx context.Context, cmd *cobra.Command, args []string) error {
+	dir := r.flag.KubeconfigDir
+
+	before, err := kubeconfig.LoadDir(dir)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// The current context is taken from the kubeconfig in use, which
+	// usually lists the files of the directory already.
+	currentContext, _, err := r.tryToGetCurrentContexts(ctx)
+	if _, exists := before.Contexts[currentContext]; err == nil && exists {
+		before.CurrentContext = currentContext
+	} else {
+		before.CurrentContext = ""
+	}
+
+	workingFile, err := os.CreateTemp("", "kubectl-gs-kubeconfig-*.yaml")
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	workingPath := workingFile.Name()
+	defer os.Remove(workingPath) // nolint:errcheck
+
+	err = workingFile.Close()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	err = clientcmd.WriteToFile(*before, workingPath)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	commonConfig := r.commonConfig
+	r.commonConfig = commonConfig.WithKubeconfig(workingPath)
+	defer func() {
+		r.commonConfig = commonConfig
+	}()
+
+	r.setLoginOptions(ctx, &args)
+	runErr := r.run(ctx, cmd, args)
+
+	err = r.storeKubeconfigDir(dir, before, workingPath)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if runErr != nil {
+		return microerror.Mask(runErr)
+	}
+
+	return nil
+}
+
+// storeKubeconfigDir writes the contexts of the temporary kubeconfig which
+// differ from those in the directory to their files, and prints the
+// KUBECONFIG value selecting the current context.
+func (r *runner) storeKubeconfigDir(dir string, before *clientcmdapi.Config, workingPath string) error {
+	after, err := clientcmd.LoadFromFile(workingPath)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var contextNames []string
+	for contextName := range after.Contexts {
+		contextNames = append(contextNames, contextName)
+	}
+	sort.Strings(contextNames)
+
+	for _, contextName := range contextNames {
+		current, _ := kubeconfig.ExtractContext(after, contextName)
+		if previous, exists := kubeconfig.ExtractContext(before, contextName); exists && reflect.DeepEqual(current, previous) {
+			continue
+		}
+
+		path, err := kubeconfig.WriteDirFile(dir, after, contextName)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprintf(r.stdout, "Wrote context '%s' to %s.\n", contextName, path)
+	}
+
+	paths, err := kubeconfig.ListDirFiles(dir)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if len(paths) < 1 {
+		return nil
+	}
+
+	var selectedPath string
+	if after.CurrentContext != "" {
+		selectedPath = kubeconfig.GetDirFilePath(dir, after.CurrentContext)
+	}
+	fmt.Fprintf(r.stdout, "\nTo use the contexts in %s, run:\n\n  export KUBECONFIG=%s\n", dir, kubeconfig.GetDirPathList(paths, selectedPath))
+
+	return nil
+}
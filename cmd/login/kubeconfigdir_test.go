@@ -0,0 +1,161 @@
+package login
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/ptr"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	kubeconfigpkg "github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	testoidc "github.com/giantswarm/kubectl-gs/v5/test/oidc"
+)
+
+func Test_runWithKubeconfigDir(t *testing.T) {
+	s := testoidc.NewServer(testoidc.MockOidcServerConfig{ClientID: clientID, InstallationCodename: "alpha"})
+	err := s.Start(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+
+	otherConfig := clientcmdapi.NewConfig()
+	otherConfig.CurrentContext = "gs-other"
+	otherConfig.Clusters["gs-other"] = &clientcmdapi.Cluster{Server: "https://api.other.example.com"}
+	otherConfig.Contexts["gs-other"] = &clientcmdapi.Context{Cluster: "gs-other", AuthInfo: "gs-user-other"}
+	otherConfig.AuthInfos["gs-user-other"] = &clientcmdapi.AuthInfo{Token: "token"}
+
+	staleConfig := clientcmdapi.NewConfig()
+	staleConfig.CurrentContext = "gs-alpha"
+	staleConfig.Clusters["gs-alpha"] = &clientcmdapi.Cluster{Server: s.Issuer()}
+	staleConfig.Contexts["gs-alpha"] = &clientcmdapi.Context{Cluster: "gs-alpha", AuthInfo: "gs-user-alpha"}
+	staleConfig.AuthInfos["gs-user-alpha"] = &clientcmdapi.AuthInfo{Token: "expired"}
+
+	testCases := []struct {
+		name string
+
+		dirFiles      map[string]*clientcmdapi.Config
+		kubeconfig    *clientcmdapi.Config
+		expectWritten []string
+		// expectedPathList lists the files in KUBECONFIG, relative to the
+		// directory.
+		expectedPathList []string
+	}{
+		{
+			name:             "case 0: empty directory",
+			expectWritten:    []string{"gs-alpha"},
+			expectedPathList: []string{"gs-alpha.yaml"},
+		},
+		{
+			name: "case 1: existing files, with the current context selected in KUBECONFIG",
+			dirFiles: map[string]*clientcmdapi.Config{
+				"gs-alpha.yaml": staleConfig,
+				"gs-other.yaml": otherConfig,
+			},
+			kubeconfig:       otherConfig,
+			expectWritten:    []string{"gs-alpha"},
+			expectedPathList: []string{"gs-other.yaml", "gs-alpha.yaml"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configDir := t.TempDir()
+			dir := filepath.Join(configDir, "kubeconfig.d")
+			for name, config := range tc.dirFiles {
+				err := os.MkdirAll(dir, 0700)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = clientcmd.WriteToFile(*config, filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			kubeconfigPath := fmt.Sprintf("%s/config.yaml", configDir)
+			if tc.kubeconfig != nil {
+				err := clientcmd.WriteToFile(*tc.kubeconfig, kubeconfigPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			listPath := configDir + "/installations"
+			err := os.WriteFile(listPath, []byte(s.Issuer()), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cf := genericclioptions.NewConfigFlags(true)
+			cf.KubeConfig = ptr.To[string](kubeconfigPath)
+
+			out := new(bytes.Buffer)
+			r := runner{
+				commonConfig: commonconfig.New(cf),
+				flag: &flag{
+					AllFrom:       listPath,
+					DeviceAuth:    true,
+					KubeconfigDir: dir,
+					LoginTimeout:  60 * time.Second,
+				},
+				stdout: out,
+				stderr: out,
+				fs:     afero.NewBasePathFs(afero.NewOsFs(), configDir),
+			}
+
+			err = r.runWithKubeconfigDir(context.Background(), nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			// The kubeconfig is left alone.
+			if tc.kubeconfig == nil {
+				if _, err := os.Stat(kubeconfigPath); !os.IsNotExist(err) {
+					t.Fatalf("expected kubeconfig %s not to be written", kubeconfigPath)
+				}
+			}
+
+			outStr := out.String()
+			for _, contextName := range tc.expectWritten {
+				path := kubeconfigpkg.GetDirFilePath(dir, contextName)
+				if !strings.Contains(outStr, fmt.Sprintf("Wrote context '%s' to %s.\n", contextName, path)) {
+					t.Fatalf("expected context %s to be written, got output:\n%s", contextName, outStr)
+				}
+
+				config, err := clientcmd.LoadFromFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if config.CurrentContext != contextName || len(config.Contexts) != 1 {
+					t.Fatalf("expected file %s to hold only context %s, got %v", path, contextName, config.Contexts)
+				}
+				if _, exists := kubeconfigpkg.GetAuthProvider(config, contextName); !exists {
+					t.Fatalf("expected context %s to use the oidc auth provider", contextName)
+				}
+			}
+			if strings.Count(outStr, "Wrote context") != len(tc.expectWritten) {
+				t.Fatalf("expected %d contexts to be written, got output:\n%s", len(tc.expectWritten), outStr)
+			}
+
+			var pathList []string
+			for _, name := range tc.expectedPathList {
+				pathList = append(pathList, filepath.Join(dir, name))
+			}
+			expectedExport := fmt.Sprintf("export KUBECONFIG=%s\n", strings.Join(pathList, string(filepath.ListSeparator)))
+			if !strings.Contains(outStr, expectedExport) {
+				t.Fatalf("output does not contain expected string:\nvalue: %s\nexpected string: %s\n", outStr, expectedExport)
+			}
+		})
+	}
+}
@@ -9,9 +9,13 @@ import (
 	"github.com/fatih/color"
 	"github.com/giantswarm/microerror"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/tokencache"
 )
 
 func (r *runner) findContext(ctx context.Context, installationIdentifier string) (bool, error) {
@@ -32,7 +36,15 @@ func (r *runner) loginWithKubeContextName(ctx context.Context, contextName strin
 	var newLoginRequired bool
 	k8sConfigAccess := r.commonConfig.GetConfigAccess()
 
-	err := switchContext(ctx, k8sConfigAccess, contextName, r.loginOptions.switchToContext)
+	// Renew expiring workload cluster client certificates before switching to them.
+	renewed, err := renewclientcert.Renew(ctx, k8sConfigAccess, contextName)
+	if err != nil {
+		fmt.Fprint(r.stderr, color.YellowString("Warning: The client certificate of context '%s' could not be renewed: %s\n", contextName, err))
+	} else if renewed {
+		fmt.Fprint(r.stdout, color.GreenString("Renewed the client certificate of context '%s'.\n", contextName))
+	}
+
+	err = switchContext(ctx, k8sConfigAccess, contextName, r.loginOptions.switchToContext)
 	if IsContextAlreadySelected(err) {
 		contextAlreadySelected = true
 	} else if IsNewLoginRequired(err) || IsTokenRenewalFailed(err) {
@@ -46,9 +58,14 @@ func (r *runner) loginWithKubeContextName(ctx context.Context, contextName strin
 		return microerror.Mask(err)
 	}
 
+	// Switching an existing context to the credential plugin requires a new login,
+	// as the plugin reads the tokens from its own cache.
+	if r.flag.ExecCredential && !isCredentialExecContext(config, contextName) {
+		newLoginRequired = true
+	}
+
 	if newLoginRequired || r.loginOptions.selfContained {
-		authType := kubeconfig.GetAuthType(config, contextName)
-		if authType == kubeconfig.AuthTypeAuthProvider {
+		if kubeconfig.GetAuthType(config, contextName) == kubeconfig.AuthTypeAuthProvider || isCredentialExecContext(config, contextName) {
 			// If we get here, we are sure that the kubeconfig context exists.
 			server, _ := kubeconfig.GetClusterServer(config, contextName)
 
@@ -138,6 +155,27 @@ func (r *runner) loginWithURL(ctx context.Context, path string, firstLogin bool,
 }
 
 func (r *runner) loginWithInstallation(ctx context.Context, tokenOverride string, i *installation.Installation) error {
+	authResult, err := r.authenticate(ctx, tokenOverride, i)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.storeCredentials(i, authResult)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if len(authResult.email) > 0 {
+		fmt.Fprint(r.stdout, color.GreenString("Logged in successfully as '%s' on cluster '%s'.\n\n", authResult.email, i.Codename))
+	} else {
+		fmt.Fprint(r.stdout, color.GreenString("Logged in successfully as '%s' on cluster '%s'.\n\n", authResult.username, i.Codename))
+	}
+	return nil
+}
+
+// authenticate obtains the credentials for an installation, either from the
+// token override or from the installation's authentication provider.
+func (r *runner) authenticate(ctx context.Context, tokenOverride string, i *installation.Installation) (authInfo, error) {
 	k8sConfigAccess := r.commonConfig.GetConfigAccess()
 
 	var err error
@@ -150,7 +188,21 @@ func (r *runner) loginWithInstallation(ctx context.Context, tokenOverride string
 			}
 		} else {
 			contextName := kubeconfig.GenerateKubeContextName(i.Codename)
-			if r.flag.DeviceAuth || r.isDeviceAuthContext(k8sConfigAccess, contextName) {
+			if r.flag.ClientCredentials {
+				var credentials clientCredentials
+				credentials, err = readClientCredentials(r.flag.ClientCredentialsFile)
+				if err != nil {
+					return authInfo{}, microerror.Mask(err)
+				}
+				authResult, err = handleClientCredentialsOIDC(ctx, r.stderr, i, credentials, r.flag.InternalAPI)
+			} else if r.flag.TokenExchange != "" {
+				var subjectToken string
+				subjectToken, err = readSubjectToken(r.flag.TokenExchange)
+				if err != nil {
+					return authInfo{}, microerror.Mask(err)
+				}
+				authResult, err = handleTokenExchangeOIDC(ctx, r.stderr, i, r.flag.ConnectorID, subjectToken, r.flag.InternalAPI)
+			} else if r.flag.DeviceAuth || r.isDeviceAuthContext(k8sConfigAccess, contextName) {
 				authResult, err = handleDeviceFlowOIDC(r.stdout, r.stderr, i, r.flag.InternalAPI)
 			} else {
 				authResult, err = handleOIDC(ctx, r.stdout, r.stderr, i, r.flag.ConnectorID, r.flag.ClusterAdmin, r.flag.InternalAPI, r.flag.CallbackServerHost, r.flag.CallbackServerPort, r.flag.LoginTimeout)
@@ -158,34 +210,48 @@ func (r *runner) loginWithInstallation(ctx context.Context, tokenOverride string
 					fmt.Fprintf(r.stderr, "\nYour authentication flow timed out after %s. Please execute the same command again.\n", r.flag.LoginTimeout.String())
 					fmt.Fprintf(r.stderr, "You can use the --login-timeout flag to configure a longer timeout interval, for example --login-timeout=%.0fs.\n", 2*r.flag.LoginTimeout.Seconds())
 					if errors.Is(err, context.DeadlineExceeded) {
-						return microerror.Maskf(authResponseTimedOutError, "failed to get an authentication response on time")
+						return authInfo{}, microerror.Maskf(authResponseTimedOutError, "failed to get an authentication response on time")
 					}
 				}
 			}
 			if err != nil {
-				return microerror.Mask(err)
+				return authInfo{}, microerror.Mask(err)
 			}
 
 		}
 	}
+
+	return authResult, nil
+}
+
+// storeCredentials writes the credentials of an installation into the
+// kubeconfig, or into the self-contained file.
+func (r *runner) storeCredentials(i *installation.Installation, authResult authInfo) error {
+	k8sConfigAccess := r.commonConfig.GetConfigAccess()
+
+	var err error
+	execCredential := r.flag.ExecCredential || r.isCredentialExecContext(k8sConfigAccess, kubeconfig.GenerateKubeContextName(i.Codename))
+	if execCredential && len(authResult.clientID) > 0 {
+		// The credential plugin picks the tokens up from the cache.
+		err = storeCachedTokens(i, authResult)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
 	if r.loginOptions.selfContained {
-		err = printMCCredentials(k8sConfigAccess, i, authResult, r.fs, r.flag.InternalAPI, r.flag.SelfContained)
+		err = printMCCredentials(k8sConfigAccess, i, authResult, r.fs, r.flag.InternalAPI, r.flag.SelfContained, execCredential)
 		if err != nil {
 			return microerror.Mask(err)
 		}
 	} else {
 		// Store kubeconfig and CA certificate.
-		err = storeMCCredentials(k8sConfigAccess, i, authResult, r.flag.InternalAPI, r.loginOptions.switchToContext)
+		err = storeMCCredentials(k8sConfigAccess, i, authResult, r.flag.InternalAPI, r.loginOptions.switchToContext, execCredential)
 		if err != nil {
 			return microerror.Mask(err)
 		}
 	}
 
-	if len(authResult.email) > 0 {
-		fmt.Fprint(r.stdout, color.GreenString("Logged in successfully as '%s' on cluster '%s'.\n\n", authResult.email, i.Codename))
-	} else {
-		fmt.Fprint(r.stdout, color.GreenString("Logged in successfully as '%s' on cluster '%s'.\n\n", authResult.username, i.Codename))
-	}
 	return nil
 }
 
@@ -205,3 +271,46 @@ func (r *runner) isDeviceAuthContext(k8sConfigAccess clientcmd.ConfigAccess, con
 func isDeviceAuthInfo(authInfo string) bool {
 	return strings.HasSuffix(authInfo, "-device")
 }
+
+func (r *runner) isCredentialExecContext(k8sConfigAccess clientcmd.ConfigAccess, contextName string) bool {
+	config, err := k8sConfigAccess.GetStartingConfig()
+	if err != nil {
+		return false
+	}
+
+	return isCredentialExecContext(config, contextName)
+}
+
+// isCredentialExecContext checks whether a context obtains its tokens
+// through the 'kubectl gs credential' exec plugin.
+func isCredentialExecContext(config *clientcmdapi.Config, contextName string) bool {
+	exec, exists := kubeconfig.GetExecConfig(config, contextName)
+	if !exists {
+		return false
+	}
+
+	_, _, ok := kubeconfig.GetCredentialExecParams(exec)
+
+	return ok
+}
+
+// storeCachedTokens writes the tokens of an OIDC login result
+// into the cache used by the credential plugin.
+func storeCachedTokens(i *installation.Installation, authResult authInfo) error {
+	cacheDir, err := key.GetCacheDir()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	entry := tokencache.Entry{
+		IDToken:      authResult.token,
+		RefreshToken: authResult.refreshToken,
+	}
+
+	err = tokencache.Persist(cacheDir, i.AuthURL, authResult.clientID, entry)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package login
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/oidc"
+)
+
+const (
+	// automationUsername is used for non-interactive logins, if the ID
+	// token does not tell who logged in.
+	automationUsername = "automation"
+)
+
+var (
+	// Refresh tokens are not issued without user interaction, so
+	// offline_access is not requested.
+	nonInteractiveScopes = [...]string{gooidc.ScopeOpenID, "profile", "email", "groups", "audience:server:client_id:dex-k8s-authenticator"}
+)
+
+type clientCredentials struct {
+	ClientID     string `json:"client-id"`
+	ClientSecret string `json:"client-secret"`
+}
+
+// handleClientCredentialsOIDC obtains an ID token from an installation's
+// authentication provider with the OAuth2 client credentials of a client,
+// without user interaction.
+func handleClientCredentialsOIDC(ctx context.Context, errOut io.Writer, i *installation.Installation, credentials clientCredentials, internalAPI bool) (authInfo, error) {
+	oidcConfig := oidc.Config{
+		ClientID:     credentials.ClientID,
+		ClientSecret: credentials.ClientSecret,
+		Issuer:       i.AuthURL,
+		AuthScopes:   nonInteractiveScopes[:],
+	}
+	auther, err := oidc.New(ctx, oidcConfig)
+	if err != nil {
+		return authInfo{}, microerror.Mask(err)
+	}
+
+	user, err := auther.GetClientCredentialsToken(ctx)
+	if err != nil {
+		return authInfo{}, microerror.Maskf(nonInteractiveAuthError, "%s", err.Error())
+	}
+
+	authResult := newNonInteractiveAuthInfo(user, credentials.ClientID)
+	verifyNonInteractiveToken(errOut, i, authResult, internalAPI)
+
+	return authResult, nil
+}
+
+// handleTokenExchangeOIDC trades the ID token of a CI system for an ID token
+// of an installation's authentication provider, without user interaction.
+// The Dex connector must trust the issuer of the CI system's token.
+func handleTokenExchangeOIDC(ctx context.Context, errOut io.Writer, i *installation.Installation, connectorID string, subjectToken string, internalAPI bool) (authInfo, error) {
+	oidcConfig := oidc.Config{
+		ClientID:   clientID,
+		Issuer:     i.AuthURL,
+		AuthScopes: nonInteractiveScopes[:],
+	}
+	auther, err := oidc.New(ctx, oidcConfig)
+	if err != nil {
+		return authInfo{}, microerror.Mask(err)
+	}
+
+	user, err := auther.ExchangeToken(ctx, connectorID, subjectToken)
+	if err != nil {
+		return authInfo{}, microerror.Maskf(nonInteractiveAuthError, "%s", err.Error())
+	}
+
+	authResult := newNonInteractiveAuthInfo(user, automationUsername)
+	verifyNonInteractiveToken(errOut, i, authResult, internalAPI)
+
+	return authResult, nil
+}
+
+func newNonInteractiveAuthInfo(user oidc.UserInfo, defaultUsername string) authInfo {
+	username := user.Username
+	if username == "" {
+		username = defaultUsername
+	}
+
+	return authInfo{
+		username: username,
+		token:    user.IDToken,
+		email:    user.Email,
+		clientID: user.ClientID,
+	}
+}
+
+func verifyNonInteractiveToken(errOut io.Writer, i *installation.Installation, authResult authInfo, internalAPI bool) {
+	apiServerURL := i.K8sApiURL
+	if internalAPI {
+		apiServerURL = i.K8sInternalApiURL
+	}
+
+	err := VerifyIDTokenWithKubernetesAPI(authResult.token, apiServerURL, []byte(i.CACert))
+	if err != nil {
+		fmt.Fprintf(errOut, "%s\n", color.YellowString("Non-interactive login succeeded but token verification returned error %s.", err.Error()))
+	}
+}
+
+// readClientCredentials returns the client credentials from the file, if
+// one is given, or from the environment.
+func readClientCredentials(path string) (clientCredentials, error) {
+	var credentials clientCredentials
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return clientCredentials{}, microerror.Maskf(invalidFlagError, "--%s: %s", flagClientCredentialsFile, err.Error())
+		}
+
+		err = yaml.Unmarshal(data, &credentials)
+		if err != nil {
+			return clientCredentials{}, microerror.Maskf(invalidFlagError, "--%s: %s", flagClientCredentialsFile, err.Error())
+		}
+
+		if credentials.ClientID == "" || credentials.ClientSecret == "" {
+			return clientCredentials{}, microerror.Maskf(invalidFlagError, "--%s: the file must contain 'client-id' and 'client-secret'", flagClientCredentialsFile)
+		}
+	} else {
+		credentials.ClientID = os.Getenv(envClientID)
+		credentials.ClientSecret = os.Getenv(envClientSecret)
+
+		if credentials.ClientID == "" || credentials.ClientSecret == "" {
+			return clientCredentials{}, microerror.Maskf(invalidFlagError, "--%s requires the %s and %s environment variables, or --%s", flagClientCredentials, envClientID, envClientSecret, flagClientCredentialsFile)
+		}
+	}
+
+	return credentials, nil
+}
+
+// readSubjectToken returns the ID token of the CI system from a file.
+func readSubjectToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", microerror.Maskf(invalidFlagError, "--%s: %s", flagTokenExchange, err.Error())
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", microerror.Maskf(invalidFlagError, "--%s: the file %s is empty", flagTokenExchange, path)
+	}
+
+	return token, nil
+}
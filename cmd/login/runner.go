@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
 )
 
@@ -24,6 +25,10 @@ type runner struct {
 	commonConfig *commonconfig.CommonConfig
 	loginOptions LoginOptions
 
+	// installationGetter, if set, replaces commonConfig.NewInstallation for
+	// the installations listed with --all-from.
+	installationGetter func(ctx context.Context, path string) (*installation.Installation, error)
+
 	stdout io.Writer
 	stderr io.Writer
 }
@@ -46,6 +51,15 @@ func (r *runner) Run(cmd *cobra.Command, args []string) error {
 		return microerror.Mask(err)
 	}
 
+	if r.flag.KubeconfigDir != "" {
+		err = r.runWithKubeconfigDir(ctx, cmd, args)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
 	r.setLoginOptions(ctx, &args)
 	err = r.run(ctx, cmd, args)
 	if err != nil {
@@ -56,6 +70,19 @@ func (r *runner) Run(cmd *cobra.Command, args []string) error {
 }
 
 func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	if r.flag.AllFrom != "" {
+		if len(args) > 0 {
+			return microerror.Maskf(invalidConfigError, "No arguments can be given with --%s.", flagAllFrom)
+		}
+
+		err := r.loginFromFile(ctx, r.flag.AllFrom)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
 	switch len(args) {
 	// No arguments given - we try to reuse the existing context.
 	case 0:
@@ -132,9 +159,10 @@ func (r *runner) setLoginOptions(ctx context.Context, args *[]string) {
 	hasWCNameFlag := r.flag.WCName != ""
 	hasSelfContainedFlag := r.flag.SelfContained != ""
 	hasContextOverride := contextOverride != ""
+	hasAllFromFlag := r.flag.AllFrom != ""
 
 	// indicates whether it is desired to update current context in the kubeconfig file
-	shouldSwitchContextInConfig := !hasContextOverride && (hasWCNameFlag || !(hasSelfContainedFlag || r.flag.KeepContext))
+	shouldSwitchContextInConfig := !hasContextOverride && !hasAllFromFlag && (hasWCNameFlag || !(hasSelfContainedFlag || r.flag.KeepContext))
 
 	// indicates whether it is desired to update current context in the kubeconfig file to the wc client context
 	shouldSwitchToWCContextInConfig := hasWCNameFlag && !(hasSelfContainedFlag || r.flag.KeepContext)
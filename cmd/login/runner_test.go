@@ -19,8 +19,11 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
+	kubeconfigpkg "github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/tokencache"
 	"github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
 	testoidc "github.com/giantswarm/kubectl-gs/v5/test/oidc"
 )
@@ -592,6 +595,282 @@ func TestMCLoginWithInstallation(t *testing.T) {
 	}
 }
 
+func TestMCLoginWithExecCredential(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	configDir := t.TempDir()
+	cf := genericclioptions.NewConfigFlags(true)
+	cf.KubeConfig = ptr.To[string](fmt.Sprintf("%s/config.yaml", configDir))
+
+	s := testoidc.NewServer(testoidc.MockOidcServerConfig{ClientID: clientID})
+	err := s.Start(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+
+	out := new(bytes.Buffer)
+	r := runner{
+		commonConfig: commonconfig.New(cf),
+		flag: &flag{
+			DeviceAuth:     true,
+			ExecCredential: true,
+			LoginTimeout:   60 * time.Second,
+		},
+		stdout: out,
+		stderr: out,
+		fs:     afero.NewBasePathFs(afero.NewOsFs(), configDir),
+	}
+	k8sConfigAccess := r.commonConfig.GetConfigAccess()
+	err = clientcmd.ModifyConfig(k8sConfigAccess, *clientcmdapi.NewConfig(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r.setLoginOptions(ctx, &[]string{"codename"})
+
+	i := CreateTestInstallationWithIssuer(s.Issuer())
+	err = r.loginWithInstallation(ctx, "", i)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	config, err := k8sConfigAccess.GetStartingConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if kubeconfigpkg.GetAuthType(config, "gs-codename") != kubeconfigpkg.AuthTypeExec {
+		t.Fatal("expected the context to use the exec credential plugin")
+	}
+	if _, exists := kubeconfigpkg.GetAuthProvider(config, "gs-codename"); exists {
+		t.Fatal("expected the context to not use the oidc auth provider")
+	}
+
+	exec, _ := kubeconfigpkg.GetExecConfig(config, "gs-codename")
+	issuer, execClientID, ok := kubeconfigpkg.GetCredentialExecParams(exec)
+	if !ok || issuer != s.Issuer() || execClientID != clientID {
+		t.Fatalf("unexpected exec configuration: %v", exec)
+	}
+
+	cacheDir, err := key.GetCacheDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := tokencache.Load(cacheDir, s.Issuer(), clientID)
+	if err != nil {
+		t.Fatalf("expected tokens to be cached: %s", err)
+	}
+	if entry.RefreshToken != "refresh-token" {
+		t.Fatalf("unexpected cached refresh token %q", entry.RefreshToken)
+	}
+
+	// Switching to the context renews the cached tokens if needed.
+	config.CurrentContext = ""
+	err = clientcmd.ModifyConfig(k8sConfigAccess, *config, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = switchContext(ctx, k8sConfigAccess, "gs-codename", true)
+	if err != nil {
+		t.Fatalf("unexpected error switching context: %s", err.Error())
+	}
+}
+
+func TestMCLoginNonInteractive(t *testing.T) {
+	const (
+		ciClientID     = "ci-client"
+		ciClientSecret = "ci-secret"
+		ciToken        = "ci-token"
+	)
+
+	testCases := []struct {
+		name string
+
+		serverConfig testoidc.MockOidcServerConfig
+		flags        *flag
+		env          map[string]string
+		credentials  string
+		subjectToken string
+
+		expectError      *microerror.Error
+		expectedClientID string
+		expectedOutput   string
+	}{
+		{
+			name: "case 0: client credentials from the environment",
+			serverConfig: testoidc.MockOidcServerConfig{
+				ClientID:     ciClientID,
+				ClientSecret: ciClientSecret,
+			},
+			flags: &flag{ClientCredentials: true},
+			env: map[string]string{
+				envClientID:     ciClientID,
+				envClientSecret: ciClientSecret,
+			},
+			expectedClientID: ciClientID,
+			expectedOutput:   "Logged in successfully as 'ci-client' on cluster 'codename'.\n\n",
+		},
+		{
+			name: "case 1: client credentials from a file",
+			serverConfig: testoidc.MockOidcServerConfig{
+				ClientID:     ciClientID,
+				ClientSecret: ciClientSecret,
+			},
+			flags:            &flag{ClientCredentials: true},
+			credentials:      "client-id: ci-client\nclient-secret: ci-secret\n",
+			expectedClientID: ciClientID,
+			expectedOutput:   "Logged in successfully as 'ci-client' on cluster 'codename'.\n\n",
+		},
+		{
+			name: "case 2: client credentials with a wrong secret",
+			serverConfig: testoidc.MockOidcServerConfig{
+				ClientID:     ciClientID,
+				ClientSecret: ciClientSecret,
+			},
+			flags: &flag{ClientCredentials: true},
+			env: map[string]string{
+				envClientID:     ciClientID,
+				envClientSecret: "wrong-secret",
+			},
+			expectError: nonInteractiveAuthError,
+		},
+		{
+			name: "case 3: client credentials missing",
+			serverConfig: testoidc.MockOidcServerConfig{
+				ClientID:     ciClientID,
+				ClientSecret: ciClientSecret,
+			},
+			flags:       &flag{ClientCredentials: true},
+			expectError: invalidFlagError,
+		},
+		{
+			name: "case 4: token exchange",
+			serverConfig: testoidc.MockOidcServerConfig{
+				ClientID:     clientID,
+				SubjectToken: ciToken,
+			},
+			flags:            &flag{ConnectorID: "github-actions"},
+			subjectToken:     ciToken + "\n",
+			expectedClientID: clientID,
+			expectedOutput:   "Logged in successfully as 'automation' on cluster 'codename'.\n\n",
+		},
+		{
+			name: "case 5: token exchange with a token which is not trusted",
+			serverConfig: testoidc.MockOidcServerConfig{
+				ClientID:     clientID,
+				SubjectToken: ciToken,
+			},
+			flags:        &flag{ConnectorID: "github-actions"},
+			subjectToken: "other-token",
+			expectError:  nonInteractiveAuthError,
+		},
+		{
+			name: "case 6: token exchange with an empty token file",
+			serverConfig: testoidc.MockOidcServerConfig{
+				ClientID:     clientID,
+				SubjectToken: ciToken,
+			},
+			flags:        &flag{ConnectorID: "github-actions"},
+			subjectToken: "\n",
+			expectError:  invalidFlagError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(envClientID, "")
+			t.Setenv(envClientSecret, "")
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			configDir := t.TempDir()
+			if tc.credentials != "" {
+				tc.flags.ClientCredentialsFile = configDir + "/credentials.yaml"
+				err := os.WriteFile(tc.flags.ClientCredentialsFile, []byte(tc.credentials), 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.subjectToken != "" {
+				tc.flags.TokenExchange = configDir + "/token"
+				err := os.WriteFile(tc.flags.TokenExchange, []byte(tc.subjectToken), 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cf := genericclioptions.NewConfigFlags(true)
+			cf.KubeConfig = ptr.To[string](fmt.Sprintf("%s/config.yaml", configDir))
+
+			out := new(bytes.Buffer)
+			r := runner{
+				commonConfig: commonconfig.New(cf),
+				flag:         tc.flags,
+				stdout:       out,
+				stderr:       out,
+				fs:           afero.NewBasePathFs(afero.NewOsFs(), configDir),
+			}
+			k8sConfigAccess := r.commonConfig.GetConfigAccess()
+			err := clientcmd.ModifyConfig(k8sConfigAccess, *clientcmdapi.NewConfig(), false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := testoidc.NewServer(tc.serverConfig)
+			err = s.Start(t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Stop()
+
+			ctx := context.Background()
+			r.setLoginOptions(ctx, &[]string{"codename"})
+
+			err = r.loginWithInstallation(ctx, "", CreateTestInstallationWithIssuer(s.Issuer()))
+			if err != nil {
+				if microerror.Cause(err) != tc.expectError {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				return
+			} else if tc.expectError != nil {
+				t.Fatalf("unexpected success")
+			}
+
+			config, err := k8sConfigAccess.GetStartingConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.CurrentContext != "gs-codename" {
+				t.Fatalf("expected context gs-codename to be selected, got %q", config.CurrentContext)
+			}
+
+			authProvider, exists := kubeconfigpkg.GetAuthProvider(config, "gs-codename")
+			if !exists {
+				t.Fatal("expected the context to use the oidc auth provider")
+			}
+			if authProvider.Config[ClientID] != tc.expectedClientID {
+				t.Fatalf("expected client ID %q, got %q", tc.expectedClientID, authProvider.Config[ClientID])
+			}
+			if authProvider.Config[Issuer] != s.Issuer() {
+				t.Fatalf("expected issuer %q, got %q", s.Issuer(), authProvider.Config[Issuer])
+			}
+			if authProvider.Config[IDToken] == "" {
+				t.Fatal("expected an ID token")
+			}
+			if authProvider.Config[RefreshToken] != "" {
+				t.Fatalf("expected no refresh token, got %q", authProvider.Config[RefreshToken])
+			}
+
+			if !strings.Contains(out.String(), tc.expectedOutput) {
+				t.Fatalf("output does not contain expected string:\nvalue: %s\nexpected string: %s\n", out.String(), tc.expectedOutput)
+			}
+		})
+	}
+}
+
 func createValidTestConfig(wcSuffix string, authProvider bool) *clientcmdapi.Config {
 	const (
 		server       = "https://anything.com:8080"
@@ -15,6 +15,7 @@ import (
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/certificate"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/clientcert"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/organization"
@@ -241,9 +242,9 @@ func (r *runner) createCertKubeconfig(ctx context.Context, c *cluster.Cluster, s
 
 	credentialConfig := clientCertCredentialConfig{
 		clusterID:     r.flag.WCName,
-		certCRT:       secret.Data[credentialKeyCertCRT],
-		certKey:       secret.Data[credentialKeyCertKey],
-		certCA:        secret.Data[credentialKeyCertCA],
+		certCRT:       secret.Data[certificate.KeyCertCRT],
+		certKey:       secret.Data[certificate.KeyCertKey],
+		certCA:        secret.Data[certificate.KeyCertCA],
 		clusterServer: clusterServer,
 		filePath:      r.flag.SelfContained,
 		loginOptions:  r.loginOptions,
@@ -251,6 +252,19 @@ func (r *runner) createCertKubeconfig(ctx context.Context, c *cluster.Cluster, s
 		proxyPort:     r.flag.ProxyPort,
 	}
 
+	// Keep what is needed to renew the certificate automatically later on.
+	credentialConfig.certInfo = &kubeconfig.ClientCertInfo{
+		ClusterName:         certConfig.clusterName,
+		ClusterNamespace:    certConfig.clusterNamespace,
+		ClusterBasePath:     certConfig.clusterBasePath,
+		Groups:              certConfig.groups,
+		TTL:                 certConfig.ttl,
+		CNPrefix:            certConfig.cnPrefix,
+		CertOperatorVersion: certConfig.certOperatorVersion,
+		Organization:        certConfig.organizationName,
+		Provider:            certConfig.provider,
+	}
+
 	contextName, contextExists, err := r.storeWCClientCertCredentials(credentialConfig)
 	if err != nil {
 		return "", false, microerror.Mask(err)
@@ -309,35 +323,35 @@ func (r *runner) getCredentials(ctx context.Context, clientCertService clientcer
 
 	// If cert-operator is not running (as in CAPI) we attempt to use the MC PKI to create a certificate
 	if config.certOperatorVersion == "" {
-		// Retrieve the WC CA-secret.
-		ca, err := clientCertService.GetCredential(ctx, config.clusterNamespace, config.clusterName+"-ca")
-		if err != nil {
-			return nil, nil, microerror.Mask(err)
-		}
-		clientCertsecret, err = generateCredential(ctx, ca, config)
+		clientCertsecret, err = certificate.Issue(ctx, clientCertService, toCertificateConfig(config))
 		if err != nil {
 			return nil, nil, microerror.Mask(err)
 		}
 		return nil, clientCertsecret, nil
 	}
 
-	clientCert, err = createCert(ctx, clientCertService, config)
-	if err != nil {
-		return nil, nil, microerror.Mask(err)
-	}
-	// apply the certConfig
-	err = clientCertService.Create(ctx, clientCert)
-	if err != nil {
-		return nil, nil, microerror.Mask(err)
-	}
-	// Retrieve client certificate credential.
-	clientCertsecret, err = fetchCredential(ctx, config.provider, clientCertService, clientCert)
+	clientCert, clientCertsecret, err = certificate.Request(ctx, clientCertService, toCertificateConfig(config))
 	if err != nil {
 		return nil, nil, microerror.Mask(err)
 	}
 	return clientCert, clientCertsecret, nil
 }
 
+func toCertificateConfig(config clientCertConfig) certificate.Config {
+	return certificate.Config{
+		ClusterName:      config.clusterName,
+		ClusterNamespace: config.clusterNamespace,
+		ClusterBasePath:  config.clusterBasePath,
+		Groups:           config.groups,
+		TTL:              config.ttl,
+		CNPrefix:         config.cnPrefix,
+
+		CertOperatorVersion: config.certOperatorVersion,
+		Organization:        config.organizationName,
+		Provider:            config.provider,
+	}
+}
+
 func (r *runner) storeWCClientCertCredentials(c clientCertCredentialConfig) (string, bool, error) {
 	k8sConfigAccess := r.commonConfig.GetConfigAccess()
 	// Store client certificate credential either into the current kubeconfig or a self-contained file if a path is given.
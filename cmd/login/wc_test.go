@@ -36,6 +36,7 @@ import (
 	//nolint:staticcheck
 	"github.com/giantswarm/kubectl-gs/v5/internal/key"
 	"github.com/giantswarm/kubectl-gs/v5/internal/label"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/certificate"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/test/kubeclient"
 	testoidc "github.com/giantswarm/kubectl-gs/v5/test/oidc"
@@ -506,7 +507,7 @@ func createSecret(ctx context.Context, client k8sclient.Interface, provider stri
 		}
 		return nil
 	}
-	b := backoff.NewConstant(credentialMaxRetryTimeout, credentialRetryTimeout)
+	b := backoff.NewConstant(10*time.Second, 1*time.Second)
 
 	err = backoff.Retry(o, b)
 	if err != nil {
@@ -661,7 +662,7 @@ func getCAdata() map[string][]byte {
 	}
 	ca, _ := x509.CreateCertificate(rand.Reader, cert, cert, &privateKey.PublicKey, privateKey)
 	return map[string][]byte{
-		"tls.key": getPrivKeyPEM(privateKey),
-		"tls.crt": getCertPEM(ca),
+		"tls.key": certificate.EncodePrivateKeyPEM(privateKey),
+		"tls.crt": certificate.EncodeCertificatePEM(ca),
 	}
 }
@@ -0,0 +1,109 @@
+package logout
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+)
+
+const (
+	name = `logout <arg1> <arg2> [flags]
+
+Arguments <arg1> and <arg2> are optional and can take several forms.
+No arguments means that the currently selected context will be removed.
+
+Use <arg1> alone for:
+
+  - a context name, to remove only this context
+  - a Giant Swarm management cluster code name, to remove the management
+    cluster context and all workload cluster contexts belonging to it
+  - a management and workload cluster name, like 'mymc-mywc'
+
+Use <arg1> <arg2> for
+
+  - a Giant Swarm management cluster and a Giant Swarm workload cluster
+  `
+	shortDescription = "Removes contexts and credentials created by 'kubectl gs login'"
+	longDescription  = `Removes contexts and credentials created by 'kubectl gs login'
+
+Removing a context also removes the user and cluster entries it
+references, unless they are used by another context. OIDC refresh
+tokens are revoked at the issuer, if it supports token revocation.
+Certificate files stored by older kubectl-gs versions are deleted.
+
+If the current context gets removed, no context will be selected
+afterwards.`
+	examples = `
+  kubectl gs logout mymc
+
+  kubectl gs logout mymc mywc
+
+  kubectl gs logout gs-mymc-mywc-clientcert
+
+  kubectl gs logout --` + flagAll + `
+
+  kubectl gs logout mymc --` + flagSelfContained + ` /tmp/mymc.yaml
+`
+)
+
+type Config struct {
+	Logger     micrologger.Logger
+	FileSystem afero.Fs
+
+	ConfigFlags *genericclioptions.RESTClientGetter
+
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.FileSystem == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.FileSystem must not be empty", config)
+	}
+	if config.ConfigFlags == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ConfigFlags must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+		fs:     config.FileSystem,
+
+		commonConfig: &commonconfig.CommonConfig{
+			ConfigFlags: config.ConfigFlags,
+		},
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     name,
+		Short:   shortDescription,
+		Long:    longDescription,
+		Example: examples,
+		Args:    cobra.MaximumNArgs(2),
+		RunE:    r.Run,
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
@@ -0,0 +1,27 @@
+package logout
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagAll           = "all"
+	flagSelfContained = "self-contained"
+	flagSkipRevoke    = "skip-revoke"
+)
+
+type flag struct {
+	All           bool
+	SelfContained string
+	SkipRevoke    bool
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.All, flagAll, false, "Remove all Giant Swarm contexts, i. e. all contexts with a 'gs-' prefix.")
+	cmd.Flags().StringVar(&f.SelfContained, flagSelfContained, "", "Remove the contexts from this self-contained kubectl config file, instead of the current kubeconfig. The file is deleted if no context is left.")
+	cmd.Flags().BoolVar(&f.SkipRevoke, flagSkipRevoke, false, "Do not revoke OIDC refresh tokens at the issuer.")
+}
+
+func (f *flag) Validate() error {
+	return nil
+}
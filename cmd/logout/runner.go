@@ -0,0 +1,357 @@
+package logout
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/oidc"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/tokencache"
+)
+
+const (
+	revokeTimeout = 10 * time.Second
+
+	authProviderClientID     = "client-id"
+	authProviderIssuer       = "idp-issuer-url"
+	authProviderRefreshToken = "refresh-token"
+)
+
+type runner struct {
+	flag   *flag
+	logger micrologger.Logger
+	fs     afero.Fs
+
+	commonConfig *commonconfig.CommonConfig
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	if r.flag.All && len(args) > 0 {
+		return microerror.Maskf(invalidFlagError, "--%s cannot be combined with arguments.", flagAll)
+	}
+
+	var err error
+	var config *clientcmdapi.Config
+	if r.flag.SelfContained != "" {
+		config, err = r.loadSelfContainedConfig()
+	} else {
+		config, err = r.commonConfig.GetConfigAccess().GetStartingConfig()
+	}
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	contextNames, err := r.findContexts(config, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	previousContext := config.CurrentContext
+	for _, contextName := range contextNames {
+		r.removeContext(ctx, config, contextName)
+		fmt.Fprintf(r.stdout, "Removed context '%s'.\n", contextName)
+	}
+
+	if r.flag.SelfContained != "" {
+		err = r.writeSelfContainedConfig(config)
+	} else {
+		err = clientcmd.ModifyConfig(r.commonConfig.GetConfigAccess(), *config, false)
+	}
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if previousContext != "" && config.CurrentContext == "" {
+		fmt.Fprintf(r.stdout, "\nThe current context '%s' has been removed, no context is selected now.\n", previousContext)
+		fmt.Fprintf(r.stdout, "Use 'kubectl config use-context' or 'kubectl gs login' to select another context.\n")
+	}
+
+	fmt.Fprint(r.stdout, color.GreenString("\nLogged out successfully, removed %d context(s).\n", len(contextNames)))
+
+	return nil
+}
+
+// findContexts returns the names of the contexts matching the given
+// arguments, sorted by name.
+func (r *runner) findContexts(config *clientcmdapi.Config, args []string) ([]string, error) {
+	var matches func(contextName string) bool
+	var identifier string
+
+	switch {
+	case r.flag.All:
+		identifier = "Giant Swarm clusters"
+		matches = func(contextName string) bool {
+			isKubeContext, _ := kubeconfig.IsKubeContext(contextName)
+			return isKubeContext
+		}
+
+	case len(args) == 0:
+		identifier = r.commonConfig.GetContextOverride()
+		if identifier == "" {
+			identifier = config.CurrentContext
+		}
+		if identifier == "" {
+			return nil, microerror.Maskf(contextDoesNotExistError, "No context is selected. Please specify the context or cluster to log out from.")
+		}
+		matches = func(contextName string) bool {
+			return contextName == identifier
+		}
+
+	default:
+		identifier = strings.ToLower(strings.Join(args, "-"))
+		if _, exists := config.Contexts[identifier]; exists {
+			matches = func(contextName string) bool {
+				return contextName == identifier
+			}
+		} else if kubeconfig.IsCodeName(identifier) {
+			// All contexts of the management cluster, including its workload clusters.
+			mcContextName := kubeconfig.GenerateKubeContextName(identifier)
+			matches = func(contextName string) bool {
+				return contextName == mcContextName || strings.HasPrefix(contextName, mcContextName+"-")
+			}
+		} else if kubeconfig.IsWCCodeName(identifier) {
+			// All contexts of the workload cluster, regardless of the authentication method.
+			parts := strings.SplitN(identifier, "-", 2)
+			wcContextName := kubeconfig.GenerateWCKubeContextName(kubeconfig.GenerateKubeContextName(parts[0]), parts[1])
+			matches = func(contextName string) bool {
+				return contextName == wcContextName ||
+					contextName == wcContextName+kubeconfig.ClientCertSuffix ||
+					contextName == wcContextName+kubeconfig.AWSIAMSuffix
+			}
+		} else {
+			matches = func(contextName string) bool {
+				return contextName == identifier
+			}
+		}
+	}
+
+	var contextNames []string
+	for contextName := range config.Contexts {
+		if matches(contextName) {
+			contextNames = append(contextNames, contextName)
+		}
+	}
+
+	if len(contextNames) < 1 {
+		return nil, microerror.Maskf(contextDoesNotExistError, "Could not find any context for %s.", identifier)
+	}
+
+	sort.Strings(contextNames)
+
+	return contextNames, nil
+}
+
+// removeContext deletes a context, together with the user and cluster
+// entries it references, unless they are still used by another context.
+func (r *runner) removeContext(ctx context.Context, config *clientcmdapi.Config, contextName string) {
+	kubeContext := config.Contexts[contextName]
+	delete(config.Contexts, contextName)
+
+	if config.CurrentContext == contextName {
+		config.CurrentContext = ""
+	}
+
+	if kubeContext == nil {
+		return
+	}
+
+	if user, exists := config.AuthInfos[kubeContext.AuthInfo]; exists && !isAuthInfoReferenced(config, kubeContext.AuthInfo) {
+		if !r.flag.SkipRevoke {
+			r.revokeTokens(ctx, kubeContext.AuthInfo, user)
+		}
+		r.removeCertFile(user.ClientCertificate)
+		r.removeCertFile(user.ClientKey)
+
+		delete(config.AuthInfos, kubeContext.AuthInfo)
+	}
+
+	if cluster, exists := config.Clusters[kubeContext.Cluster]; exists && !isClusterReferenced(config, kubeContext.Cluster) {
+		r.removeCertFile(cluster.CertificateAuthority)
+
+		err := kubeconfig.RemoveCertificate(kubeContext.Cluster, r.fs)
+		if err != nil {
+			fmt.Fprintln(r.stderr, color.YellowString("Could not remove certificates of cluster '%s': %s", kubeContext.Cluster, err))
+		}
+
+		delete(config.Clusters, kubeContext.Cluster)
+	}
+}
+
+// revokeTokens revokes the OIDC refresh token of a user, either stored in the
+// oidc auth provider configuration or in the credential plugin's token cache.
+// Failures are reported, but do not stop the logout.
+func (r *runner) revokeTokens(ctx context.Context, userName string, user *clientcmdapi.AuthInfo) {
+	var issuer, clientID, refreshToken string
+	var cacheDir string
+	if user.AuthProvider != nil {
+		issuer = user.AuthProvider.Config[authProviderIssuer]
+		clientID = user.AuthProvider.Config[authProviderClientID]
+		refreshToken = user.AuthProvider.Config[authProviderRefreshToken]
+	} else if execIssuer, execClientID, ok := kubeconfig.GetCredentialExecParams(user.Exec); ok {
+		issuer, clientID = execIssuer, execClientID
+
+		var err error
+		cacheDir, err = key.GetCacheDir()
+		if err != nil {
+			fmt.Fprintln(r.stderr, color.YellowString("Could not find the token cache of user '%s': %s", userName, err))
+			return
+		}
+
+		entry, err := tokencache.Load(cacheDir, issuer, clientID)
+		if err == nil {
+			refreshToken = entry.RefreshToken
+		}
+	}
+
+	if issuer == "" || clientID == "" {
+		return
+	}
+
+	if refreshToken != "" {
+		err := revokeRefreshToken(ctx, issuer, clientID, refreshToken)
+		if oidc.IsRevocationNotSupported(err) {
+			fmt.Fprintln(r.stderr, color.YellowString("The issuer %s does not support token revocation. The refresh token of user '%s' stays valid until it expires.", issuer, userName))
+		} else if err != nil {
+			fmt.Fprintln(r.stderr, color.YellowString("Could not revoke the refresh token of user '%s': %s", userName, err))
+		} else {
+			fmt.Fprintf(r.stdout, "Revoked the refresh token of user '%s'.\n", userName)
+		}
+	}
+
+	if cacheDir != "" {
+		err := tokencache.Delete(cacheDir, issuer, clientID)
+		if err != nil {
+			fmt.Fprintln(r.stderr, color.YellowString("Could not remove the cached tokens of user '%s': %s", userName, err))
+		}
+	}
+}
+
+// removeCertFile deletes a certificate or key file,
+// if it has been written by kubectl-gs.
+func (r *runner) removeCertFile(filePath string) {
+	if !kubeconfig.IsKubeCertFile(filePath) {
+		return
+	}
+
+	err := r.fs.Remove(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Fprintln(r.stderr, color.YellowString("Could not remove file %s: %s", filePath, err))
+	}
+}
+
+func (r *runner) loadSelfContainedConfig() (*clientcmdapi.Config, error) {
+	data, err := afero.ReadFile(r.fs, r.flag.SelfContained)
+	if os.IsNotExist(err) {
+		return nil, microerror.Maskf(contextDoesNotExistError, "The file %s does not exist.", r.flag.SelfContained)
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return config, nil
+}
+
+func (r *runner) writeSelfContainedConfig(config *clientcmdapi.Config) error {
+	if len(config.Contexts) < 1 {
+		err := r.fs.Remove(r.flag.SelfContained)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		fmt.Fprintf(r.stdout, "Deleted %s, as it contains no contexts anymore.\n", r.flag.SelfContained)
+
+		return nil
+	}
+
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = afero.WriteFile(r.fs, r.flag.SelfContained, data, 0600)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func revokeRefreshToken(ctx context.Context, issuer, clientID, refreshToken string) error {
+	ctx, cancel := context.WithTimeout(ctx, revokeTimeout)
+	defer cancel()
+
+	oidcConfig := oidc.Config{
+		Issuer:   issuer,
+		ClientID: clientID,
+	}
+
+	auther, err := oidc.New(ctx, oidcConfig)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = auther.RevokeToken(ctx, refreshToken)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func isAuthInfoReferenced(config *clientcmdapi.Config, userName string) bool {
+	for _, c := range config.Contexts {
+		if c.AuthInfo == userName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isClusterReferenced(config *clientcmdapi.Config, clusterName string) bool {
+	for _, c := range config.Contexts {
+		if c.Cluster == clusterName {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,249 @@
+package logout
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/ptr"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	testoidc "github.com/giantswarm/kubectl-gs/v5/test/oidc"
+)
+
+func TestLogout(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		flags         *flag
+		selfContained bool
+		modifyConfig  func(config *clientcmdapi.Config)
+
+		expectContexts       []string
+		expectUsers          []string
+		expectCurrentContext string
+		expectRevoked        []string
+		expectFileDeleted    bool
+		expectError          *microerror.Error
+	}{
+		{
+			name:                 "case 0: log out from a management cluster and its workload clusters",
+			args:                 []string{"codename"},
+			flags:                &flag{},
+			expectContexts:       []string{"gs-other", "other"},
+			expectUsers:          []string{"gs-other-user", "other-user"},
+			expectCurrentContext: "",
+			expectRevoked:        []string{"mc-refresh-token"},
+		},
+		{
+			name:                 "case 1: log out from a workload cluster",
+			args:                 []string{"codename", "wc"},
+			flags:                &flag{},
+			expectContexts:       []string{"gs-codename", "gs-other", "other"},
+			expectUsers:          []string{"gs-codename-user", "gs-other-user", "other-user"},
+			expectCurrentContext: "gs-codename",
+		},
+		{
+			name:                 "case 2: log out from a single context",
+			args:                 []string{"gs-codename-wc-clientcert"},
+			flags:                &flag{},
+			expectContexts:       []string{"gs-codename", "gs-codename-wc", "gs-other", "other"},
+			expectUsers:          []string{"gs-codename-user", "gs-codename-wc-user", "gs-other-user", "other-user"},
+			expectCurrentContext: "gs-codename",
+		},
+		{
+			name:                 "case 3: log out from the current context",
+			flags:                &flag{},
+			expectContexts:       []string{"gs-codename-wc", "gs-codename-wc-clientcert", "gs-other", "other"},
+			expectUsers:          []string{"gs-codename-wc-clientcert-user", "gs-codename-wc-user", "gs-other-user", "other-user"},
+			expectCurrentContext: "",
+			expectRevoked:        []string{"mc-refresh-token"},
+		},
+		{
+			name:                 "case 4: log out from all Giant Swarm clusters",
+			flags:                &flag{All: true},
+			expectContexts:       []string{"other"},
+			expectUsers:          []string{"other-user"},
+			expectCurrentContext: "",
+			expectRevoked:        []string{"mc-refresh-token"},
+		},
+		{
+			name:                 "case 5: skip token revocation",
+			args:                 []string{"codename"},
+			flags:                &flag{SkipRevoke: true},
+			expectContexts:       []string{"gs-other", "other"},
+			expectUsers:          []string{"gs-other-user", "other-user"},
+			expectCurrentContext: "",
+		},
+		{
+			name:        "case 6: unknown cluster",
+			args:        []string{"unknown"},
+			flags:       &flag{},
+			expectError: contextDoesNotExistError,
+		},
+		{
+			name:        "case 7: arguments combined with --all",
+			args:        []string{"codename"},
+			flags:       &flag{All: true},
+			expectError: invalidFlagError,
+		},
+		{
+			name:                 "case 8: self-contained file",
+			args:                 []string{"codename", "wc"},
+			flags:                &flag{},
+			selfContained:        true,
+			expectContexts:       []string{"gs-codename", "gs-other", "other"},
+			expectUsers:          []string{"gs-codename-user", "gs-other-user", "other-user"},
+			expectCurrentContext: "gs-codename",
+		},
+		{
+			name:          "case 9: self-contained file without remaining contexts",
+			flags:         &flag{All: true, SkipRevoke: true},
+			selfContained: true,
+			modifyConfig: func(config *clientcmdapi.Config) {
+				delete(config.Contexts, "other")
+			},
+			expectFileDeleted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := testoidc.NewServer(testoidc.MockOidcServerConfig{ClientID: "client"})
+			err := s.Start(t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Stop()
+
+			configDir := t.TempDir()
+			cf := genericclioptions.NewConfigFlags(true)
+			cf.KubeConfig = ptr.To[string](fmt.Sprintf("%s/config.yaml", configDir))
+
+			// Certificate files are looked up in the home directory,
+			// so the file system must not be the real one.
+			fs := afero.NewMemMapFs()
+
+			startConfig := createTestConfig(s.Issuer())
+			if tc.modifyConfig != nil {
+				tc.modifyConfig(startConfig)
+			}
+			if tc.selfContained {
+				tc.flags.SelfContained = "/self-contained.yaml"
+				var data []byte
+				data, err = clientcmd.Write(*startConfig)
+				if err == nil {
+					err = afero.WriteFile(fs, tc.flags.SelfContained, data, 0600)
+				}
+			} else {
+				err = clientcmd.WriteToFile(*startConfig, *cf.KubeConfig)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := runner{
+				flag:         tc.flags,
+				fs:           fs,
+				commonConfig: commonconfig.New(cf),
+				stdout:       new(bytes.Buffer),
+				stderr:       new(bytes.Buffer),
+			}
+
+			err = r.run(context.Background(), &cobra.Command{}, tc.args)
+			if err != nil {
+				if microerror.Cause(err) != tc.expectError {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				return
+			} else if tc.expectError != nil {
+				t.Fatalf("unexpected success")
+			}
+
+			if tc.expectFileDeleted {
+				if exists, _ := afero.Exists(fs, tc.flags.SelfContained); exists {
+					t.Fatalf("expected %s to be deleted", tc.flags.SelfContained)
+				}
+				return
+			}
+
+			var result *clientcmdapi.Config
+			if tc.selfContained {
+				var data []byte
+				data, err = afero.ReadFile(fs, tc.flags.SelfContained)
+				if err == nil {
+					result, err = clientcmd.Load(data)
+				}
+			} else {
+				result, err = r.commonConfig.GetConfigAccess().GetStartingConfig()
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if contexts := sortedKeys(result.Contexts); !reflect.DeepEqual(contexts, tc.expectContexts) {
+				t.Fatalf("expected contexts %v, got %v", tc.expectContexts, contexts)
+			}
+			if users := sortedKeys(result.AuthInfos); !reflect.DeepEqual(users, tc.expectUsers) {
+				t.Fatalf("expected users %v, got %v", tc.expectUsers, users)
+			}
+			if len(result.Clusters) != len(tc.expectContexts) {
+				t.Fatalf("expected %d clusters, got %d", len(tc.expectContexts), len(result.Clusters))
+			}
+			if result.CurrentContext != tc.expectCurrentContext {
+				t.Fatalf("expected current context %q, got %q", tc.expectCurrentContext, result.CurrentContext)
+			}
+			if revoked := s.RevokedTokens(); !reflect.DeepEqual(revoked, tc.expectRevoked) {
+				t.Fatalf("expected revoked tokens %v, got %v", tc.expectRevoked, revoked)
+			}
+		})
+	}
+}
+
+func createTestConfig(issuer string) *clientcmdapi.Config {
+	config := clientcmdapi.NewConfig()
+
+	for _, name := range []string{"gs-codename", "gs-codename-wc", "gs-codename-wc-clientcert", "gs-other", "other"} {
+		config.Clusters[name] = &clientcmdapi.Cluster{Server: "https://" + name + ".example.com"}
+		config.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name + "-user"}
+		config.AuthInfos[name+"-user"] = &clientcmdapi.AuthInfo{Token: "token"}
+	}
+
+	config.AuthInfos["gs-codename-user"] = &clientcmdapi.AuthInfo{
+		AuthProvider: &clientcmdapi.AuthProviderConfig{
+			Name: "oidc",
+			Config: map[string]string{
+				authProviderClientID:     "client",
+				authProviderIssuer:       issuer,
+				authProviderRefreshToken: "mc-refresh-token",
+				"id-token":               "mc-id-token",
+			},
+		},
+	}
+	config.AuthInfos["gs-codename-wc-clientcert-user"] = &clientcmdapi.AuthInfo{
+		ClientCertificateData: []byte("cert"),
+		ClientKeyData:         []byte("key"),
+	}
+	config.CurrentContext = "gs-codename"
+
+	return config
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -13,13 +13,23 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
+	"github.com/giantswarm/kubectl-gs/v5/cmd/auth"
+	"github.com/giantswarm/kubectl-gs/v5/cmd/contexts"
+	"github.com/giantswarm/kubectl-gs/v5/cmd/credential"
+	"github.com/giantswarm/kubectl-gs/v5/cmd/describe"
 	"github.com/giantswarm/kubectl-gs/v5/cmd/get"
 	"github.com/giantswarm/kubectl-gs/v5/cmd/gitops"
 	"github.com/giantswarm/kubectl-gs/v5/cmd/login"
+	"github.com/giantswarm/kubectl-gs/v5/cmd/logout"
+	"github.com/giantswarm/kubectl-gs/v5/cmd/search"
 	"github.com/giantswarm/kubectl-gs/v5/cmd/selfupdate"
 	"github.com/giantswarm/kubectl-gs/v5/cmd/template"
 	"github.com/giantswarm/kubectl-gs/v5/cmd/update"
 	"github.com/giantswarm/kubectl-gs/v5/cmd/validate"
+	"github.com/giantswarm/kubectl-gs/v5/cmd/whoami"
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/project"
 )
 
@@ -83,6 +93,12 @@ func New(config Config) (*cobra.Command, error) {
 				return
 			}
 
+			// The credential plugin is executed by kubectl for every request,
+			// so we don't want to track it.
+			if cmd.Name() == kubeconfig.CredentialCommand {
+				return
+			}
+
 			tdClient, err := telemetrydeck.NewClient(telemetrydeckAppID)
 			if err != nil {
 				log.Printf("error creating telemetrydeck client: %s", err)
@@ -114,13 +130,24 @@ func New(config Config) (*cobra.Command, error) {
 	}
 	f.Init(c)
 
+	// The installation info is cached, unless the cache directory is
+	// unknown.
+	var installationCache *installation.CacheConfig
+	if cacheDir, err := key.GetCacheDir(); err == nil {
+		f.installationCache.Dir = cacheDir
+		f.installationCache.TTL = installation.DefaultCacheTTL
+		f.installationCache.Warnings = config.Stderr
+		installationCache = &f.installationCache
+	}
+
 	var loginCmd *cobra.Command
 	{
 		c := login.Config{
 			Logger:     config.Logger,
 			FileSystem: config.FileSystem,
 
-			ConfigFlags: &f.config,
+			ConfigFlags:       &f.config,
+			InstallationCache: installationCache,
 
 			Stderr: config.Stderr,
 			Stdout: config.Stdout,
@@ -132,6 +159,54 @@ func New(config Config) (*cobra.Command, error) {
 		}
 	}
 
+	var logoutCmd *cobra.Command
+	{
+		c := logout.Config{
+			Logger:     config.Logger,
+			FileSystem: config.FileSystem,
+
+			ConfigFlags: &f.config,
+
+			Stderr: config.Stderr,
+			Stdout: config.Stdout,
+		}
+
+		logoutCmd, err = logout.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var credentialCmd *cobra.Command
+	{
+		c := credential.Config{
+			Logger: config.Logger,
+
+			Stderr: config.Stderr,
+			Stdout: config.Stdout,
+		}
+
+		credentialCmd, err = credential.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var contextsCmd *cobra.Command
+	{
+		c := contexts.Config{
+			Logger: config.Logger,
+
+			Stderr: config.Stderr,
+			Stdout: config.Stdout,
+		}
+
+		contextsCmd, err = contexts.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var templateCmd *cobra.Command
 	{
 		c := template.Config{
@@ -155,7 +230,8 @@ func New(config Config) (*cobra.Command, error) {
 			Logger:     config.Logger,
 			FileSystem: config.FileSystem,
 
-			ConfigFlags: &f.config,
+			ConfigFlags:       &f.config,
+			InstallationCache: installationCache,
 
 			Stderr: config.Stderr,
 			Stdout: config.Stdout,
@@ -167,6 +243,67 @@ func New(config Config) (*cobra.Command, error) {
 		}
 	}
 
+	var describeCmd *cobra.Command
+	{
+		c := describe.Config{
+			Logger:            config.Logger,
+			ConfigFlags:       &f.config,
+			InstallationCache: installationCache,
+			Stderr:            config.Stderr,
+			Stdout:            config.Stdout,
+		}
+
+		describeCmd, err = describe.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var whoamiCmd *cobra.Command
+	{
+		c := whoami.Config{
+			Logger:      config.Logger,
+			ConfigFlags: &f.config,
+			Stderr:      config.Stderr,
+			Stdout:      config.Stdout,
+		}
+
+		whoamiCmd, err = whoami.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var authCmd *cobra.Command
+	{
+		c := auth.Config{
+			Logger:      config.Logger,
+			ConfigFlags: &f.config,
+			Stderr:      config.Stderr,
+			Stdout:      config.Stdout,
+		}
+
+		authCmd, err = auth.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var searchCmd *cobra.Command
+	{
+		c := search.Config{
+			Logger:      config.Logger,
+			ConfigFlags: &f.config,
+			Stderr:      config.Stderr,
+			Stdout:      config.Stdout,
+		}
+
+		searchCmd, err = search.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var gitopsCmd *cobra.Command
 	{
 		c := gitops.Config{
@@ -229,12 +366,19 @@ func New(config Config) (*cobra.Command, error) {
 			return nil, microerror.Mask(err)
 		}
 	}
+	c.AddCommand(authCmd)
+	c.AddCommand(contextsCmd)
+	c.AddCommand(credentialCmd)
+	c.AddCommand(describeCmd)
 	c.AddCommand(getCmd)
 	c.AddCommand(gitopsCmd)
 	c.AddCommand(loginCmd)
+	c.AddCommand(logoutCmd)
+	c.AddCommand(searchCmd)
 	c.AddCommand(templateCmd)
 	c.AddCommand(updateCmd)
 	c.AddCommand(validateCmd)
+	c.AddCommand(whoamiCmd)
 	c.AddCommand(selfUpdateCmd)
 
 	return c, nil
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/project"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/selfupdate"
 )
@@ -70,6 +71,12 @@ func (r *runner) persistentPostRun(ctx context.Context, cmd *cobra.Command, args
 		return nil
 	}
 
+	// The credential plugin output is consumed by kubectl, and it is
+	// executed for every request, so we skip the check there as well.
+	if cmd.Name() == kubeconfig.CredentialCommand {
+		return nil
+	}
+
 	if r.flag.disableVersionCheck {
 		// User disabled the update check.
 		return nil
@@ -0,0 +1,119 @@
+package apps
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
+)
+
+const (
+	name  = "apps <query>..."
+	alias = "app"
+
+	shortDescription = "Search for apps in all catalogs"
+	longDescription  = `Search for apps in all catalogs
+
+Searches the latest AppCatalogEntry CRs of all catalogs for apps matching
+the query. As with 'kubectl gs get catalogs --all-namespaces', the internal
+catalogs in the giantswarm namespace are left out.
+
+Every word of the query must match the name, description, keywords or
+annotations of an app. The results are ranked by where the words match,
+from best to worst:
+
+- The name of the app is the word.
+- The name of the app starts with or contains the word.
+- One of the keywords of the app is or contains the word.
+- The description of the app contains the word.
+- One of the annotations of the app contains the word.
+
+With --index, the index.yaml of the Helm repository of each catalog is
+searched as well, finding apps without AppCatalogEntry CRs.
+
+Output columns:
+
+- CATALOG: Name of the catalog.
+- APP NAME: Name of the app.
+- VERSION: Latest version of the app.
+- APP VERSION: Upstream version of the app.
+- RESTRICTIONS: Restrictions on where and how often the app can be installed.
+- DESCRIPTION: Helm chart description.
+- SOURCE: Whether the app was found in an AppCatalogEntry CR or the index
+  (only with --index).`
+
+	examples = `  # Search for an app to use as ingress controller
+  kubectl gs search apps ingress
+
+  # Search for apps matching all words
+  kubectl gs search apps security policy
+
+  # Also search the Helm repositories of the catalogs
+  kubectl gs search apps kyverno --index
+
+  # Print the results as JSON
+  kubectl gs search apps ingress --output json`
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	ConfigFlags *genericclioptions.RESTClientGetter
+
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.ConfigFlags == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ConfigFlags must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		commonConfig: &commonconfig.CommonConfig{
+			ConfigFlags: config.ConfigFlags,
+		},
+		flag:   f,
+		logger: config.Logger,
+
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     name,
+		Aliases: []string{alias},
+		Short:   shortDescription,
+		Long:    longDescription,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(1),
+		RunE:    r.Run,
+		PreRunE: middleware.Compose(
+			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
+		),
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
@@ -0,0 +1,42 @@
+package apps
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+)
+
+const (
+	flagIndex       = "index"
+	flagMaxColWidth = "max-col-width"
+)
+
+type flag struct {
+	Index       bool
+	MaxColWidth uint
+
+	print *genericclioptions.PrintFlags
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.Index, flagIndex, false, "Also search the index.yaml of the Helm repository of each catalog, finding apps without AppCatalogEntry CRs.")
+	cmd.Flags().UintVar(&f.MaxColWidth, flagMaxColWidth, 80, "maximum column width for output table")
+
+	f.print = genericclioptions.NewPrintFlags("")
+
+	// Merging current command flags and config flags,
+	// to be able to override kubectl-specific ones.
+	f.print.AddFlags(cmd)
+}
+
+func (f *flag) Validate() error {
+	switch *f.print.OutputFormat {
+	case output.TypeDefault, output.TypeJSON, output.TypeYAML:
+	default:
+		return microerror.Maskf(invalidFlagError, "--output must be %q or %q", output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package apps
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+)
+
+const (
+	naValue = "n/a"
+)
+
+func (r *runner) printOutput(results []result, query []string) error {
+	if results == nil {
+		results = []result{}
+	}
+
+	switch *r.flag.print.OutputFormat {
+	case output.TypeJSON:
+		data, err := json.MarshalIndent(results, "", "    ")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprintln(r.stdout, string(data))
+
+	case output.TypeYAML:
+		data, err := yaml.Marshal(results)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprint(r.stdout, string(data))
+
+	case output.TypeDefault:
+		if len(results) == 0 {
+			r.printNoResultsOutput(query)
+			return nil
+		}
+
+		printer := printers.NewTablePrinter(printers.PrintOptions{})
+		err := printer.PrintObj(getTable(results, r.flag.Index, r.flag.MaxColWidth), r.stdout)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+	default:
+		return microerror.Maskf(invalidFlagError, "output format %q is not supported, use %q or %q", *r.flag.print.OutputFormat, output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
+
+func (r *runner) printNoMatchOutput() {
+	fmt.Fprintf(r.stdout, "No Catalog CRD found.\n")
+	fmt.Fprintf(r.stdout, "Please check you are accessing a management cluster\n\n")
+}
+
+func (r *runner) printNoResultsOutput(query []string) {
+	fmt.Fprintf(r.stdout, "No apps found matching '%s'.\n", strings.Join(query, " "))
+	if !r.flag.Index {
+		fmt.Fprintf(r.stdout, "To also search the Helm repositories of the catalogs, use --%s.\n", flagIndex)
+	}
+}
+
+func getTable(results []result, withSource bool, maxColWidth uint) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Catalog", Type: "string"},
+			{Name: "App Name", Type: "string"},
+			{Name: "Version", Type: "string"},
+			{Name: "App Version", Type: "string"},
+			{Name: "Restrictions", Type: "string"},
+			{Name: "Description", Type: "string"},
+		},
+	}
+	if withSource {
+		table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{Name: "Source", Type: "string"})
+	}
+
+	for _, res := range results {
+		restrictions := naValue
+		if len(res.Restrictions) > 0 {
+			restrictions = strings.Join(res.Restrictions, ",")
+		}
+
+		cells := []interface{}{
+			res.Catalog,
+			res.Name,
+			res.Version,
+			valueOrNA(res.AppVersion),
+			restrictions,
+			truncate(valueOrNA(res.Description), maxColWidth),
+		}
+		if withSource {
+			cells = append(cells, res.Source)
+		}
+
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: cells})
+	}
+
+	return table
+}
+
+func truncate(value string, maxColWidth uint) string {
+	if uint(len(value)) > maxColWidth {
+		return fmt.Sprintf("%s...", value[:maxColWidth])
+	}
+
+	return value
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return naValue
+	}
+
+	return value
+}
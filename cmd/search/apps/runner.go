@@ -0,0 +1,88 @@
+package apps
+
+import (
+	"context"
+	"io"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	catalogdata "github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/catalog"
+)
+
+type runner struct {
+	commonConfig *commonconfig.CommonConfig
+	flag         *flag
+	logger       micrologger.Logger
+
+	service catalogdata.Interface
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return microerror.Maskf(invalidFlagError, "a query must be given")
+	}
+
+	err := r.getService()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	results, err := r.search(ctx, args)
+	if catalogdata.IsNoMatch(err) {
+		r.printNoMatchOutput()
+		return nil
+	} else if catalogdata.IsNoResources(err) {
+		results = nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.printOutput(results, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) getService() error {
+	if r.service != nil {
+		return nil
+	}
+
+	client, err := r.commonConfig.GetClient(r.logger)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.service, err = catalogdata.New(catalogdata.Config{
+		Client: client.CtrlClient(),
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
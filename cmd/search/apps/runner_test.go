@@ -0,0 +1,251 @@
+package apps
+
+import (
+	"bytes"
+	"context"
+	goflag "flag"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
+
+	catalogdata "github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/catalog"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/scheme"
+	"github.com/giantswarm/kubectl-gs/v5/test/goldenfile"
+)
+
+var update = goflag.Bool("update", false, "update .golden reference test files")
+
+const index = `apiVersion: v1
+entries:
+  ingress-nginx:
+  - name: ingress-nginx
+    version: 3.1.0
+    appVersion: 1.9.0
+    description: Ingress controller based on NGINX
+    keywords:
+    - ingress
+  traefik:
+  - name: traefik
+    version: 2.1.0-rc.1
+    appVersion: 3.1.0
+    description: Traefik ingress controller
+  - name: traefik
+    version: 2.0.0
+    appVersion: 3.0.0
+    description: Traefik ingress controller
+    keywords:
+    - ingress
+  - name: traefik
+    version: 1.9.0
+    appVersion: 2.11.0
+    description: Traefik ingress controller
+`
+
+// Test_run uses golden files.
+//
+// go test ./cmd/search/apps -run Test_run -update
+func Test_run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/index.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(index))
+	}))
+	defer server.Close()
+
+	storage := []runtime.Object{
+		newCatalog("giantswarm", "default", "helm", server.URL),
+		newCatalog("community", "org-acme", "oci", "oci://registry.example.com/charts"),
+		newCatalog("control-plane-catalog", "giantswarm", "helm", server.URL),
+		newAppCatalogEntry("giantswarm", "default", "ingress-nginx", "3.1.0", "Ingress controller based on NGINX", []string{"ingress", "nginx"}, nil, &applicationv1alpha1.AppCatalogEntrySpecRestrictions{
+			ClusterSingleton: true,
+			FixedNamespace:   "kube-system",
+		}),
+		newAppCatalogEntry("giantswarm", "default", "kong-app", "5.0.1", "Kong API gateway, also usable as ingress controller", []string{"api-gateway"}, nil, &applicationv1alpha1.AppCatalogEntrySpecRestrictions{
+			CompatibleProviders: []string{"aws", "azure"},
+		}),
+		newAppCatalogEntry("giantswarm", "default", "security-bundle", "1.8.0", "Security tools for workload clusters", []string{"security", "policy"}, map[string]string{"application.giantswarm.io/team": "shield"}, nil),
+		newAppCatalogEntry("community", "org-acme", "kyverno-policies", "0.4.0", "Kyverno policies", []string{"policy"}, nil, nil),
+		newAppCatalogEntry("control-plane-catalog", "giantswarm", "ingress-internal", "1.0.0", "Internal ingress", []string{"ingress"}, nil, nil),
+	}
+
+	testCases := []struct {
+		name               string
+		args               []string
+		index              bool
+		outputType         string
+		expectedGoldenFile string
+		expectedStderr     string
+		errorMatcher       func(error) bool
+	}{
+		{
+			name:               "case 0: search for apps by name, keyword and description",
+			args:               []string{"ingress"},
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_search_apps.golden",
+		},
+		{
+			name:               "case 1: search for apps matching all words",
+			args:               []string{"policy", "security"},
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_search_apps_all_words.golden",
+		},
+		{
+			name:               "case 2: search for apps, including the catalog indexes",
+			args:               []string{"ingress"},
+			index:              true,
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_search_apps_index.golden",
+			expectedStderr:     "The index of catalog 'org-acme/community' cannot be searched",
+		},
+		{
+			name:               "case 3: search for apps by annotation, with json output",
+			args:               []string{"SHIELD"},
+			outputType:         output.TypeJSON,
+			expectedGoldenFile: "run_search_apps_json_output.golden",
+		},
+		{
+			name:               "case 4: search for apps without results",
+			args:               []string{"ingress", "policy"},
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_search_apps_none.golden",
+		},
+		{
+			name:         "case 5: search for apps, with unsupported output",
+			args:         []string{"ingress"},
+			outputType:   output.TypeName,
+			errorMatcher: IsInvalidFlag,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			flag := &flag{
+				Index:       tc.index,
+				MaxColWidth: 40,
+				print:       genericclioptions.NewPrintFlags("").WithDefaultOutput(tc.outputType),
+			}
+
+			out := new(bytes.Buffer)
+			errOut := new(bytes.Buffer)
+			runner := &runner{
+				flag:    flag,
+				service: newCatalogService(t, storage...),
+				stdout:  out,
+				stderr:  errOut,
+			}
+
+			err := runner.run(context.TODO(), nil, tc.args)
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if tc.expectedStderr == "" && errOut.Len() > 0 {
+				t.Fatalf("unexpected stderr output: %q", errOut.String())
+			} else if !strings.Contains(errOut.String(), tc.expectedStderr) {
+				t.Fatalf("expected stderr to contain %q, got: %q", tc.expectedStderr, errOut.String())
+			}
+
+			var expectedResult []byte
+			{
+				gf := goldenfile.New("testdata", tc.expectedGoldenFile)
+				if *update {
+					err = gf.Update(out.Bytes())
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+					expectedResult = out.Bytes()
+				} else {
+					expectedResult, err = gf.Read()
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+				}
+			}
+
+			diff := cmp.Diff(string(expectedResult), out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func newCatalogService(t *testing.T, object ...runtime.Object) catalogdata.Interface {
+	clientScheme, err := scheme.NewScheme()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	client := fake.NewClientBuilder().WithScheme(clientScheme).WithRuntimeObjects(object...).Build()
+
+	service, err := catalogdata.New(catalogdata.Config{Client: client})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	return service
+}
+
+func newCatalog(name, namespace, repositoryType, url string) *applicationv1alpha1.Catalog {
+	return &applicationv1alpha1.Catalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: applicationv1alpha1.CatalogSpec{
+			Title: name,
+			Repositories: []applicationv1alpha1.CatalogSpecRepository{
+				{
+					Type: repositoryType,
+					URL:  url,
+				},
+			},
+		},
+	}
+}
+
+func newAppCatalogEntry(catalog, namespace, appName, version, description string, keywords []string, annotations map[string]string, restrictions *applicationv1alpha1.AppCatalogEntrySpecRestrictions) *applicationv1alpha1.AppCatalogEntry {
+	return &applicationv1alpha1.AppCatalogEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      catalog + "-" + appName + "-" + version,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"application.giantswarm.io/catalog": catalog,
+				"app.kubernetes.io/name":            appName,
+				"latest":                            "true",
+			},
+			Annotations: annotations,
+		},
+		Spec: applicationv1alpha1.AppCatalogEntrySpec{
+			AppName:    appName,
+			AppVersion: "v" + version,
+			Catalog: applicationv1alpha1.AppCatalogEntrySpecCatalog{
+				Name:      catalog,
+				Namespace: namespace,
+			},
+			Chart: applicationv1alpha1.AppCatalogEntrySpecChart{
+				Description: description,
+				Keywords:    keywords,
+			},
+			Restrictions: restrictions,
+			Version:      version,
+		},
+	}
+}
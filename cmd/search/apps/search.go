@@ -0,0 +1,306 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	catalogdata "github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/catalog"
+)
+
+const (
+	sourceCatalogEntry = "AppCatalogEntry"
+	sourceIndex        = "index"
+)
+
+// Scores of a word of the query, by where it matches the app. A word may
+// match several fields, adding up their scores.
+const (
+	scoreName            = 100
+	scoreNamePrefix      = 50
+	scoreNameContains    = 25
+	scoreKeyword         = 20
+	scoreKeywordContains = 10
+	scoreDescription     = 5
+	scoreAnnotation      = 2
+)
+
+// result is an app matching the query.
+type result struct {
+	Catalog          string   `json:"catalog"`
+	CatalogNamespace string   `json:"catalogNamespace"`
+	Name             string   `json:"name"`
+	Version          string   `json:"version"`
+	AppVersion       string   `json:"appVersion,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	Restrictions     []string `json:"restrictions,omitempty"`
+	Source           string   `json:"source"`
+	Score            int      `json:"score"`
+
+	keywords    []string
+	annotations map[string]string
+}
+
+// search returns the apps of all catalogs matching every word of the query,
+// best matches first.
+func (r *runner) search(ctx context.Context, query []string) ([]result, error) {
+	catalogs, err := r.getCatalogs(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	candidates, err := r.getCatalogEntryCandidates(ctx, catalogs)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if r.flag.Index {
+		candidates = append(candidates, r.getIndexCandidates(ctx, catalogs, candidates)...)
+	}
+
+	var words []string
+	for _, q := range query {
+		words = append(words, strings.Fields(strings.ToLower(q))...)
+	}
+
+	var results []result
+	for _, candidate := range candidates {
+		score, ok := getScore(candidate, words)
+		if !ok {
+			continue
+		}
+
+		candidate.Score = score
+		results = append(results, candidate)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+		return results[i].Catalog < results[j].Catalog
+	})
+
+	return results, nil
+}
+
+// getCatalogs returns the catalogs to search, by namespace and name.
+func (r *runner) getCatalogs(ctx context.Context) (map[string]*applicationv1alpha1.Catalog, error) {
+	resource, err := r.service.Get(ctx, catalogdata.GetOptions{
+		AllNamespaces: true,
+		Namespace:     metav1.NamespaceAll,
+	})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	catalogs := map[string]*applicationv1alpha1.Catalog{}
+	if collection, ok := resource.(*catalogdata.Collection); ok {
+		for _, item := range collection.Items {
+			catalogs[catalogKey(item.CR.Namespace, item.CR.Name)] = item.CR
+		}
+	}
+
+	return catalogs, nil
+}
+
+// getCatalogEntryCandidates returns the apps of the catalogs from their
+// latest AppCatalogEntry CRs.
+func (r *runner) getCatalogEntryCandidates(ctx context.Context, catalogs map[string]*applicationv1alpha1.Catalog) ([]result, error) {
+	entries, err := r.service.GetEntries(ctx, "latest=true")
+	if catalogdata.IsNoResources(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var candidates []result
+	for _, ace := range entries.Items {
+		catalogNamespace := ace.Spec.Catalog.Namespace
+		if catalogNamespace == "" {
+			catalogNamespace = ace.Namespace
+		}
+		if _, ok := catalogs[catalogKey(catalogNamespace, ace.Spec.Catalog.Name)]; !ok {
+			continue
+		}
+
+		candidates = append(candidates, result{
+			Catalog:          ace.Spec.Catalog.Name,
+			CatalogNamespace: catalogNamespace,
+			Name:             ace.Spec.AppName,
+			Version:          ace.Spec.Version,
+			AppVersion:       ace.Spec.AppVersion,
+			Description:      ace.Spec.Chart.Description,
+			Restrictions:     getRestrictions(ace.Spec.Restrictions),
+			Source:           sourceCatalogEntry,
+			keywords:         ace.Spec.Chart.Keywords,
+			annotations:      ace.Annotations,
+		})
+	}
+
+	return candidates, nil
+}
+
+// getIndexCandidates returns the apps found in the index.yaml of the
+// catalogs, which are not known from their AppCatalogEntry CRs. Catalogs
+// whose index cannot be fetched are reported and skipped.
+func (r *runner) getIndexCandidates(ctx context.Context, catalogs map[string]*applicationv1alpha1.Catalog, known []result) []result {
+	knownApps := map[string]bool{}
+	for _, k := range known {
+		knownApps[catalogKey(k.CatalogNamespace, k.Catalog)+"/"+k.Name] = true
+	}
+
+	var keys []string
+	for key := range catalogs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var candidates []result
+	for _, key := range keys {
+		catalog := catalogs[key]
+
+		index, err := catalogdata.FetchIndex(ctx, catalog)
+		if err != nil {
+			fmt.Fprintf(r.stderr, "The index of catalog '%s/%s' cannot be searched: %s\n", catalog.Namespace, catalog.Name, err)
+			continue
+		}
+
+		for appName, versions := range index.Entries {
+			if knownApps[key+"/"+appName] {
+				continue
+			}
+
+			latest := getLatestChartVersion(versions)
+			if latest == nil {
+				continue
+			}
+
+			candidates = append(candidates, result{
+				Catalog:          catalog.Name,
+				CatalogNamespace: catalog.Namespace,
+				Name:             appName,
+				Version:          latest.Version,
+				AppVersion:       latest.AppVersion,
+				Description:      latest.Description,
+				Source:           sourceIndex,
+				keywords:         latest.Keywords,
+				annotations:      latest.Annotations,
+			})
+		}
+	}
+
+	return candidates
+}
+
+// getScore returns how well the app matches the words of the query, and
+// whether every word matches.
+func getScore(candidate result, words []string) (int, bool) {
+	name := strings.ToLower(candidate.Name)
+	description := strings.ToLower(candidate.Description)
+
+	var total int
+	for _, word := range words {
+		var score int
+
+		switch {
+		case name == word:
+			score += scoreName
+		case strings.HasPrefix(name, word):
+			score += scoreNamePrefix
+		case strings.Contains(name, word):
+			score += scoreNameContains
+		}
+
+		var keywordScore int
+		for _, keyword := range candidate.keywords {
+			keyword = strings.ToLower(keyword)
+			if keyword == word {
+				keywordScore = scoreKeyword
+				break
+			} else if strings.Contains(keyword, word) {
+				keywordScore = scoreKeywordContains
+			}
+		}
+		score += keywordScore
+
+		if strings.Contains(description, word) {
+			score += scoreDescription
+		}
+
+		for k, v := range candidate.annotations {
+			if strings.Contains(strings.ToLower(k), word) || strings.Contains(strings.ToLower(v), word) {
+				score += scoreAnnotation
+				break
+			}
+		}
+
+		if score == 0 {
+			return 0, false
+		}
+		total += score
+	}
+
+	return total, true
+}
+
+// getLatestChartVersion returns the highest version of the chart, preferring
+// stable versions over pre-releases.
+func getLatestChartVersion(versions catalogdata.ChartVersions) *catalogdata.ChartVersion {
+	var latest *catalogdata.ChartVersion
+	var latestVersion *semver.Version
+	for _, cv := range versions {
+		v, err := semver.NewVersion(cv.Version)
+		if err != nil {
+			continue
+		}
+
+		stable := v.Prerelease() == ""
+		if latestVersion == nil ||
+			stable && latestVersion.Prerelease() != "" ||
+			stable == (latestVersion.Prerelease() == "") && v.GreaterThan(latestVersion) {
+			latest = cv
+			latestVersion = v
+		}
+	}
+
+	return latest
+}
+
+func getRestrictions(restrictions *applicationv1alpha1.AppCatalogEntrySpecRestrictions) []string {
+	if restrictions == nil {
+		return nil
+	}
+
+	var result []string
+	if restrictions.ClusterSingleton {
+		result = append(result, "cluster-singleton")
+	}
+	if restrictions.NamespaceSingleton {
+		result = append(result, "namespace-singleton")
+	}
+	if restrictions.FixedNamespace != "" {
+		result = append(result, fmt.Sprintf("namespace=%s", restrictions.FixedNamespace))
+	}
+	if restrictions.GpuInstances {
+		result = append(result, "gpu-instances")
+	}
+	if len(restrictions.CompatibleProviders) > 0 {
+		result = append(result, fmt.Sprintf("providers=%s", strings.Join(restrictions.CompatibleProviders, "|")))
+	}
+
+	return result
+}
+
+func catalogKey(namespace, name string) string {
+	return namespace + "/" + name
+}
@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/pluralize"
+)
+
+const noValue = "-"
+
+// updateResult is a row of the summary report.
+type updateResult struct {
+	App    *applicationv1alpha1.App
+	From   string
+	To     string
+	Result string
+	Failed bool
+}
+
+// runBulk updates all apps matching the label selector, and prints a
+// summary of the versions before and after the update.
+func (r *runner) runBulk(ctx context.Context, namespace string, dryRun dryrun.Strategy) error {
+	resource, err := r.service.Get(ctx, app.GetOptions{
+		Namespace:     namespace,
+		LabelSelector: r.flag.LabelSelector,
+	})
+	if app.IsNoResources(err) {
+		return microerror.Maskf(noResourcesError, "No apps matching the selector '%s' found.\n", r.flag.LabelSelector)
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var apps []*applicationv1alpha1.App
+	for _, item := range resource.(*app.Collection).Items {
+		apps = append(apps, item.CR)
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		if apps[i].Namespace != apps[j].Namespace {
+			return apps[i].Namespace < apps[j].Namespace
+		}
+		return apps[i].Name < apps[j].Name
+	})
+
+	var results []updateResult
+	var failures int
+	for _, a := range apps {
+		result := r.updateApp(ctx, a, dryRun)
+		if result.Failed {
+			failures++
+		}
+		results = append(results, result)
+	}
+
+	err = r.printSummary(results)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if failures > 0 {
+		return microerror.Maskf(updateFailedError, "%d of %d %s could not be updated.", failures, len(results), pluralize.Pluralize("app", len(results)))
+	}
+
+	return nil
+}
+
+// updateApp updates a single app of a bulk update. Errors are reported in
+// the result instead of being returned, so the other apps are still
+// updated.
+func (r *runner) updateApp(ctx context.Context, a *applicationv1alpha1.App, dryRun dryrun.Strategy) updateResult {
+	result := updateResult{
+		App:  a,
+		From: a.Spec.Version,
+		To:   r.flag.Version,
+	}
+
+	fail := func(err error) updateResult {
+		fmt.Fprintf(r.stderr, "Updating app '%s/%s' failed: %s\n\n", a.Namespace, a.Name, err)
+		result.Result = fmt.Sprintf("failed: %s", err)
+		result.Failed = true
+		return result
+	}
+
+	version := r.flag.Version
+	if constraint, ok := r.getVersionConstraint(); ok {
+		var err error
+		version, err = r.service.LatestVersion(ctx, a, constraint)
+		if app.IsAlreadyLatest(err) {
+			version = a.Spec.Version
+		} else if err != nil {
+			result.To = noValue
+			return fail(err)
+		}
+		result.To = version
+	}
+
+	if version == "" {
+		// Only the reconciliation is suspended or resumed.
+		result.To = a.Spec.Version
+	} else if version == a.Spec.Version && !r.flag.SuspendReconciliation {
+		result.Result = "skipped: already on the version"
+		return result
+	}
+
+	patchResult, err := r.service.Patch(ctx, app.PatchOptions{
+		DryRun:                dryRun,
+		Namespace:             a.Namespace,
+		Name:                  a.Name,
+		SuspendReconciliation: r.flag.SuspendReconciliation,
+		Version:               version,
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	if dryRun.IsDryRun() {
+		err = dryrun.PrintDiff(r.stdout, fmt.Sprintf("app %s/%s", a.Namespace, a.Name), patchResult.Original, patchResult.Patched)
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	result.Result = fmt.Sprintf("updated%s", dryRun.Suffix())
+
+	return result
+}
+
+// printSummary prints the versions of the apps before and after the bulk
+// update.
+func (r *runner) printSummary(results []updateResult) error {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "NAMESPACE", Type: "string"},
+			{Name: "NAME", Type: "string"},
+			{Name: "FROM", Type: "string"},
+			{Name: "TO", Type: "string"},
+			{Name: "RESULT", Type: "string"},
+		},
+	}
+
+	for _, result := range results {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []interface{}{
+				result.App.Namespace,
+				result.App.Name,
+				result.From,
+				result.To,
+				result.Result,
+			},
+		})
+	}
+
+	printer := printers.NewTablePrinter(printers.PrintOptions{})
+	err := printer.PrintObj(table, r.stdout)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
+)
+
+const catalogIndex = `apiVersion: v1
+entries:
+  fake-app:
+  - name: fake-app
+    version: 1.0.0
+  - name: fake-app
+    version: 0.3.0-rc.1
+  - name: fake-app
+    version: 0.2.0
+  - name: fake-app
+    version: 0.1.0
+`
+
+func Test_run_bulk(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		storage      []runtime.Object
+		flags        flag
+		errorMatcher func(error) bool
+		message      string
+		// storedVersions are the versions of the stored apps after running
+		// the command, by namespace/name.
+		storedVersions map[string]string
+	}{
+		{
+			name: "update the selected apps to the latest version",
+			storage: []runtime.Object{
+				withLabel(newApp("fake-app", "0.0.1", "fake-catalog"), "team", "a"),
+				withLabel(newApp("other-app", "0.1.0", "fake-catalog"), "team", "a"),
+				newApp("unselected-app", "0.0.1", "fake-catalog"),
+				newCatalog("fake-catalog"),
+				newAppCatalogEntry("fake-app", "0.0.1", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.1.0", "fake-catalog", "true"),
+				newAppCatalogEntry("other-app", "0.1.0", "fake-catalog", "true"),
+			},
+			flags: flag{LabelSelector: "team=a", ToLatest: true},
+			message: `NAMESPACE   NAME        FROM    TO      RESULT
+default     fake-app    0.0.1   0.1.0   updated
+default     other-app   0.1.0   0.1.0   skipped: already on the version
+`,
+			storedVersions: map[string]string{
+				"default/fake-app":       "0.1.0",
+				"default/other-app":      "0.1.0",
+				"default/unselected-app": "0.0.1",
+			},
+		},
+		{
+			name: "update the apps of all namespaces to the latest patch version",
+			storage: []runtime.Object{
+				withLabel(newApp("fake-app", "0.1.0", "fake-catalog"), "team", "a"),
+				inNamespace(withLabel(newApp("fake-app", "0.1.0", "fake-catalog"), "team", "a"), "org-acme"),
+				newCatalog("fake-catalog"),
+				newAppCatalogEntry("fake-app", "0.1.0", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.1.1", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.2.0", "fake-catalog", "true"),
+			},
+			flags: flag{LabelSelector: "team=a", AllNamespaces: true, ToLatestPatch: true},
+			message: `NAMESPACE   NAME       FROM    TO      RESULT
+default     fake-app   0.1.0   0.1.1   updated
+org-acme    fake-app   0.1.0   0.1.1   updated
+`,
+			storedVersions: map[string]string{
+				"default/fake-app":  "0.1.1",
+				"org-acme/fake-app": "0.1.1",
+			},
+		},
+		{
+			name: "update to the latest minor version from the catalog index",
+			storage: []runtime.Object{
+				withLabel(newApp("fake-app", "0.1.0", "fake-catalog"), "team", "a"),
+				newCatalog("fake-catalog"),
+				newAppCatalogEntry("fake-app", "1.0.0", "fake-catalog", "true"),
+			},
+			flags: flag{LabelSelector: "team=a", ToLatestMinor: true},
+			message: `NAMESPACE   NAME       FROM    TO      RESULT
+default     fake-app   0.1.0   0.2.0   updated
+`,
+			storedVersions: map[string]string{
+				"default/fake-app": "0.2.0",
+			},
+		},
+		{
+			name: "preview the update with client dry run",
+			storage: []runtime.Object{
+				withLabel(newApp("fake-app", "0.0.1", "fake-catalog"), "team", "a"),
+				newCatalog("fake-catalog"),
+				newAppCatalogEntry("fake-app", "0.0.1", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.1.0", "fake-catalog", "true"),
+			},
+			flags: flag{LabelSelector: "team=a", ToLatest: true, DryRun: "client"},
+			message: `--- app default/fake-app (current)
++++ app default/fake-app (updated)
+@@ -1,6 +1,8 @@
+ apiVersion: app.application.giantswarm.io/v1alpha1
+ kind: App
+ metadata:
++  annotations:
++    kubectl-gs.giantswarm.io/version-history: 0.0.1,0.1.0
+   creationTimestamp: null
+   labels:
+     team: a
+@@ -9,6 +11,7 @@
+   resourceVersion: "999"
+ spec:
+   catalog: fake-catalog
++  catalogNamespace: default
+   config:
+     configMap:
+       name: ""
+@@ -37,7 +40,7 @@
+     secret:
+       name: ""
+       namespace: ""
+-  version: 0.0.1
++  version: 0.1.0
+ status:
+   appVersion: ""
+   release:
+NAMESPACE   NAME       FROM    TO      RESULT
+default     fake-app   0.0.1   0.1.0   updated (dry run)
+`,
+			storedVersions: map[string]string{
+				"default/fake-app": "0.0.1",
+			},
+		},
+		{
+			name: "update an app with an invalid version",
+			storage: []runtime.Object{
+				withLabel(newApp("fake-app", "0.0.1", "fake-catalog"), "team", "a"),
+				withLabel(newApp("other-app", "main", "fake-catalog"), "team", "a"),
+				newCatalog("fake-catalog"),
+				newAppCatalogEntry("fake-app", "0.0.1", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.0.2", "fake-catalog", "true"),
+			},
+			flags: flag{LabelSelector: "team=a", ToLatestPatch: true},
+			message: `NAMESPACE   NAME        FROM    TO      RESULT
+default     fake-app    0.0.1   0.0.2   updated
+default     other-app   main    -       failed: invalid version error: version ` + "`main`" + ` of app ` + "`other-app`" + ` is not a valid semantic version
+`,
+			errorMatcher: IsUpdateFailed,
+			storedVersions: map[string]string{
+				"default/fake-app":  "0.0.2",
+				"default/other-app": "main",
+			},
+		},
+		{
+			name: "never update to a lower version than the current one",
+			storage: []runtime.Object{
+				withLabel(newApp("fake-app", "1.1.0", "fake-catalog"), "team", "a"),
+				newCatalog("fake-catalog"),
+				newAppCatalogEntry("fake-app", "1.0.0", "fake-catalog", "true"),
+			},
+			flags: flag{LabelSelector: "team=a", ToLatest: true},
+			message: `NAMESPACE   NAME       FROM    TO      RESULT
+default     fake-app   1.1.0   1.1.0   skipped: already on the version
+`,
+			storedVersions: map[string]string{
+				"default/fake-app": "1.1.0",
+			},
+		},
+		{
+			name: "no app matches the selector",
+			storage: []runtime.Object{
+				newApp("fake-app", "0.0.1", "fake-catalog"),
+			},
+			flags:        flag{LabelSelector: "team=a", ToLatest: true},
+			errorMatcher: IsNoResources,
+		},
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/index.yaml" {
+			_, _ = rw.Write([]byte(catalogIndex))
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	}))
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		t.Fatalf("httptest: failed to listen on a port: %v", err)
+	}
+	server.Listener.Close()
+	server.Listener = l
+	server.Start()
+	defer server.Close()
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			ctx := context.TODO()
+
+			flag := &tc.flags
+			flag.print = genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault)
+
+			out := new(bytes.Buffer)
+			service := newAppService(t, tc.storage...)
+			runner := &runner{
+				commonConfig: commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(kubeconfig.CreateFakeKubeConfig())),
+				service:      service,
+				flag:         flag,
+				stdout:       out,
+				stderr:       new(bytes.Buffer),
+			}
+
+			err := runner.run(ctx, nil, []string{})
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			diff := cmp.Diff(tc.message, out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+
+			for key, expected := range tc.storedVersions {
+				namespace, name, _ := strings.Cut(key, "/")
+
+				resource, err := service.Get(ctx, app.GetOptions{Namespace: namespace, Name: name})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+
+				storedVersion := resource.(*app.App).CR.Spec.Version
+				if storedVersion != expected {
+					t.Fatalf("expected stored version %q of app %s, got %q", expected, key, storedVersion)
+				}
+			}
+		})
+	}
+}
+
+func withLabel(a *applicationv1alpha1.App, key, value string) *applicationv1alpha1.App {
+	if a.Labels == nil {
+		a.Labels = map[string]string{}
+	}
+	a.Labels[key] = value
+
+	return a
+}
+
+func inNamespace(a *applicationv1alpha1.App, namespace string) *applicationv1alpha1.App {
+	a.Namespace = namespace
+
+	return a
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
 )
 
@@ -23,16 +24,68 @@ const (
 
 Updates given app with the provided values.
 
+Instead of a single app given by --name, all apps matching a label selector
+can be updated at once. A summary of the versions before and after the update
+is printed at the end.
+
+The --to-latest flags look up the version in the AppCatalogEntry CRs of the
+app's catalog, falling back to the catalog's index.yaml for older versions.
+
+The user config of an app can be changed with --values-file, --set and
+--unset. The user config ConfigMap (or Secret, with --secret) referenced by
+the app is created if needed. The values it would then have, merged with the
+chart values and the catalog and cluster config, are validated against the
+values schema of the app before anything is written. The values of an
+existing user config are written back as plain YAML: comments are dropped and
+keys are sorted.
+
+With --rollback, the app is set back to the version it had before the last
+update, as listed by 'kubectl gs get app <name> --history'. Failed
+deployments are skipped. Use --to to roll back to another version.
+
 Options:
   --name <name>              App CR name to update.
+  -l, --selector <selector>  Label selector of the App CRs to update.
   --namespace <cluster>      Cluster to update the app on.
-  --version <version>        New version to update the app to.`
+  -A, --all-namespaces       Update the App CRs matching --selector in all namespaces.
+  --version <version>        New version to update the app to.
+  --to-latest                Update to the latest version available.
+  --to-latest-minor          Update to the latest version of the current major version.
+  --to-latest-patch          Update to the latest version of the current minor version.
+  --rollback                 Roll back to the previous version.
+  --to <version>             Version to roll back to, with --rollback.
+  --values-file <path>       YAML file with values to merge into the user config.
+  --set <key>=<value>        Set a user config value. Use dots for nested keys.
+  --unset <key>              Remove a user config value. Use dots for nested keys.
+  --secret                   Change the user config Secret instead of the ConfigMap.
+  --dry-run[=client|server]  Only show the changes as a diff, without applying them.`
 
 	examples = `  # Display this help
 kubectl gs update app --help
 
 # Update app version
-kubectl gs update app --name hello-world-app --namespace ab01c --version 0.2.0`
+kubectl gs update app --name hello-world-app --namespace ab01c --version 0.2.0
+
+# Preview an app update, validated by the API server
+kubectl gs update app --name hello-world-app --namespace ab01c --version 0.2.0 --dry-run=server
+
+# Update an app to the latest version available in its catalog
+kubectl gs update app --name hello-world-app --namespace ab01c --to-latest
+
+# Update all apps of a team in all namespaces to their latest patch version
+kubectl gs update app --selector application.giantswarm.io/team=honeybadger --all-namespaces --to-latest-patch
+
+# Preview the bulk update
+kubectl gs update app --selector application.giantswarm.io/team=honeybadger --all-namespaces --to-latest-patch --dry-run
+
+# Change the user config of an app
+kubectl gs update app --name hello-world-app --namespace ab01c --set ingress.enabled=true --unset replicaCount
+
+# Merge values into the user config Secret of an app
+kubectl gs update app --name hello-world-app --namespace ab01c --values-file secret-values.yaml --secret
+
+# Roll an app back to the version it had before the last update
+kubectl gs update app --name hello-world-app --namespace ab01c --rollback`
 )
 
 type Config struct {
@@ -81,6 +134,7 @@ func New(config Config) (*cobra.Command, error) {
 		RunE:    r.Run,
 		PreRunE: middleware.Compose(
 			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
 		),
 	}
 
@@ -39,3 +39,30 @@ var noResourcesError = &microerror.Error{
 func IsNoResources(err error) bool {
 	return microerror.Cause(err) == noResourcesError
 }
+
+var updateFailedError = &microerror.Error{
+	Kind: "updateFailedError",
+}
+
+// IsUpdateFailed asserts updateFailedError.
+func IsUpdateFailed(err error) bool {
+	return microerror.Cause(err) == updateFailedError
+}
+
+var invalidValuesError = &microerror.Error{
+	Kind: "invalidValuesError",
+}
+
+// IsInvalidValues asserts invalidValuesError.
+func IsInvalidValues(err error) bool {
+	return microerror.Cause(err) == invalidValuesError
+}
+
+var noHistoryError = &microerror.Error{
+	Kind: "noHistoryError",
+}
+
+// IsNoHistory asserts noHistoryError.
+func IsNoHistory(err error) bool {
+	return microerror.Cause(err) == noHistoryError
+}
@@ -1,21 +1,51 @@
 package app
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
 )
 
 const (
-	flagVersion = "version"
-	flagName    = "name"
-	flagSuspend = "suspend-reconciliation"
+	flagAllNamespaces = "all-namespaces"
+	flagDryRun        = "dry-run"
+	flagLabelSelector = "selector"
+	flagVersion       = "version"
+	flagName          = "name"
+	flagRollback      = "rollback"
+	flagSecret        = "secret"
+	flagSet           = "set"
+	flagSuspend       = "suspend-reconciliation"
+	flagTo            = "to"
+	flagToLatest      = "to-latest"
+	flagToLatestMinor = "to-latest-minor"
+	flagToLatestPatch = "to-latest-patch"
+	flagUnset         = "unset"
+	flagValuesFile    = "values-file"
 )
 
 type flag struct {
 	print                 *genericclioptions.PrintFlags
+	AllNamespaces         bool
+	DryRun                string
+	LabelSelector         string
 	Name                  string
+	Rollback              bool
+	Secret                bool
+	Set                   []string
 	SuspendReconciliation bool
+	To                    string
+	ToLatest              bool
+	ToLatestMinor         bool
+	ToLatestPatch         bool
+	Unset                 []string
+	ValuesFile            string
 	Version               string
 }
 
@@ -30,6 +60,23 @@ func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&f.Name, flagName, "", "Name of the app to update")
 	_ = cmd.Flags().MarkHidden(flagName)
 
+	cmd.Flags().StringVarP(&f.LabelSelector, flagLabelSelector, "l", "", "Label selector of the apps to update, instead of a single app given by --name")
+	cmd.Flags().BoolVarP(&f.AllNamespaces, flagAllNamespaces, "A", false, "Update the apps matching --selector across all namespaces")
+	cmd.Flags().BoolVar(&f.ToLatest, flagToLatest, false, "Update the apps to the latest version available in their catalog")
+	cmd.Flags().BoolVar(&f.ToLatestMinor, flagToLatestMinor, false, "Update the apps to the latest version within their current major version")
+	cmd.Flags().BoolVar(&f.ToLatestPatch, flagToLatestPatch, false, "Update the apps to the latest version within their current minor version")
+
+	cmd.Flags().BoolVar(&f.Rollback, flagRollback, false, "Roll the app back to the version it had before the last update")
+	cmd.Flags().StringVar(&f.To, flagTo, "", fmt.Sprintf("Version to roll back to with --%s, instead of the previous one", flagRollback))
+
+	cmd.Flags().StringVar(&f.ValuesFile, flagValuesFile, "", "Path to a YAML file with values to merge into the user config of the app. The user config is rewritten without its comments and with sorted keys")
+	cmd.Flags().StringArrayVar(&f.Set, flagSet, nil, "Set a value in the user config of the app, in the form key=value, with dots separating nested keys")
+	cmd.Flags().StringArrayVar(&f.Unset, flagUnset, nil, "Remove a key from the user config of the app, with dots separating nested keys")
+	cmd.Flags().BoolVar(&f.Secret, flagSecret, false, fmt.Sprintf("Apply --%s, --%s and --%s to the user config Secret instead of the ConfigMap", flagValuesFile, flagSet, flagUnset))
+
+	cmd.Flags().StringVar(&f.DryRun, flagDryRun, string(dryrun.None), fmt.Sprintf("Only show the changes as a diff, without applying them. Must be one of %s. With %q, the changes are computed locally. With %q, they are sent to the API server without being persisted.", strings.Join(dryrun.Strategies, ", "), dryrun.Client, dryrun.Server))
+	cmd.Flags().Lookup(flagDryRun).NoOptDefVal = string(dryrun.Client)
+
 	f.print = genericclioptions.NewPrintFlags("")
 
 	// Merging current command flags and config flags,
@@ -38,9 +85,67 @@ func (f *flag) Init(cmd *cobra.Command) {
 }
 
 func (f *flag) Validate() error {
-	if f.Name == "" {
-		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagName)
+	if f.Name == "" && f.LabelSelector == "" {
+		return microerror.Maskf(invalidFlagError, "one of --%s or --%s must be set", flagName, flagLabelSelector)
+	}
+	if f.Name != "" && f.LabelSelector != "" {
+		return microerror.Maskf(invalidFlagError, "--%s and --%s cannot be combined", flagName, flagLabelSelector)
+	}
+	if f.AllNamespaces && f.LabelSelector == "" {
+		return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagAllNamespaces, flagLabelSelector)
+	}
+
+	if f.Rollback && f.Name == "" {
+		return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagRollback, flagName)
+	}
+	if f.To != "" && !f.Rollback {
+		return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagTo, flagRollback)
+	}
+
+	var versionFlags []string
+	for name, set := range map[string]bool{
+		flagRollback:      f.Rollback,
+		flagVersion:       f.Version != "",
+		flagToLatest:      f.ToLatest,
+		flagToLatestMinor: f.ToLatestMinor,
+		flagToLatestPatch: f.ToLatestPatch,
+	} {
+		if set {
+			versionFlags = append(versionFlags, "--"+name)
+		}
+	}
+	if len(versionFlags) > 1 {
+		sort.Strings(versionFlags)
+		return microerror.Maskf(invalidFlagError, "only one of %s can be set", strings.Join(versionFlags, ", "))
+	}
+
+	if f.hasValues() && f.Name == "" {
+		return microerror.Maskf(invalidFlagError, "--%s, --%s and --%s require --%s", flagValuesFile, flagSet, flagUnset, flagName)
+	}
+	if f.Secret && !f.hasValues() {
+		return microerror.Maskf(invalidFlagError, "--%s requires one of --%s, --%s or --%s", flagSecret, flagValuesFile, flagSet, flagUnset)
+	}
+	for _, value := range f.Set {
+		k, _, ok := strings.Cut(value, "=")
+		if !ok || k == "" {
+			return microerror.Maskf(invalidFlagError, "--%s must be in the form key=value, got %q", flagSet, value)
+		}
+	}
+	for _, k := range f.Unset {
+		if k == "" {
+			return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagUnset)
+		}
+	}
+
+	_, err := dryrun.Parse(f.DryRun)
+	if err != nil {
+		return microerror.Maskf(invalidFlagError, "--%s must be one of %s", flagDryRun, strings.Join(dryrun.Strategies, ", "))
 	}
 
 	return nil
 }
+
+// hasValues returns true if the user config of the app is changed.
+func (f *flag) hasValues() bool {
+	return f.ValuesFile != "" || len(f.Set) > 0 || len(f.Unset) > 0
+}
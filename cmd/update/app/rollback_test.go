@@ -0,0 +1,126 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/annotation"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
+)
+
+func Test_run_rollback(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		storage      []runtime.Object
+		flags        flag
+		errorMatcher func(error) bool
+		// expectedVersion and expectedHistory are the version and version
+		// history annotation of the app after running the command.
+		expectedVersion string
+		expectedHistory string
+	}{
+		{
+			name: "roll back to the previous version",
+			storage: []runtime.Object{
+				withVersionHistory(newApp("fake-app", "0.2.0", "fake-catalog"), "0.1.0,0.2.0"),
+				newAppCatalogEntry("fake-app", "0.1.0", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.2.0", "fake-catalog", "true"),
+			},
+			flags:           flag{Name: "fake-app", Rollback: true},
+			expectedVersion: "0.1.0",
+			expectedHistory: "0.1.0,0.2.0,0.1.0",
+		},
+		{
+			name: "roll back to a given version",
+			storage: []runtime.Object{
+				withVersionHistory(newApp("fake-app", "0.2.0", "fake-catalog"), "0.1.0,0.2.0"),
+				newAppCatalogEntry("fake-app", "0.0.1", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.2.0", "fake-catalog", "true"),
+			},
+			flags:           flag{Name: "fake-app", Rollback: true, To: "0.0.1"},
+			expectedVersion: "0.0.1",
+			expectedHistory: "0.1.0,0.2.0,0.0.1",
+		},
+		{
+			name: "roll back to the current version",
+			storage: []runtime.Object{
+				withVersionHistory(newApp("fake-app", "0.2.0", "fake-catalog"), "0.1.0,0.2.0"),
+			},
+			flags:           flag{Name: "fake-app", Rollback: true, To: "0.2.0"},
+			errorMatcher:    IsInvalidFlag,
+			expectedVersion: "0.2.0",
+			expectedHistory: "0.1.0,0.2.0",
+		},
+		{
+			name: "roll back an app never updated",
+			storage: []runtime.Object{
+				newApp("fake-app", "0.2.0", "fake-catalog"),
+			},
+			flags:           flag{Name: "fake-app", Rollback: true},
+			errorMatcher:    IsNoHistory,
+			expectedVersion: "0.2.0",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			ctx := context.TODO()
+
+			flag := &tc.flags
+			flag.print = genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault)
+
+			service := newAppService(t, tc.storage...)
+			runner := &runner{
+				commonConfig: commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(kubeconfig.CreateFakeKubeConfig())),
+				service:      service,
+				flag:         flag,
+				stdout:       new(bytes.Buffer),
+				stderr:       new(bytes.Buffer),
+			}
+
+			err := runner.run(ctx, nil, []string{})
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			resource, err := service.Get(ctx, app.GetOptions{Namespace: "default", Name: "fake-app"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			appCR := resource.(*app.App).CR
+
+			diff := cmp.Diff(tc.expectedVersion, appCR.Spec.Version)
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+
+			diff = cmp.Diff(tc.expectedHistory, appCR.Annotations[annotation.AppVersionHistory])
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func withVersionHistory(a *applicationv1alpha1.App, history string) *applicationv1alpha1.App {
+	if a.Annotations == nil {
+		a.Annotations = map[string]string{}
+	}
+	a.Annotations[annotation.AppVersionHistory] = history
+
+	return a
+}
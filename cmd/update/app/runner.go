@@ -6,12 +6,17 @@ import (
 	"io"
 	"strings"
 
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	appservice "github.com/giantswarm/kubectl-gs/v5/pkg/app"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
 )
 
 type runner struct {
@@ -19,7 +24,9 @@ type runner struct {
 	flag         *flag
 	logger       micrologger.Logger
 
-	service app.Interface
+	client            client.Client
+	service           app.Interface
+	validationService appservice.Interface
 
 	stdout io.Writer
 	stderr io.Writer
@@ -52,29 +59,193 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		}
 	}
 
-	namespace, _, err := r.commonConfig.GetNamespace()
+	var namespace string
+	{
+		if r.flag.AllNamespaces {
+			namespace = metav1.NamespaceAll
+		} else {
+			namespace, _, err = r.commonConfig.GetNamespace()
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	dryRun, err := dryrun.Parse(r.flag.DryRun)
 	if err != nil {
 		return microerror.Mask(err)
 	}
 
+	if r.flag.LabelSelector != "" {
+		return r.runBulk(ctx, namespace, dryRun)
+	}
+
+	version := r.flag.Version
+	var change *userConfigChange
+	constraint, toLatest := r.getVersionConstraint()
+	if toLatest || r.flag.Rollback || r.flag.hasValues() {
+		resource, err := r.service.Get(ctx, app.GetOptions{Namespace: namespace, Name: r.flag.Name})
+		if app.IsNotFound(err) {
+			return microerror.Maskf(notFoundError, "An app with name '%s' cannot be found in the '%s' namespace.\n", r.flag.Name, namespace)
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+		appCR := resource.(*app.App).CR
+
+		if toLatest {
+			version, err = r.service.LatestVersion(ctx, appCR, constraint)
+			if app.IsAlreadyLatest(err) {
+				fmt.Fprintf(r.stdout, "App %q in namespace %q is already on the %s.\n", appCR.Name, appCR.Namespace, describeConstraint(constraint, appCR.Spec.Version))
+				if !r.flag.hasValues() && !r.flag.SuspendReconciliation {
+					return nil
+				}
+				// Only the user config or the reconciliation is updated.
+				version = ""
+			} else if app.IsNoResources(err) {
+				return microerror.Maskf(noResourcesError, "No %s version of the app '%s' found in the catalog.\n", constraint, appCR.Spec.Name)
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+		if r.flag.Rollback {
+			version, err = r.getRollbackVersion(ctx, appCR)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+		// The user config is validated before anything is written.
+		if r.flag.hasValues() {
+			change, err = r.prepareUserConfig(ctx, appCR, version)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
 	patchOptions := app.PatchOptions{
+		DryRun:                dryRun,
 		Namespace:             namespace,
 		Name:                  r.flag.Name,
 		SuspendReconciliation: r.flag.SuspendReconciliation,
-		Version:               r.flag.Version,
+		Version:               version,
+	}
+
+	// Without a new user config, version or reconciliation change, only
+	// the existing user config is updated and the app is left as it is.
+	patchApp := change == nil || change.current == nil || version != "" || r.flag.SuspendReconciliation
+
+	if change != nil {
+		if patchApp && !dryRun.IsDryRun() {
+			// The app patch is validated first, so a failing patch does not
+			// leave the user config applied. The reference to a new user
+			// config is left out, as it does not exist yet.
+			validateOptions := patchOptions
+			validateOptions.DryRun = dryrun.Server
+			_, err = r.service.Patch(ctx, validateOptions)
+			if err != nil {
+				return r.maskPatchError(err, patchOptions)
+			}
+		}
+
+		err = r.applyUserConfig(ctx, change, dryRun)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if change.current == nil {
+			// Reference the created user config from the app.
+			userConfigName, userConfigNamespace := change.updated.GetName(), change.updated.GetNamespace()
+			if r.flag.Secret {
+				patchOptions.UserConfigSecret = &applicationv1alpha1.AppSpecUserConfigSecret{Name: userConfigName, Namespace: userConfigNamespace}
+			} else {
+				patchOptions.UserConfigConfigMap = &applicationv1alpha1.AppSpecUserConfigConfigMap{Name: userConfigName, Namespace: userConfigNamespace}
+			}
+		}
+
+		if !patchApp {
+			fmt.Fprintf(r.stdout, "App %q in namespace %q updated with %s%s\n", patchOptions.Name, patchOptions.Namespace, change.state(), dryRun.Suffix())
+			return nil
+		}
+	}
+
+	result, err := r.service.Patch(ctx, patchOptions)
+	if err != nil {
+		return r.maskPatchError(err, patchOptions)
+	}
+
+	if dryRun.IsDryRun() {
+		err = dryrun.PrintDiff(r.stdout, fmt.Sprintf("app %s/%s", patchOptions.Namespace, patchOptions.Name), result.Original, result.Patched)
+		if err != nil {
+			return microerror.Mask(err)
+		}
 	}
 
-	state, err := r.service.Patch(ctx, patchOptions)
+	fmt.Fprintf(r.stdout, "App %q in namespace %q updated with %s%s\n", patchOptions.Name, patchOptions.Namespace, strings.Join(result.State, " "), dryRun.Suffix())
+	return nil
+}
+
+// maskPatchError returns the error of patching the app, with a message for
+// the user where the cause is known.
+func (r *runner) maskPatchError(err error, patchOptions app.PatchOptions) error {
 	if app.IsNotFound(err) {
 		return microerror.Maskf(notFoundError, "An app with name '%s' cannot be found in the '%s' namespace.\n", patchOptions.Name, patchOptions.Namespace)
 	} else if app.IsNoResources(err) {
 		return microerror.Maskf(noResourcesError, "No app with the name '%s' and the version '%s' found in the catalog.\n", patchOptions.Name, patchOptions.Version)
-	} else if err != nil {
-		return microerror.Mask(err)
 	}
 
-	fmt.Fprintf(r.stdout, "App %q in namespace %q updated with %s\n", patchOptions.Name, patchOptions.Namespace, strings.Join(state, " "))
-	return nil
+	return microerror.Mask(err)
+}
+
+// getVersionConstraint returns the constraint to resolve the version to
+// update to with, if any of the --to-latest flags is set.
+func (r *runner) getVersionConstraint() (app.VersionConstraint, bool) {
+	switch {
+	case r.flag.ToLatest:
+		return app.LatestAny, true
+	case r.flag.ToLatestMinor:
+		return app.LatestMinor, true
+	case r.flag.ToLatestPatch:
+		return app.LatestPatch, true
+	}
+
+	return "", false
+}
+
+// describeConstraint describes the latest version within the constraint.
+func describeConstraint(constraint app.VersionConstraint, version string) string {
+	if constraint == app.LatestAny {
+		return fmt.Sprintf("latest version %q", version)
+	}
+
+	return fmt.Sprintf("latest %s version %q", constraint, version)
+}
+
+// getRollbackVersion returns the version to roll the app back to, which is
+// either given with --to or the last version before the current one that
+// was not a failed deployment.
+func (r *runner) getRollbackVersion(ctx context.Context, appCR *applicationv1alpha1.App) (string, error) {
+	if r.flag.To != "" {
+		if r.flag.To == appCR.Spec.Version {
+			return "", microerror.Maskf(invalidFlagError, "--%s: app '%s' is already on version %s", flagTo, appCR.Name, r.flag.To)
+		}
+		return r.flag.To, nil
+	}
+
+	history, err := r.service.GetHistory(ctx, app.GetOptions{Namespace: appCR.Namespace, Name: appCR.Name})
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	for i := len(history.Entries) - 1; i >= 0; i-- {
+		entry := history.Entries[i]
+		if entry.Version != appCR.Spec.Version && entry.Status != app.HelmStatusFailed {
+			return entry.Version, nil
+		}
+	}
+
+	return "", microerror.Maskf(noHistoryError, "No previous version of the app '%s' found to roll back to. Use --%s to choose the version.\n", appCR.Name, flagTo)
 }
 
 func (r *runner) getService() error {
@@ -97,3 +268,39 @@ func (r *runner) getService() error {
 
 	return nil
 }
+
+func (r *runner) getClient() error {
+	if r.client != nil {
+		return nil
+	}
+
+	client, err := r.commonConfig.GetClient(r.logger)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	r.client = client.CtrlClient()
+
+	return nil
+}
+
+func (r *runner) getValidationService() error {
+	if r.validationService != nil {
+		return nil
+	}
+
+	client, err := r.commonConfig.GetClient(r.logger)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	serviceConfig := appservice.Config{
+		Client: client,
+		Logger: r.logger,
+	}
+	r.validationService, err = appservice.New(serviceConfig)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -39,6 +39,9 @@ func Test_run(t *testing.T) {
 		errorMatcher      func(error) bool
 		chartResponseCode int
 		message           string
+		// storedVersion is the version of the stored app after running
+		// the command, checked when set.
+		storedVersion string
 	}{
 		{
 			name: "patch app with the latest AppCatalogEntry CR",
@@ -51,6 +54,31 @@ func Test_run(t *testing.T) {
 			flags:   flag{Name: "fake-app", Version: "0.1.0"},
 			message: "App \"fake-app\" in namespace \"default\" updated with version=0.1.0\n",
 		},
+		{
+			name: "patch app to the latest patch version",
+			storage: []runtime.Object{
+				newApp("fake-app", "0.1.0", "fake-catalog"),
+				newCatalog("fake-catalog"),
+				newAppCatalogEntry("fake-app", "0.1.0", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.1.2", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.2.0", "fake-catalog", "true"),
+			},
+			flags:         flag{Name: "fake-app", ToLatestPatch: true},
+			storedVersion: "0.1.2",
+			message:       "App \"fake-app\" in namespace \"default\" updated with version=0.1.2\n",
+		},
+		{
+			name: "do not patch app already on the latest version",
+			storage: []runtime.Object{
+				newApp("fake-app", "0.3.0", "fake-catalog"),
+				newCatalog("fake-catalog"),
+				newAppCatalogEntry("fake-app", "0.1.0", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.2.0", "fake-catalog", "true"),
+			},
+			flags:         flag{Name: "fake-app", ToLatest: true},
+			storedVersion: "0.3.0",
+			message:       "App \"fake-app\" in namespace \"default\" is already on the latest version \"0.3.0\".\n",
+		},
 		{
 			name: "patch app with the AppCatalogEntry CR (not latest)",
 			storage: []runtime.Object{
@@ -89,6 +117,86 @@ func Test_run(t *testing.T) {
 			errorMatcher:      IsNoResources,
 			chartResponseCode: 404,
 		},
+		{
+			name: "preview app patch with client dry run",
+			storage: []runtime.Object{
+				newApp("fake-app", "0.0.1", "fake-catalog"),
+				newCatalog("fake-catalog"),
+				newAppCatalogEntry("fake-app", "0.0.1", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.1.0", "fake-catalog", "true"),
+			},
+			flags:         flag{Name: "fake-app", Version: "0.1.0", DryRun: "client"},
+			storedVersion: "0.0.1",
+			message: `--- app default/fake-app (current)
++++ app default/fake-app (updated)
+@@ -1,12 +1,15 @@
+ apiVersion: app.application.giantswarm.io/v1alpha1
+ kind: App
+ metadata:
++  annotations:
++    kubectl-gs.giantswarm.io/version-history: 0.0.1,0.1.0
+   creationTimestamp: null
+   name: fake-app
+   namespace: default
+   resourceVersion: "999"
+ spec:
+   catalog: fake-catalog
++  catalogNamespace: default
+   config:
+     configMap:
+       name: ""
+@@ -35,7 +38,7 @@
+     secret:
+       name: ""
+       namespace: ""
+-  version: 0.0.1
++  version: 0.1.0
+ status:
+   appVersion: ""
+   release:
+App "fake-app" in namespace "default" updated with version=0.1.0 (dry run)
+`,
+		},
+		{
+			name: "preview app patch with server dry run",
+			storage: []runtime.Object{
+				newApp("fake-app", "0.0.1", "fake-catalog"),
+				newCatalog("fake-catalog"),
+				newAppCatalogEntry("fake-app", "0.0.1", "fake-catalog", "false"),
+				newAppCatalogEntry("fake-app", "0.1.0", "fake-catalog", "true"),
+			},
+			flags:         flag{Name: "fake-app", Version: "0.1.0", DryRun: "server"},
+			storedVersion: "0.0.1",
+			message: `--- app default/fake-app (current)
++++ app default/fake-app (updated)
+@@ -1,12 +1,15 @@
+ apiVersion: app.application.giantswarm.io/v1alpha1
+ kind: App
+ metadata:
++  annotations:
++    kubectl-gs.giantswarm.io/version-history: 0.0.1,0.1.0
+   creationTimestamp: null
+   name: fake-app
+   namespace: default
+   resourceVersion: "999"
+ spec:
+   catalog: fake-catalog
++  catalogNamespace: default
+   config:
+     configMap:
+       name: ""
+@@ -35,7 +38,7 @@
+     secret:
+       name: ""
+       namespace: ""
+-  version: 0.0.1
++  version: 0.1.0
+ status:
+   appVersion: ""
+   release:
+App "fake-app" in namespace "default" updated with version=0.1.0 (server dry run)
+`,
+		},
 		{
 			name:         "patch nonexisting app",
 			storage:      []runtime.Object{},
@@ -124,9 +232,10 @@ func Test_run(t *testing.T) {
 			flag.print = genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault)
 
 			out := new(bytes.Buffer)
+			service := newAppService(t, tc.storage...)
 			runner := &runner{
 				commonConfig: commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(fakeKubeConfig)),
-				service:      newAppService(t, tc.storage...),
+				service:      service,
 				flag:         flag,
 				stdout:       out,
 			}
@@ -150,6 +259,18 @@ func Test_run(t *testing.T) {
 			if diff != "" {
 				t.Fatalf("value not expected, got:\n %s", diff)
 			}
+
+			if tc.storedVersion != "" {
+				resource, err := service.Get(ctx, app.GetOptions{Namespace: "default", Name: tc.flags.Name})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+
+				storedVersion := resource.(*app.App).CR.Spec.Version
+				if storedVersion != tc.storedVersion {
+					t.Fatalf("expected stored version %q, got %q", tc.storedVersion, storedVersion)
+				}
+			}
 		})
 	}
 }
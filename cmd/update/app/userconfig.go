@@ -0,0 +1,309 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	appservice "github.com/giantswarm/kubectl-gs/v5/pkg/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
+	templateapp "github.com/giantswarm/kubectl-gs/v5/pkg/template/app"
+)
+
+// userConfigChange is a change of the user config of an app, computed and
+// validated before anything is written.
+type userConfigChange struct {
+	// current is the existing ConfigMap or Secret, nil if it is created.
+	current client.Object
+	// updated is the ConfigMap or Secret to write.
+	updated client.Object
+}
+
+// kind returns the lowercase kind of the user config, used in messages.
+func (c *userConfigChange) kind() string {
+	if _, ok := c.updated.(*corev1.Secret); ok {
+		return "secret"
+	}
+
+	return "configmap"
+}
+
+// state describes the user config like the state of an app patch, in
+// messages.
+func (c *userConfigChange) state() string {
+	if c.kind() == "secret" {
+		return fmt.Sprintf("userConfig.secret=%s/%s", c.updated.GetNamespace(), c.updated.GetName())
+	}
+
+	return fmt.Sprintf("userConfig.configMap=%s/%s", c.updated.GetNamespace(), c.updated.GetName())
+}
+
+// prepareUserConfig applies --values-file, --set and --unset to the user
+// config of the app, and validates the result against the values schema of
+// the given app version.
+func (r *runner) prepareUserConfig(ctx context.Context, appCR *applicationv1alpha1.App, version string) (*userConfigChange, error) {
+	err := r.getClient()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	change, values, err := r.getUserConfig(ctx, appCR)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if r.flag.ValuesFile != "" {
+		data, err := os.ReadFile(r.flag.ValuesFile)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		fileValues := map[string]interface{}{}
+		err = yaml.Unmarshal(data, &fileValues)
+		if err != nil {
+			return nil, microerror.Maskf(invalidFlagError, "--%s must be a YAML file with a map of values: %s", flagValuesFile, err)
+		}
+
+		values = appservice.MergeValues(values, fileValues)
+	}
+
+	for _, value := range r.flag.Set {
+		k, v, _ := strings.Cut(value, "=")
+		err = setValue(values, k, parseValue(v))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	for _, k := range r.flag.Unset {
+		unsetValue(values, k)
+	}
+
+	err = r.validateValues(ctx, appCR, version, values)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	switch current := change.current.(type) {
+	case *corev1.ConfigMap:
+		updated := current.DeepCopy()
+		if updated.Data == nil {
+			updated.Data = map[string]string{}
+		}
+		updated.Data["values"] = string(data)
+		change.updated = updated
+	case *corev1.Secret:
+		updated := current.DeepCopy()
+		if updated.Data == nil {
+			updated.Data = map[string][]byte{}
+		}
+		updated.Data["values"] = data
+		change.updated = updated
+	default:
+		userConfig := templateapp.UserConfig{
+			Name:      key.GenerateAssetName(appCR.Name, "userconfig"),
+			Namespace: appCR.Namespace,
+			Data:      string(data),
+		}
+		if r.flag.Secret {
+			change.updated, err = templateapp.NewSecret(userConfig)
+		} else {
+			change.updated, err = templateapp.NewConfigMap(userConfig)
+		}
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	return change, nil
+}
+
+// getUserConfig returns the ConfigMap or Secret referenced as user config
+// by the app, and the values it holds.
+func (r *runner) getUserConfig(ctx context.Context, appCR *applicationv1alpha1.App) (*userConfigChange, map[string]interface{}, error) {
+	var object client.Object
+	var kind, name, namespace string
+	if r.flag.Secret {
+		object = &corev1.Secret{}
+		kind = "secret"
+		name, namespace = appCR.Spec.UserConfig.Secret.Name, appCR.Spec.UserConfig.Secret.Namespace
+	} else {
+		object = &corev1.ConfigMap{}
+		kind = "configmap"
+		name, namespace = appCR.Spec.UserConfig.ConfigMap.Name, appCR.Spec.UserConfig.ConfigMap.Namespace
+	}
+
+	values := map[string]interface{}{}
+	if name == "" {
+		return &userConfigChange{}, values, nil
+	}
+	if namespace == "" {
+		namespace = appCR.Namespace
+	}
+
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, object)
+	if apierrors.IsNotFound(err) {
+		return nil, nil, microerror.Maskf(notFoundError, "The user config %s '%s' of app '%s' cannot be found in the '%s' namespace.\n", kind, name, appCR.Name, namespace)
+	} else if err != nil {
+		return nil, nil, microerror.Mask(err)
+	}
+
+	var data []byte
+	switch o := object.(type) {
+	case *corev1.ConfigMap:
+		o.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+		data = []byte(o.Data["values"])
+	case *corev1.Secret:
+		o.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		data = o.Data["values"]
+	}
+
+	err = yaml.Unmarshal(data, &values)
+	if err != nil {
+		return nil, nil, microerror.Maskf(invalidValuesError, "The user config of app '%s' is not a YAML map of values: %s", appCR.Name, err)
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+
+	return &userConfigChange{current: object}, values, nil
+}
+
+// validateValues validates the values the app would have with the given
+// user config values against the values schema of the app version. The
+// chart values and the catalog and cluster config are merged as usual.
+// Apps without a values schema are not validated.
+func (r *runner) validateValues(ctx context.Context, appCR *applicationv1alpha1.App, version string, values map[string]interface{}) error {
+	err := r.getValidationService()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	target := appCR.DeepCopy()
+	if version != "" {
+		target.Spec.Version = version
+	}
+
+	userValues := appservice.UserValues{
+		Secret: r.flag.Secret,
+		Values: values,
+	}
+	_, result, err := r.validationService.ValidateAppUserValues(ctx, target, userValues)
+	if appservice.IsNoSchema(err) {
+		fmt.Fprintf(r.stderr, "Warning: app '%s' has no values schema in version %s, the values are not validated.\n", appCR.Name, target.Spec.Version)
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !result.Valid() {
+		var violations []string
+		for _, resultError := range result.Errors() {
+			violations = append(violations, resultError.String())
+		}
+
+		return microerror.Maskf(invalidValuesError, "The values of app '%s' do not match its values schema: %s", appCR.Name, strings.Join(violations, "; "))
+	}
+
+	return nil
+}
+
+// applyUserConfig creates or updates the ConfigMap or Secret holding the
+// user config.
+func (r *runner) applyUserConfig(ctx context.Context, change *userConfigChange, dryRun dryrun.Strategy) error {
+	name := fmt.Sprintf("%s %s/%s", change.kind(), change.updated.GetNamespace(), change.updated.GetName())
+
+	var err error
+	if change.current == nil {
+		if dryRun != dryrun.Client {
+			err = r.client.Create(ctx, change.updated, dryRun.CreateOptions()...)
+		}
+	} else {
+		if dryRun != dryrun.Client {
+			err = r.client.Update(ctx, change.updated, dryRun.UpdateOptions()...)
+		}
+	}
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if dryRun.IsDryRun() {
+		err = dryrun.PrintDiff(r.stdout, name, change.current, change.updated)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	action := "updated"
+	if change.current == nil {
+		action = "created"
+	}
+	fmt.Fprintf(r.stdout, "User config %s %s%s\n", name, action, dryRun.Suffix())
+
+	return nil
+}
+
+// parseValue parses the value of --set as YAML, so numbers and booleans keep
+// their type. Values that are not valid YAML are used as strings.
+func parseValue(value string) interface{} {
+	var parsed interface{}
+	err := yaml.Unmarshal([]byte(value), &parsed)
+	if err != nil {
+		return value
+	}
+
+	return parsed
+}
+
+// setValue sets the value at the dot separated path, creating the maps on
+// the way.
+func setValue(values map[string]interface{}, path string, value interface{}) error {
+	keys := strings.Split(path, ".")
+
+	current := values
+	for i, k := range keys[:len(keys)-1] {
+		next, exists := current[k]
+		if !exists || next == nil {
+			next = map[string]interface{}{}
+			current[k] = next
+		}
+
+		nextMap, ok := next.(map[string]interface{})
+		if !ok {
+			return microerror.Maskf(invalidFlagError, "--%s %s: %s is not a map", flagSet, path, strings.Join(keys[:i+1], "."))
+		}
+		current = nextMap
+	}
+	current[keys[len(keys)-1]] = value
+
+	return nil
+}
+
+// unsetValue removes the value at the dot separated path, if it exists.
+func unsetValue(values map[string]interface{}, path string) {
+	keys := strings.Split(path, ".")
+
+	current := values
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := current[k].(map[string]interface{})
+		if !ok {
+			return
+		}
+		current = next
+	}
+	delete(current, keys[len(keys)-1])
+}
@@ -0,0 +1,298 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xeipuuv/gojsonschema"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
+
+	appservice "github.com/giantswarm/kubectl-gs/v5/pkg/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	catalogdata "github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/catalog"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/scheme"
+	"github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
+)
+
+const valuesSchema = `{
+  "type": "object",
+  "required": ["image"],
+  "properties": {
+    "image": {"type": "object"},
+    "replicas": {"type": "integer"}
+  }
+}`
+
+func Test_run_userConfig(t *testing.T) {
+	valuesFile := filepath.Join(t.TempDir(), "values.yaml")
+	err := os.WriteFile(valuesFile, []byte("image:\n  tag: c\n"), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var testCases = []struct {
+		name         string
+		storage      []runtime.Object
+		flags        flag
+		errorMatcher func(error) bool
+		message      string
+		// expectedConfigMap and expectedSecret are the values of the user
+		// config after running the command, checked when set.
+		expectedConfigMap string
+		expectedSecret    string
+		// expectedUserConfig is the user config reference of the app after
+		// running the command.
+		expectedUserConfig applicationv1alpha1.AppSpecUserConfig
+	}{
+		{
+			name: "set and unset values of an existing configmap",
+			storage: []runtime.Object{
+				withUserConfigMap(newApp("fake-app", "0.1.0", "fake-catalog"), "fake-app-userconfig"),
+				newUserConfigMap("fake-app-userconfig", "image:\n  tag: a\nreplicas: 1\nresources: {}\n"),
+			},
+			flags:              flag{Name: "fake-app", Set: []string{"image.tag=b", "replicas=2"}, Unset: []string{"resources"}},
+			message:            "User config configmap default/fake-app-userconfig updated\nApp \"fake-app\" in namespace \"default\" updated with userConfig.configMap=default/fake-app-userconfig\n",
+			expectedConfigMap:  "image:\n  tag: b\nreplicas: 2\n",
+			expectedUserConfig: applicationv1alpha1.AppSpecUserConfig{ConfigMap: applicationv1alpha1.AppSpecUserConfigConfigMap{Name: "fake-app-userconfig", Namespace: "default"}},
+		},
+		{
+			name: "create a configmap from a values file",
+			storage: []runtime.Object{
+				newApp("fake-app", "0.1.0", "fake-catalog"),
+			},
+			flags:              flag{Name: "fake-app", ValuesFile: valuesFile},
+			message:            "User config configmap default/fake-app-userconfig created\nApp \"fake-app\" in namespace \"default\" updated with userConfig.configMap=default/fake-app-userconfig\n",
+			expectedConfigMap:  "image:\n  tag: c\n",
+			expectedUserConfig: applicationv1alpha1.AppSpecUserConfig{ConfigMap: applicationv1alpha1.AppSpecUserConfigConfigMap{Name: "fake-app-userconfig", Namespace: "default"}},
+		},
+		{
+			name: "create a secret",
+			storage: []runtime.Object{
+				newApp("fake-app", "0.1.0", "fake-catalog"),
+			},
+			flags:              flag{Name: "fake-app", Set: []string{"password=secret"}, Secret: true},
+			message:            "User config secret default/fake-app-userconfig created\nApp \"fake-app\" in namespace \"default\" updated with userConfig.secret=default/fake-app-userconfig\n",
+			expectedSecret:     "password: secret\n",
+			expectedUserConfig: applicationv1alpha1.AppSpecUserConfig{Secret: applicationv1alpha1.AppSpecUserConfigSecret{Name: "fake-app-userconfig", Namespace: "default"}},
+		},
+		{
+			name: "values completed by the chart values",
+			storage: []runtime.Object{
+				newApp("fake-app", "0.1.0", "fake-catalog"),
+			},
+			flags:              flag{Name: "fake-app", Set: []string{"replicas=2"}},
+			message:            "User config configmap default/fake-app-userconfig created\nApp \"fake-app\" in namespace \"default\" updated with userConfig.configMap=default/fake-app-userconfig\n",
+			expectedConfigMap:  "replicas: 2\n",
+			expectedUserConfig: applicationv1alpha1.AppSpecUserConfig{ConfigMap: applicationv1alpha1.AppSpecUserConfigConfigMap{Name: "fake-app-userconfig", Namespace: "default"}},
+		},
+		{
+			name: "values not matching the schema",
+			storage: []runtime.Object{
+				withUserConfigMap(newApp("fake-app", "0.1.0", "fake-catalog"), "fake-app-userconfig"),
+				newUserConfigMap("fake-app-userconfig", "replicas: 1\n"),
+			},
+			flags:              flag{Name: "fake-app", Set: []string{"replicas=many"}},
+			errorMatcher:       IsInvalidValues,
+			expectedConfigMap:  "replicas: 1\n",
+			expectedUserConfig: applicationv1alpha1.AppSpecUserConfig{ConfigMap: applicationv1alpha1.AppSpecUserConfigConfigMap{Name: "fake-app-userconfig", Namespace: "default"}},
+		},
+		{
+			name: "preview the change with client dry run",
+			storage: []runtime.Object{
+				withUserConfigMap(newApp("fake-app", "0.1.0", "fake-catalog"), "fake-app-userconfig"),
+				newUserConfigMap("fake-app-userconfig", "replicas: 1\n"),
+			},
+			flags: flag{Name: "fake-app", Set: []string{"replicas=3"}, DryRun: "client"},
+			message: `--- configmap default/fake-app-userconfig (current)
++++ configmap default/fake-app-userconfig (updated)
+@@ -1,7 +1,7 @@
+ apiVersion: v1
+ data:
+   values: |
+-    replicas: 1
++    replicas: 3
+ kind: ConfigMap
+ metadata:
+   creationTimestamp: null
+User config configmap default/fake-app-userconfig updated (dry run)
+App "fake-app" in namespace "default" updated with userConfig.configMap=default/fake-app-userconfig (dry run)
+`,
+			expectedConfigMap:  "replicas: 1\n",
+			expectedUserConfig: applicationv1alpha1.AppSpecUserConfig{ConfigMap: applicationv1alpha1.AppSpecUserConfigConfigMap{Name: "fake-app-userconfig", Namespace: "default"}},
+		},
+		{
+			name: "app patch failing, leaving the user config unchanged",
+			storage: []runtime.Object{
+				withUserConfigMap(newApp("fake-app", "0.1.0", "fake-catalog"), "fake-app-userconfig"),
+				newUserConfigMap("fake-app-userconfig", "replicas: 1\n"),
+			},
+			// The catalog of the app cannot be found.
+			flags:              flag{Name: "fake-app", Version: "0.2.0", Set: []string{"replicas=3"}},
+			errorMatcher:       catalogdata.IsNotFound,
+			expectedConfigMap:  "replicas: 1\n",
+			expectedUserConfig: applicationv1alpha1.AppSpecUserConfig{ConfigMap: applicationv1alpha1.AppSpecUserConfigConfigMap{Name: "fake-app-userconfig", Namespace: "default"}},
+		},
+		{
+			name: "missing user config",
+			storage: []runtime.Object{
+				withUserConfigMap(newApp("fake-app", "0.1.0", "fake-catalog"), "fake-app-userconfig"),
+			},
+			flags:        flag{Name: "fake-app", Set: []string{"replicas=3"}},
+			errorMatcher: IsNotFound,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			ctx := context.TODO()
+
+			flag := &tc.flags
+			flag.print = genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault)
+
+			ctrlClient := newFakeClient(t, tc.storage...)
+			service, err := app.New(app.Config{Client: ctrlClient})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			out := new(bytes.Buffer)
+			runner := &runner{
+				commonConfig: commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(kubeconfig.CreateFakeKubeConfig())),
+				flag:         flag,
+				stdout:       out,
+				stderr:       new(bytes.Buffer),
+
+				client:            ctrlClient,
+				service:           service,
+				validationService: &fakeValidationService{schema: valuesSchema},
+			}
+
+			err = runner.run(ctx, nil, []string{})
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if tc.message != "" {
+				diff := cmp.Diff(tc.message, out.String())
+				if diff != "" {
+					t.Fatalf("value not expected, got:\n %s", diff)
+				}
+			}
+
+			if tc.expectedConfigMap != "" {
+				configMap := &corev1.ConfigMap{}
+				err = ctrlClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "fake-app-userconfig"}, configMap)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+
+				diff := cmp.Diff(tc.expectedConfigMap, configMap.Data["values"])
+				if diff != "" {
+					t.Fatalf("value not expected, got:\n %s", diff)
+				}
+			}
+			if tc.expectedSecret != "" {
+				secret := &corev1.Secret{}
+				err = ctrlClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "fake-app-userconfig"}, secret)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+
+				diff := cmp.Diff(tc.expectedSecret, string(secret.Data["values"]))
+				if diff != "" {
+					t.Fatalf("value not expected, got:\n %s", diff)
+				}
+			}
+
+			if tc.errorMatcher == nil || tc.expectedConfigMap != "" {
+				appCR := &applicationv1alpha1.App{}
+				err = ctrlClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "fake-app"}, appCR)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+
+				diff := cmp.Diff(tc.expectedUserConfig, appCR.Spec.UserConfig)
+				if diff != "" {
+					t.Fatalf("value not expected, got:\n %s", diff)
+				}
+			}
+		})
+	}
+}
+
+// fakeValidationService validates the values against a fixed schema,
+// instead of the one of the app's chart. The user values are merged with
+// fixed chart values.
+type fakeValidationService struct {
+	schema string
+}
+
+func (s *fakeValidationService) Validate(ctx context.Context, options appservice.ValidateOptions) (appservice.ValidationResults, error) {
+	return nil, nil
+}
+
+func (s *fakeValidationService) ValidateApp(ctx context.Context, app *applicationv1alpha1.App, customValuesSchema string, yamlData map[string]interface{}) (string, *gojsonschema.Result, error) {
+	result, err := appservice.ValidateSchema(s.schema, yamlData)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return s.schema, result, nil
+}
+
+func (s *fakeValidationService) ValidateAppUserValues(ctx context.Context, app *applicationv1alpha1.App, userValues appservice.UserValues) (string, *gojsonschema.Result, error) {
+	chartValues := map[string]interface{}{
+		"image": map[string]interface{}{
+			"tag": "a",
+		},
+	}
+
+	return s.ValidateApp(ctx, app, "", appservice.MergeValues(chartValues, userValues.Values))
+}
+
+func withUserConfigMap(a *applicationv1alpha1.App, name string) *applicationv1alpha1.App {
+	a.Spec.UserConfig.ConfigMap = applicationv1alpha1.AppSpecUserConfigConfigMap{
+		Name:      name,
+		Namespace: a.Namespace,
+	}
+
+	return a
+}
+
+func newUserConfigMap(name, values string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Data: map[string]string{
+			"values": values,
+		},
+	}
+}
+
+func newFakeClient(t *testing.T, object ...runtime.Object) client.Client {
+	clientScheme, err := scheme.NewScheme()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	return fake.NewClientBuilder().WithScheme(clientScheme).WithRuntimeObjects(object...).Build()
+}
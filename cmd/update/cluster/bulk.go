@@ -0,0 +1,365 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/pluralize"
+)
+
+// servicePriorities lists the values of the service priority label, from
+// the least to the most critical clusters.
+var servicePriorities = []string{label.ServicePriorityLowest, label.ServicePriorityMedium, label.ServicePriorityHighest}
+
+// defaultServicePriority applies to clusters without a service priority
+// label. According to RFC
+// https://github.com/giantswarm/rfc/tree/main/classify-cluster-priority
+// we use "highest" as the default service priority.
+const defaultServicePriority = label.ServicePriorityHighest
+
+const noValue = "-"
+
+func isServicePriority(priority string) bool {
+	for _, p := range servicePriorities {
+		if p == priority {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getServicePriority(c *cluster.Cluster) string {
+	if priority := c.Cluster.GetLabels()[label.ServicePriority]; priority != "" {
+		return priority
+	}
+
+	return defaultServicePriority
+}
+
+func servicePriorityRank(priority string) int {
+	for i, p := range servicePriorities {
+		if p == priority {
+			return i
+		}
+	}
+
+	return len(servicePriorities)
+}
+
+// wave is a group of clusters which are updated together.
+type wave struct {
+	// Priority is the service priority of the clusters in the wave. It is
+	// empty if all clusters are updated in a single wave.
+	Priority string
+	Clusters []*cluster.Cluster
+}
+
+// updateResult is a row of the summary report.
+type updateResult struct {
+	Cluster *cluster.Cluster
+	// Wave is the 1-based number of the wave, or 0 if the cluster is not
+	// part of any wave.
+	Wave int
+	// From is the release version before the update, as patching updates
+	// the cluster in place.
+	From   string
+	Result string
+	Failed bool
+}
+
+func newUpdateResult(c *cluster.Cluster, waveNumber int) updateResult {
+	from := getReleaseVersion(c)
+	if from == "" {
+		from = noValue
+	}
+
+	return updateResult{Cluster: c, Wave: waveNumber, From: from}
+}
+
+// planWaves groups the clusters into waves by their service priority, in
+// the order of the given priorities. Without priorities, all clusters are
+// updated in a single wave, the least critical ones first. Clusters with a
+// service priority not listed are returned separately.
+func planWaves(clusters []*cluster.Cluster, priorities []string) ([]wave, []*cluster.Cluster) {
+	sorted := make([]*cluster.Cluster, len(clusters))
+	copy(sorted, clusters)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := servicePriorityRank(getServicePriority(sorted[i])), servicePriorityRank(getServicePriority(sorted[j]))
+		if a != b {
+			return a < b
+		}
+
+		return sorted[i].Cluster.GetName() < sorted[j].Cluster.GetName()
+	})
+
+	if len(priorities) == 0 {
+		if len(sorted) == 0 {
+			return nil, nil
+		}
+
+		return []wave{{Clusters: sorted}}, nil
+	}
+
+	var waves []wave
+	planned := map[*cluster.Cluster]bool{}
+	for _, priority := range priorities {
+		w := wave{Priority: priority}
+		for _, c := range sorted {
+			if getServicePriority(c) == priority {
+				w.Clusters = append(w.Clusters, c)
+				planned[c] = true
+			}
+		}
+		// Waves without clusters are dropped, so that there is no soak
+		// interval for them.
+		if len(w.Clusters) > 0 {
+			waves = append(waves, w)
+		}
+	}
+
+	var unplanned []*cluster.Cluster
+	for _, c := range sorted {
+		if !planned[c] {
+			unplanned = append(unplanned, c)
+		}
+	}
+
+	return waves, unplanned
+}
+
+// runBulk updates all clusters in the namespace matching the label
+// selector, or all clusters in the namespace of the organization, wave by
+// wave. When a wave fails, the following waves are skipped. A summary of all
+// clusters is printed at the end.
+func (r *runner) runBulk(ctx context.Context, namespace string, scheduledTime time.Time, dryRun dryrun.Strategy) error {
+	targetRelease := r.flag.ReleaseVersion
+
+	getOptions := cluster.GetOptions{
+		Namespace:      namespace,
+		Provider:       r.flag.Provider,
+		LabelSelector:  r.flag.LabelSelector,
+		FallbackToCapi: true,
+	}
+
+	c, err := r.service.Get(ctx, getOptions)
+	if cluster.IsNoResources(err) {
+		return microerror.Maskf(noResourcesError, "No clusters matching the selector found in the '%s' namespace.", namespace)
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	collection, ok := c.(*cluster.Collection)
+	if !ok || len(collection.Items) == 0 {
+		return microerror.Maskf(noResourcesError, "No clusters matching the selector found in the '%s' namespace.", namespace)
+	}
+
+	var clusters []*cluster.Cluster
+	for i := range collection.Items {
+		clusters = append(clusters, &collection.Items[i])
+	}
+
+	if r.flag.Wait {
+		for _, c := range clusters {
+			if !isCapiProvider(c) {
+				return microerror.Maskf(notAllowedError, "--%s is only supported for CAPI clusters, but cluster '%s' is not a CAPI cluster.", flagWait, c.Cluster.GetName())
+			}
+		}
+	}
+
+	waves, unplanned := planWaves(clusters, r.flag.Waves)
+
+	var results []updateResult
+	var failed bool
+	for i, w := range waves {
+		if failed {
+			for _, c := range w.Clusters {
+				result := newUpdateResult(c, i+1)
+				result.Result = "skipped: a previous wave failed"
+				results = append(results, result)
+			}
+			continue
+		}
+
+		var names []string
+		for _, c := range w.Clusters {
+			names = append(names, c.Cluster.GetName())
+		}
+		if w.Priority != "" {
+			fmt.Fprintf(r.stdout, "Wave %d/%d (service priority %s): %s\n\n", i+1, len(waves), w.Priority, strings.Join(names, ", "))
+		} else {
+			fmt.Fprintf(r.stdout, "Wave %d/%d: %s\n\n", i+1, len(waves), strings.Join(names, ", "))
+		}
+
+		// With a scheduled time, the waves are spaced by the soak interval
+		// instead of waiting in between.
+		var waveScheduledTime time.Time
+		if !scheduledTime.IsZero() {
+			waveScheduledTime = scheduledTime.Add(time.Duration(i) * r.flag.SoakInterval)
+		}
+
+		waveResults := r.updateWave(ctx, w.Clusters, i+1, targetRelease, waveScheduledTime, dryRun)
+		for _, result := range waveResults {
+			failed = failed || result.Failed
+		}
+		results = append(results, waveResults...)
+
+		if failed || i == len(waves)-1 || r.flag.SoakInterval == 0 || !scheduledTime.IsZero() {
+			continue
+		}
+
+		if dryRun.IsDryRun() {
+			fmt.Fprintf(r.stdout, "Would soak for %s before the next wave%s.\n\n", r.flag.SoakInterval, dryRun.Suffix())
+			continue
+		}
+
+		fmt.Fprintf(r.stdout, "Soaking for %s before the next wave.\n\n", r.flag.SoakInterval)
+		select {
+		case <-ctx.Done():
+			return microerror.Mask(ctx.Err())
+		case <-time.After(r.flag.SoakInterval):
+		}
+	}
+
+	for _, c := range unplanned {
+		result := newUpdateResult(c, 0)
+		result.Result = fmt.Sprintf("skipped: service priority %s is not part of --%s", getServicePriority(c), flagWaves)
+		results = append(results, result)
+	}
+
+	err = r.printSummary(results, targetRelease)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var failures int
+	for _, result := range results {
+		if result.Failed {
+			failures++
+		}
+	}
+	if failures > 0 {
+		return microerror.Maskf(updateFailedError, "%d of %d %s could not be updated.", failures, len(results), pluralize.Pluralize("cluster", len(results)))
+	}
+
+	return nil
+}
+
+// updateWave updates all clusters of a wave, before waiting for their
+// rollouts, so that they roll out at the same time.
+func (r *runner) updateWave(ctx context.Context, clusters []*cluster.Cluster, waveNumber int, targetRelease string, scheduledTime time.Time, dryRun dryrun.Strategy) []updateResult {
+	var results []updateResult
+	var updated []*cluster.Cluster
+
+	for _, c := range clusters {
+		result := newUpdateResult(c, waveNumber)
+
+		if getReleaseVersion(c) == targetRelease {
+			result.Result = "skipped: already on the release"
+			results = append(results, result)
+			continue
+		}
+
+		err := r.updateCluster(ctx, c, targetRelease, scheduledTime, dryRun)
+		if err != nil {
+			fmt.Fprintf(r.stderr, "Updating cluster '%s' failed: %s\n\n", c.Cluster.GetName(), err)
+			result.Result = fmt.Sprintf("failed: %s", err)
+			result.Failed = true
+		} else if !scheduledTime.IsZero() {
+			result.Result = fmt.Sprintf("scheduled for %s%s", scheduledTime.Format(time.RFC1123), dryRun.Suffix())
+		} else {
+			result.Result = fmt.Sprintf("updated%s", dryRun.Suffix())
+			updated = append(updated, c)
+		}
+		results = append(results, result)
+	}
+
+	if !r.flag.Wait || len(updated) == 0 {
+		return results
+	}
+
+	// The clusters of the wave roll out at the same time, so the timeout
+	// applies to the wave, not to each of its clusters.
+	ctx, cancel := context.WithTimeout(ctx, r.flag.Timeout)
+	defer cancel()
+
+	for i := range results {
+		if !containsCluster(updated, results[i].Cluster) {
+			continue
+		}
+
+		err := r.getCtrlClient()
+		if err == nil {
+			err = r.waitForRollout(ctx, r.ctrlClient, results[i].Cluster.Cluster.GetName(), results[i].Cluster.Cluster.GetNamespace(), targetRelease)
+		}
+		// Waiting for the other clusters of the wave goes on, so that the
+		// summary is complete.
+		if err != nil {
+			fmt.Fprintf(r.stderr, "%s\n\n", err)
+			results[i].Result = fmt.Sprintf("failed: %s", err)
+			results[i].Failed = true
+			continue
+		}
+		results[i].Result = "rolled out"
+	}
+
+	return results
+}
+
+func containsCluster(clusters []*cluster.Cluster, c *cluster.Cluster) bool {
+	for _, other := range clusters {
+		if other == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *runner) printSummary(results []updateResult, targetRelease string) error {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "NAME", Type: "string"},
+			{Name: "SERVICE PRIORITY", Type: "string"},
+			{Name: "WAVE", Type: "string"},
+			{Name: "FROM", Type: "string"},
+			{Name: "TO", Type: "string"},
+			{Name: "RESULT", Type: "string"},
+		},
+	}
+
+	for _, result := range results {
+		waveNumber := noValue
+		if result.Wave > 0 {
+			waveNumber = fmt.Sprintf("%d", result.Wave)
+		}
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []interface{}{
+				result.Cluster.Cluster.GetName(),
+				getServicePriority(result.Cluster),
+				waveNumber,
+				result.From,
+				targetRelease,
+				result.Result,
+			},
+		})
+	}
+
+	printer := printers.NewTablePrinter(printers.PrintOptions{})
+	err := printer.PrintObj(table, r.stdout)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,513 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	k8smetadatalabel "github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	securityv1alpha1 "github.com/giantswarm/organization-operator/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/label"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/organization"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/scheme"
+	"github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
+)
+
+func Test_planWaves(t *testing.T) {
+	clusters := []*cluster.Cluster{
+		{Cluster: withServicePriority(newCluster("prod2", "default", ""), "highest")},
+		{Cluster: withServicePriority(newCluster("stage1", "default", ""), "lowest")},
+		{Cluster: newCluster("prod1", "default", "")},
+		{Cluster: withServicePriority(newCluster("test1", "default", ""), "medium")},
+	}
+
+	testCases := []struct {
+		name              string
+		priorities        []string
+		expectedWaves     []string
+		expectedUnplanned []string
+	}{
+		{
+			name:          "single wave, least critical clusters first",
+			expectedWaves: []string{": stage1, test1, prod1, prod2"},
+		},
+		{
+			name:          "one wave per service priority",
+			priorities:    []string{"lowest", "medium", "highest"},
+			expectedWaves: []string{"lowest: stage1", "medium: test1", "highest: prod1, prod2"},
+		},
+		{
+			name:              "service priorities not listed are not planned",
+			priorities:        []string{"highest", "lowest"},
+			expectedWaves:     []string{"highest: prod1, prod2", "lowest: stage1"},
+			expectedUnplanned: []string{"test1"},
+		},
+		{
+			name:              "waves without clusters are dropped",
+			priorities:        []string{"medium"},
+			expectedWaves:     []string{"medium: test1"},
+			expectedUnplanned: []string{"stage1", "prod1", "prod2"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			waves, unplanned := planWaves(clusters, tc.priorities)
+
+			var gotWaves []string
+			for _, w := range waves {
+				gotWaves = append(gotWaves, fmt.Sprintf("%s: %s", w.Priority, clusterNames(w.Clusters)))
+			}
+			diff := cmp.Diff(tc.expectedWaves, gotWaves)
+			if diff != "" {
+				t.Fatalf("waves not expected, got:\n %s", diff)
+			}
+
+			var gotUnplanned []string
+			for _, c := range unplanned {
+				gotUnplanned = append(gotUnplanned, c.Cluster.Name)
+			}
+			diff = cmp.Diff(tc.expectedUnplanned, gotUnplanned)
+			if diff != "" {
+				t.Fatalf("unplanned clusters not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func Test_run_bulk(t *testing.T) {
+	testCases := []struct {
+		name           string
+		storage        []runtime.Object
+		flags          flag
+		expectedOutput string
+		// expectedReleases are the release version labels after the update.
+		expectedReleases map[string]string
+		// expectedSchedules are the scheduled update times after the update.
+		expectedSchedules map[string]string
+		errorMatcher      func(error) bool
+	}{
+		{
+			name: "update clusters matching the selector in waves",
+			storage: []runtime.Object{
+				withServicePriority(withLabel(newCluster("stage1", "default", ""), "env", "test"), "lowest"),
+				newAWSCluster("stage1", "default", ""),
+				withLabel(newCluster("prod1", "default", ""), "env", "test"),
+				newAWSCluster("prod1", "default", ""),
+				withServicePriority(withLabel(newCluster("test1", "default", ""), "env", "test"), "medium"),
+				newAWSCluster("test1", "default", ""),
+				withReleaseVersion(withServicePriority(withLabel(newCluster("prod2", "default", ""), "env", "test"), "highest"), "16.1.0"),
+				newAWSCluster("prod2", "default", ""),
+				newCluster("other1", "default", ""),
+				newAWSCluster("other1", "default", ""),
+			},
+			flags: flag{LabelSelector: "env=test", ReleaseVersion: "16.1.0", Provider: "aws", Waves: []string{"lowest", "highest"}, SoakInterval: 10 * time.Millisecond},
+			expectedOutput: `Wave 1/2 (service priority lowest): stage1
+
+No components or apps change from release v16.0.1 to v16.1.0.
+
+Cluster 'stage1' is updated to release version '16.1.0'
+
+Soaking for 10ms before the next wave.
+
+Wave 2/2 (service priority highest): prod1, prod2
+
+No components or apps change from release v16.0.1 to v16.1.0.
+
+Cluster 'prod1' is updated to release version '16.1.0'
+
+NAME     SERVICE PRIORITY   WAVE   FROM     TO       RESULT
+stage1   lowest             1      16.0.1   16.1.0   updated
+prod1    highest            2      16.0.1   16.1.0   updated
+prod2    highest            2      16.1.0   16.1.0   skipped: already on the release
+test1    medium             -      16.0.1   16.1.0   skipped: service priority medium is not part of --waves
+`,
+			expectedReleases: map[string]string{
+				"stage1": "16.1.0",
+				"prod1":  "16.1.0",
+				"test1":  "16.0.1",
+				"other1": "16.0.1",
+			},
+		},
+		{
+			name: "schedule the updates of all clusters, spaced by the soak interval",
+			storage: []runtime.Object{
+				withServicePriority(newCluster("stage1", "default", ""), "lowest"),
+				newAWSCluster("stage1", "default", ""),
+				newCluster("prod1", "default", ""),
+				newAWSCluster("prod1", "default", ""),
+			},
+			flags: flag{AllInOrg: "acme", ReleaseVersion: "16.1.0", Provider: "aws", ScheduledTime: "2022-01-01 01:00", Waves: []string{"lowest", "highest"}, SoakInterval: 24 * time.Hour},
+			expectedSchedules: map[string]string{
+				"stage1": "01 Jan 22 01:00 UTC",
+				"prod1":  "02 Jan 22 01:00 UTC",
+			},
+			expectedReleases: map[string]string{
+				"stage1": "16.0.1",
+				"prod1":  "16.0.1",
+			},
+		},
+		{
+			name: "preview the update of all clusters",
+			storage: []runtime.Object{
+				withServicePriority(newCluster("stage1", "default", ""), "lowest"),
+				newAWSCluster("stage1", "default", ""),
+				newCluster("prod1", "default", ""),
+				newAWSCluster("prod1", "default", ""),
+			},
+			flags: flag{AllInOrg: "acme", ReleaseVersion: "16.1.0", Provider: "aws", DryRun: "client", Waves: []string{"lowest", "highest"}, SoakInterval: time.Hour},
+			expectedOutput: `Wave 1/2 (service priority lowest): stage1
+
+No components or apps change from release v16.0.1 to v16.1.0.
+
+--- cluster default/stage1 (current)
++++ cluster default/stage1 (updated)
+@@ -7,7 +7,7 @@
+   labels:
+     cluster.x-k8s.io/cluster-name: stage1
+     giantswarm.io/service-priority: lowest
+-    release.giantswarm.io/version: 16.0.1
++    release.giantswarm.io/version: 16.1.0
+   name: stage1
+   namespace: default
+   resourceVersion: "999"
+Cluster 'stage1' is updated to release version '16.1.0' (dry run)
+
+Would soak for 1h0m0s before the next wave (dry run).
+
+Wave 2/2 (service priority highest): prod1
+
+No components or apps change from release v16.0.1 to v16.1.0.
+
+--- cluster default/prod1 (current)
++++ cluster default/prod1 (updated)
+@@ -6,7 +6,7 @@
+   creationTimestamp: null
+   labels:
+     cluster.x-k8s.io/cluster-name: prod1
+-    release.giantswarm.io/version: 16.0.1
++    release.giantswarm.io/version: 16.1.0
+   name: prod1
+   namespace: default
+   resourceVersion: "999"
+Cluster 'prod1' is updated to release version '16.1.0' (dry run)
+
+NAME     SERVICE PRIORITY   WAVE   FROM     TO       RESULT
+stage1   lowest             1      16.0.1   16.1.0   updated (dry run)
+prod1    highest            2      16.0.1   16.1.0   updated (dry run)
+`,
+			expectedReleases: map[string]string{
+				"stage1": "16.0.1",
+				"prod1":  "16.0.1",
+			},
+		},
+		{
+			name: "no clusters matching the selector",
+			storage: []runtime.Object{
+				newCluster("prod1", "default", ""),
+				newAWSCluster("prod1", "default", ""),
+			},
+			flags:        flag{LabelSelector: "env=test", ReleaseVersion: "16.1.0", Provider: "aws"},
+			errorMatcher: IsNoResources,
+		},
+		{
+			name: "organization which does not exist",
+			storage: []runtime.Object{
+				newCluster("prod1", "default", ""),
+				newAWSCluster("prod1", "default", ""),
+			},
+			flags:        flag{AllInOrg: "unknown", ReleaseVersion: "16.1.0", Provider: "aws"},
+			errorMatcher: IsNotFound,
+		},
+		{
+			name: "organization without a namespace",
+			storage: []runtime.Object{
+				newCluster("prod1", "default", ""),
+				newAWSCluster("prod1", "default", ""),
+			},
+			flags:        flag{AllInOrg: "pending", ReleaseVersion: "16.1.0", Provider: "aws"},
+			errorMatcher: IsNotFound,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			ctx := context.TODO()
+
+			flag := &tc.flags
+			flag.print = genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault)
+
+			out := new(bytes.Buffer)
+			service := newClusterService(t, tc.storage...)
+			runner := &runner{
+				commonConfig: commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(kubeconfig.CreateFakeKubeConfig())),
+				flag:         flag,
+				stdout:       out,
+				stderr:       new(bytes.Buffer),
+				service:      service,
+
+				organizationService: newOrganizationService(t, newOrganization("acme", "default"), newOrganization("pending", "")),
+				releaseService:      newReleaseService(t),
+			}
+
+			err := runner.run(ctx, nil, []string{})
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if tc.expectedOutput != "" {
+				diff := cmp.Diff(tc.expectedOutput, out.String())
+				if diff != "" {
+					t.Fatalf("value not expected, got:\n %s", diff)
+				}
+			}
+
+			for name, release := range tc.expectedReleases {
+				c := getStoredCluster(t, service, name)
+				if c.Labels[label.ReleaseVersion] != release {
+					t.Fatalf("expected release version %q of cluster %q, got %q", release, name, c.Labels[label.ReleaseVersion])
+				}
+			}
+			for name, scheduledTime := range tc.expectedSchedules {
+				c := getStoredCluster(t, service, name)
+				if c.Annotations[annotation.UpdateScheduleTargetTime] != scheduledTime {
+					t.Fatalf("expected update of cluster %q to be scheduled for %q, got %q", name, scheduledTime, c.Annotations[annotation.UpdateScheduleTargetTime])
+				}
+				if c.Annotations[annotation.UpdateScheduleTargetRelease] != flag.ReleaseVersion {
+					t.Fatalf("expected update of cluster %q to be scheduled to release %q, got %q", name, flag.ReleaseVersion, c.Annotations[annotation.UpdateScheduleTargetRelease])
+				}
+			}
+		})
+	}
+}
+
+func Test_run_bulk_failedWave(t *testing.T) {
+	defer func(p time.Duration) {
+		pollInterval = p
+	}(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	ctx := context.TODO()
+
+	var storage []client.Object
+	for _, name := range []string{"stage1", "prod1"} {
+		storage = append(storage, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-userconfig", name),
+				Namespace: "org-test",
+			},
+			Data: map[string]string{
+				"values": "global:\n  release:\n    version: 27.0.0\n",
+			},
+		})
+	}
+	storage = append(storage,
+		withServicePriority(newCAPICluster("stage1", "27.0.0", readyCondition(corev1.ConditionTrue, "")), "lowest"),
+		newClusterApp("stage1", "failed"),
+		newCAPICluster("prod1", "27.0.0", readyCondition(corev1.ConditionTrue, "")),
+	)
+	ctrlClient := newFakeClient(t, storage...)
+
+	out := new(bytes.Buffer)
+	runner := &runner{
+		// The namespace of the organization is used instead.
+		commonConfig: commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(kubeconfig.CreateFakeKubeConfig()).WithNamespace("default")),
+		flag: &flag{
+			AllInOrg:       "test",
+			ReleaseVersion: "28.1.0",
+			Provider:       "capa",
+			StallTimeout:   time.Second,
+			Timeout:        time.Second,
+			Wait:           true,
+			Waves:          []string{"lowest", "highest"},
+			print:          genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault),
+		},
+		stdout:     out,
+		stderr:     new(bytes.Buffer),
+		ctrlClient: ctrlClient,
+		service:    cluster.New(cluster.Config{Client: ctrlClient}),
+
+		organizationService: newOrganizationService(t, newOrganization("test", "org-test")),
+		releaseService:      newReleaseService(t),
+	}
+
+	err := runner.run(ctx, nil, []string{})
+	if !IsUpdateFailed(err) {
+		t.Fatalf("error not matching expected matcher, got: %v", err)
+	}
+
+	expectedSummary := `NAME     SERVICE PRIORITY   WAVE   FROM     TO       RESULT
+stage1   lowest             1      27.0.0   28.1.0   failed: rollout failed error: The rollout of release version '28.1.0' to cluster 'stage1' failed: cluster app: helm upgrade failed
+prod1    highest            2      27.0.0   28.1.0   skipped: a previous wave failed
+`
+	if !bytes.HasSuffix(out.Bytes(), []byte(expectedSummary)) {
+		t.Fatalf("expected output to end with summary:\n%s\ngot:\n%s", expectedSummary, out.String())
+	}
+
+	// The values of the skipped cluster must not have been updated.
+	cm := &corev1.ConfigMap{}
+	err = ctrlClient.Get(ctx, client.ObjectKey{Name: "prod1-userconfig", Namespace: "org-test"}, cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff("global:\n  release:\n    version: 27.0.0\n", cm.Data["values"])
+	if diff != "" {
+		t.Fatalf("value not expected, got:\n %s", diff)
+	}
+}
+
+func Test_run_bulk_waveTimeout(t *testing.T) {
+	defer func(p time.Duration) {
+		pollInterval = p
+	}(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	ctx := context.TODO()
+
+	var storage []client.Object
+	for _, name := range []string{"stage1", "stage2"} {
+		storage = append(storage,
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-userconfig", name),
+					Namespace: "org-test",
+				},
+				Data: map[string]string{
+					"values": "global:\n  release:\n    version: 27.0.0\n",
+				},
+			},
+			// The rollout never finishes.
+			newCAPICluster(name, "27.0.0", readyCondition(corev1.ConditionTrue, "")),
+		)
+	}
+	ctrlClient := newFakeClient(t, storage...)
+
+	timeout := 300 * time.Millisecond
+	out := new(bytes.Buffer)
+	runner := &runner{
+		commonConfig: commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(kubeconfig.CreateFakeKubeConfig()).WithNamespace("default")),
+		flag: &flag{
+			AllInOrg:       "test",
+			ReleaseVersion: "28.1.0",
+			Provider:       "capa",
+			StallTimeout:   time.Minute,
+			Timeout:        timeout,
+			Wait:           true,
+			print:          genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault),
+		},
+		stdout:     out,
+		stderr:     new(bytes.Buffer),
+		ctrlClient: ctrlClient,
+		service:    cluster.New(cluster.Config{Client: ctrlClient}),
+
+		organizationService: newOrganizationService(t, newOrganization("test", "org-test")),
+		releaseService:      newReleaseService(t),
+	}
+
+	start := time.Now()
+	err := runner.run(ctx, nil, []string{})
+	if !IsUpdateFailed(err) {
+		t.Fatalf("error not matching expected matcher, got: %v", err)
+	}
+
+	// The clusters of the wave are waited for within the same timeout,
+	// not one after another.
+	if elapsed := time.Since(start); elapsed >= 2*timeout {
+		t.Fatalf("expected the wave to time out after %s, took %s", timeout, elapsed)
+	}
+	for _, name := range []string{"stage1", "stage2"} {
+		expected := fmt.Sprintf("failed: rollout timeout error: The rollout of release version '28.1.0' to cluster '%s' did not finish within %s.", name, timeout)
+		if !strings.Contains(out.String(), expected) {
+			t.Fatalf("expected output to contain %q, got:\n%s", expected, out.String())
+		}
+	}
+}
+
+func withLabel(c *capi.Cluster, key, value string) *capi.Cluster {
+	c.Labels[key] = value
+	return c
+}
+
+func withServicePriority(c *capi.Cluster, priority string) *capi.Cluster {
+	return withLabel(c, k8smetadatalabel.ServicePriority, priority)
+}
+
+func withReleaseVersion(c *capi.Cluster, release string) *capi.Cluster {
+	return withLabel(c, label.ReleaseVersion, release)
+}
+
+func clusterNames(clusters []*cluster.Cluster) string {
+	var names string
+	for i, c := range clusters {
+		if i > 0 {
+			names += ", "
+		}
+		names += c.Cluster.Name
+	}
+
+	return names
+}
+
+func newOrganizationService(t *testing.T, object ...runtime.Object) organization.Interface {
+	clientScheme, err := scheme.NewScheme()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", microerror.Pretty(err, true))
+	}
+
+	clients := k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+		CtrlClient: fake.NewClientBuilder().WithScheme(clientScheme).WithRuntimeObjects(object...).Build(),
+	})
+
+	service, err := organization.New(organization.Config{
+		Client: clients,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", microerror.Pretty(err, true))
+	}
+
+	return service
+}
+
+func newOrganization(name, namespace string) *securityv1alpha1.Organization {
+	return &securityv1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: securityv1alpha1.OrganizationStatus{
+			Namespace: namespace,
+		},
+	}
+}
+
+func getStoredCluster(t *testing.T, service *cluster.Service, name string) *capi.Cluster {
+	resource, err := service.Get(context.TODO(), cluster.GetOptions{Name: name, Namespace: "default", Provider: "aws"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resource.(*cluster.Cluster).Cluster
+}
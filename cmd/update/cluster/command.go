@@ -12,6 +12,7 @@ import (
 
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewclientcert"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware/renewtoken"
 )
 
@@ -23,12 +24,38 @@ const (
 
 Updates given cluster with the provided values.
 
+For CAPI clusters, the release version is set at 'global.release.version'
+in the values of the '<cluster-name>-userconfig' ConfigMap, which is where
+all cluster-<provider> charts expect it. Other provider-specific paths are
+not supported.
+
+Before updating, the release is validated: it must exist, be active, not be
+a downgrade and not skip a major version. The components and apps changing
+with the update are printed. Use --force to update anyway.
+
+Instead of a single cluster, all clusters of an organization, or the ones
+matching a label selector, can be updated. They can be updated in waves,
+one per service priority, e.g. the least critical clusters first, waiting
+for a soak interval before updating the next wave. When a wave fails, the
+following waves are skipped. A summary of all clusters is printed at the end.
+
 Options:
   --name <cluster-name>             	Name of the cluster to update.
+  -l, --selector <selector>		Update all clusters matching the label selector.
+  --all-in-org <organization>		Update all clusters of the organization.
   --namespace <cluster-namespace>   	Namespace of the cluster.
   --release-version <release-version>   Update the cluster to a release version. The release version must be higher than the current release version.
   --scheduled-time <scheduled-time>     Optionally: Scheduled time when cluster should be updated, time format 'YYYY-MM-DD HH:MM'.
-  --provider <provider> 		Name of the provider.`
+  --cancel-schedule			Cancel the scheduled update of the cluster.
+  --reschedule				Move the scheduled update of the cluster to --scheduled-time, keeping the scheduled release version unless --release-version is given.
+  --provider <provider> 		Name of the provider. Not needed for CAPI clusters.
+  --force				Update the cluster even if the release upgrade path is not valid.
+  --dry-run[=client|server]		Only show the changes as a diff, without applying them.
+  --wait				Wait for the rollout to finish, printing its progress. Only supported for CAPI clusters.
+  --timeout <duration>			How long to wait for the rollout to finish, for each wave when updating many clusters. Defaults to 2h.
+  --stall-timeout <duration>		How long the rollout may make no progress before it is considered stalled. Defaults to 20m.
+  --waves <priorities>			Update the clusters in waves, one per service priority, e.g. 'lowest,medium,highest'.
+  --soak-interval <duration>		How long to wait after a wave, before updating the next one. With --scheduled-time, waves are scheduled this far apart.`
 
 	examples = `  # Display this help
 kubectl gs update cluster --help
@@ -37,7 +64,25 @@ kubectl gs update cluster --help
 kubectl gs update cluster --name abcd1 --namespace my-org --release-version 16.1.0 --provider aws
 
 # Schedule cluster update
-kubectl gs update cluster --name abcd1 --namespace my-org --release-version 16.1.0 --scheduled-time "2022-01-01 10:00" --provider aws`
+kubectl gs update cluster --name abcd1 --namespace my-org --release-version 16.1.0 --scheduled-time "2022-01-01 10:00" --provider aws
+
+# Move a scheduled cluster update to another time
+kubectl gs update cluster --name abcd1 --namespace my-org --reschedule --scheduled-time "2022-01-02 10:00" --provider aws
+
+# Cancel a scheduled cluster update
+kubectl gs update cluster --name abcd1 --namespace my-org --cancel-schedule --provider aws
+
+# Preview a cluster update, validated by the API server
+kubectl gs update cluster --name abcd1 --namespace my-org --release-version 16.1.0 --provider aws --dry-run=server
+
+# Update a CAPI cluster and wait up to 90 minutes for the rollout to finish
+kubectl gs update cluster --name abcd1 --namespace my-org --release-version 29.1.0 --provider capa --wait --timeout 90m
+
+# Update the least critical clusters of an organization first, and the others after a day
+kubectl gs update cluster --all-in-org my-org --release-version 29.1.0 --provider capa --waves lowest,medium,highest --wait --soak-interval 24h
+
+# Schedule the updates of all clusters matching a label selector, one wave per day
+kubectl gs update cluster -l env=test --namespace org-my-org --release-version 29.1.0 --provider capa --scheduled-time "2025-01-01 10:00" --waves lowest,highest --soak-interval 24h`
 )
 
 type Config struct {
@@ -86,6 +131,7 @@ func New(config Config) (*cobra.Command, error) {
 		RunE:    r.Run,
 		PreRunE: middleware.Compose(
 			renewtoken.Middleware(*config.ConfigFlags),
+			renewclientcert.Middleware(*config.ConfigFlags),
 		),
 	}
 
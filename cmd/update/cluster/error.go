@@ -48,3 +48,75 @@ var notAllowedError = &microerror.Error{
 func IsNotAllowed(err error) bool {
 	return microerror.Cause(err) == notAllowedError
 }
+
+var invalidValuesError = &microerror.Error{
+	Kind: "invalidValuesError",
+}
+
+// IsInvalidValues asserts invalidValuesError.
+func IsInvalidValues(err error) bool {
+	return microerror.Cause(err) == invalidValuesError
+}
+
+var pathNotFoundError = &microerror.Error{
+	Kind: "pathNotFoundError",
+}
+
+// IsPathNotFound asserts pathNotFoundError.
+func IsPathNotFound(err error) bool {
+	return microerror.Cause(err) == pathNotFoundError
+}
+
+var rolloutFailedError = &microerror.Error{
+	Kind: "rolloutFailedError",
+}
+
+// IsRolloutFailed asserts rolloutFailedError.
+func IsRolloutFailed(err error) bool {
+	return microerror.Cause(err) == rolloutFailedError
+}
+
+var rolloutStalledError = &microerror.Error{
+	Kind: "rolloutStalledError",
+}
+
+// IsRolloutStalled asserts rolloutStalledError.
+func IsRolloutStalled(err error) bool {
+	return microerror.Cause(err) == rolloutStalledError
+}
+
+var rolloutTimeoutError = &microerror.Error{
+	Kind: "rolloutTimeoutError",
+}
+
+// IsRolloutTimeout asserts rolloutTimeoutError.
+func IsRolloutTimeout(err error) bool {
+	return microerror.Cause(err) == rolloutTimeoutError
+}
+
+var updateFailedError = &microerror.Error{
+	Kind: "updateFailedError",
+}
+
+// IsUpdateFailed asserts updateFailedError.
+func IsUpdateFailed(err error) bool {
+	return microerror.Cause(err) == updateFailedError
+}
+
+var noScheduledUpdateError = &microerror.Error{
+	Kind: "noScheduledUpdateError",
+}
+
+// IsNoScheduledUpdate asserts noScheduledUpdateError.
+func IsNoScheduledUpdate(err error) bool {
+	return microerror.Cause(err) == noScheduledUpdateError
+}
+
+var invalidUpgradePathError = &microerror.Error{
+	Kind: "invalidUpgradePathError",
+}
+
+// IsInvalidUpgradePath asserts invalidUpgradePathError.
+func IsInvalidUpgradePath(err error) bool {
+	return microerror.Cause(err) == invalidUpgradePathError
+}
@@ -1,34 +1,80 @@
 package cluster
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
 )
 
 const (
+	flagAllInOrg       = "all-in-org"
+	flagCancelSchedule = "cancel-schedule"
+	flagDryRun         = "dry-run"
+	flagForce          = "force"
+	flagLabelSelector  = "selector"
 	flagName           = "name"
 	flagReleaseVersion = "release-version"
 	flagScheduledTime  = "scheduled-time"
 	flagProvider       = "provider"
+	flagReschedule     = "reschedule"
+	flagSoakInterval   = "soak-interval"
+	flagStallTimeout   = "stall-timeout"
+	flagTimeout        = "timeout"
+	flagWait           = "wait"
+	flagWaves          = "waves"
 )
 
 type flag struct {
 	print          *genericclioptions.PrintFlags
+	AllInOrg       string
+	CancelSchedule bool
+	DryRun         string
+	Force          bool
+	LabelSelector  string
 	Name           string
 	ReleaseVersion string
 	ScheduledTime  string
 	Provider       string
+	Reschedule     bool
+	SoakInterval   time.Duration
+	StallTimeout   time.Duration
+	Timeout        time.Duration
+	Wait           bool
+	Waves          []string
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&f.Name, flagName, "", "Name of the cluster to update.")
+	cmd.Flags().StringVarP(&f.LabelSelector, flagLabelSelector, "l", "", "Update all clusters in the namespace matching this label selector, instead of a single cluster.")
+	cmd.Flags().StringVar(&f.AllInOrg, flagAllInOrg, "", "Update all clusters of the organization with this name, instead of a single cluster. The namespace of the organization is used, instead of --namespace.")
 
 	cmd.Flags().StringVar(&f.ReleaseVersion, flagReleaseVersion, "", "Update the cluster to a release version. The release version must be higher than the current release version.")
 
+	cmd.Flags().BoolVar(&f.Force, flagForce, false, "Update the cluster even if the release does not exist, is not active, is a downgrade or skips a major version.")
+
 	cmd.Flags().StringVar(&f.ScheduledTime, flagScheduledTime, "", "Optionally: Scheduled time when cluster should be updated. The value has to be in RFC822 Format and UTC time zone.")
 
-	cmd.Flags().StringVar(&f.Provider, flagProvider, "", "Name of the provider.")
+	cmd.Flags().BoolVar(&f.CancelSchedule, flagCancelSchedule, false, "Cancel the scheduled update of the cluster.")
+
+	cmd.Flags().BoolVar(&f.Reschedule, flagReschedule, false, fmt.Sprintf("Move the scheduled update of the cluster to the time given with --%s. The scheduled release version is kept, unless --%s is given.", flagScheduledTime, flagReleaseVersion))
+
+	cmd.Flags().StringVar(&f.Provider, flagProvider, "", "Name of the provider. Not needed for CAPI clusters, whose provider is taken from their infrastructure.")
+
+	cmd.Flags().StringVar(&f.DryRun, flagDryRun, string(dryrun.None), fmt.Sprintf("Only show the changes as a diff, without applying them. Must be one of %s. With %q, the changes are computed locally. With %q, they are sent to the API server without being persisted.", strings.Join(dryrun.Strategies, ", "), dryrun.Client, dryrun.Server))
+	cmd.Flags().Lookup(flagDryRun).NoOptDefVal = string(dryrun.Client)
+
+	cmd.Flags().BoolVar(&f.Wait, flagWait, false, "Wait for the rollout of the release to finish, printing its progress. Fails if the rollout reports a failure or stalls. Only supported for CAPI clusters.")
+	cmd.Flags().DurationVar(&f.Timeout, flagTimeout, 2*time.Hour, fmt.Sprintf("How long to wait for the rollout to finish, when using --%s. When updating many clusters, this applies to each wave, as its clusters roll out at the same time.", flagWait))
+	cmd.Flags().DurationVar(&f.StallTimeout, flagStallTimeout, 20*time.Minute, fmt.Sprintf("How long the rollout may make no progress before it is considered stalled, when using --%s.", flagWait))
+
+	cmd.Flags().StringSliceVar(&f.Waves, flagWaves, nil, fmt.Sprintf("Update the clusters in waves, one per service priority, in the given order, e.g. 'lowest,medium,highest'. Clusters with a service priority not listed are not updated. Only with --%s or --%s.", flagLabelSelector, flagAllInOrg))
+	cmd.Flags().DurationVar(&f.SoakInterval, flagSoakInterval, 0, fmt.Sprintf("How long to wait after a wave has been updated, before updating the next one. With --%s, the next wave is scheduled this long after the previous one instead.", flagScheduledTime))
 
 	f.print = genericclioptions.NewPrintFlags("")
 
@@ -38,13 +84,89 @@ func (f *flag) Init(cmd *cobra.Command) {
 }
 
 func (f *flag) Validate() error {
-	if f.Name == "" {
-		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagName)
+	var targets int
+	for _, set := range []bool{f.Name != "", f.LabelSelector != "", f.AllInOrg != ""} {
+		if set {
+			targets++
+		}
+	}
+	if targets == 0 {
+		return microerror.Maskf(invalidFlagError, "one of --%s, --%s or --%s must be given", flagName, flagLabelSelector, flagAllInOrg)
+	}
+	if targets > 1 {
+		return microerror.Maskf(invalidFlagError, "only one of --%s, --%s or --%s can be given", flagName, flagLabelSelector, flagAllInOrg)
+	}
+
+	if f.Name != "" {
+		if len(f.Waves) > 0 {
+			return microerror.Maskf(invalidFlagError, "--%s can only be used with --%s or --%s", flagWaves, flagLabelSelector, flagAllInOrg)
+		}
+		if f.SoakInterval != 0 {
+			return microerror.Maskf(invalidFlagError, "--%s can only be used with --%s or --%s", flagSoakInterval, flagLabelSelector, flagAllInOrg)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, priority := range f.Waves {
+		if !isServicePriority(priority) {
+			return microerror.Maskf(invalidFlagError, "--%s must only contain the service priorities %s, got %q", flagWaves, strings.Join(servicePriorities, ", "), priority)
+		}
+		if seen[priority] {
+			return microerror.Maskf(invalidFlagError, "--%s must not contain service priority %q more than once", flagWaves, priority)
+		}
+		seen[priority] = true
 	}
 
-	if f.ReleaseVersion == "" {
+	if f.SoakInterval < 0 {
+		return microerror.Maskf(invalidFlagError, "--%s must not be negative", flagSoakInterval)
+	}
+
+	if f.CancelSchedule || f.Reschedule {
+		if f.Name == "" {
+			return microerror.Maskf(invalidFlagError, "--%s and --%s can only be used with --%s", flagCancelSchedule, flagReschedule, flagName)
+		}
+		if f.CancelSchedule && f.Reschedule {
+			return microerror.Maskf(invalidFlagError, "--%s cannot be used with --%s", flagCancelSchedule, flagReschedule)
+		}
+		if f.Wait {
+			return microerror.Maskf(invalidFlagError, "--%s and --%s cannot be used with --%s", flagCancelSchedule, flagReschedule, flagWait)
+		}
+	}
+
+	if f.CancelSchedule {
+		if f.ReleaseVersion != "" {
+			return microerror.Maskf(invalidFlagError, "--%s cannot be used with --%s", flagCancelSchedule, flagReleaseVersion)
+		}
+		if f.ScheduledTime != "" {
+			return microerror.Maskf(invalidFlagError, "--%s cannot be used with --%s", flagCancelSchedule, flagScheduledTime)
+		}
+	} else if f.Reschedule {
+		if f.ScheduledTime == "" {
+			return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagReschedule, flagScheduledTime)
+		}
+	} else if f.ReleaseVersion == "" {
 		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagReleaseVersion)
 	}
 
+	dryRun, err := dryrun.Parse(f.DryRun)
+	if err != nil {
+		return microerror.Maskf(invalidFlagError, "--%s must be one of %s", flagDryRun, strings.Join(dryrun.Strategies, ", "))
+	}
+
+	if f.Wait {
+		if f.ScheduledTime != "" {
+			return microerror.Maskf(invalidFlagError, "--%s cannot be used with --%s", flagWait, flagScheduledTime)
+		}
+		if dryRun.IsDryRun() {
+			return microerror.Maskf(invalidFlagError, "--%s cannot be used with --%s", flagWait, flagDryRun)
+		}
+		if f.Timeout <= 0 {
+			return microerror.Maskf(invalidFlagError, "--%s must be positive", flagTimeout)
+		}
+		if f.StallTimeout <= 0 {
+			return microerror.Maskf(invalidFlagError, "--%s must be positive", flagStallTimeout)
+		}
+	}
+
 	return nil
 }
@@ -13,10 +13,14 @@ import (
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/kubectl-gs/v5/internal/label"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/organization"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/release"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
 )
 
 type runner struct {
@@ -24,7 +28,10 @@ type runner struct {
 	flag         *flag
 	logger       micrologger.Logger
 
-	service cluster.Interface
+	ctrlClient          client.Client
+	service             cluster.Interface
+	organizationService organization.Interface
+	releaseService      release.Interface
 
 	stdout io.Writer
 	stderr io.Writer
@@ -49,8 +56,7 @@ func (r *runner) Run(cmd *cobra.Command, args []string) error {
 
 func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
 	var err error
-	var scheduledTime string
-	var t time.Time
+	var scheduledTime time.Time
 
 	name := r.flag.Name
 	targetRelease := r.flag.ReleaseVersion
@@ -59,12 +65,11 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	if r.flag.ScheduledTime != "" {
 		{
 			layout := "2006-01-02 15:04"
-			t, err = time.Parse(layout, r.flag.ScheduledTime)
+			scheduledTime, err = time.Parse(layout, r.flag.ScheduledTime)
 			if err != nil {
 				fmt.Println(err)
 				return microerror.Maskf(notAllowedError, "Scheduled time has not the right time format, please use 'YYYY-MM-DD HH:MM' as the time format.")
 			}
-			scheduledTime = t.Format(time.RFC822)
 		}
 	}
 
@@ -81,10 +86,32 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		return microerror.Mask(err)
 	}
 
+	dryRun, err := dryrun.Parse(r.flag.DryRun)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if r.flag.AllInOrg != "" {
+		namespace, err = r.getOrganizationNamespace(ctx, r.flag.AllInOrg)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if name == "" {
+		err = r.runBulk(ctx, namespace, scheduledTime, dryRun)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
 	getOptions := cluster.GetOptions{
-		Name:      name,
-		Namespace: namespace,
-		Provider:  provider,
+		Name:           name,
+		Namespace:      namespace,
+		Provider:       provider,
+		FallbackToCapi: true,
 	}
 
 	c, err := r.service.Get(ctx, getOptions)
@@ -101,10 +128,68 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		return microerror.Maskf(notFoundError, "Cluster with name '%s' cannot be found in the '%s' namespace.\n", getOptions.Name, getOptions.Namespace)
 	}
 
+	if r.flag.Wait && !isCapiProvider(resource) {
+		return microerror.Maskf(notAllowedError, "--%s is only supported for CAPI clusters.", flagWait)
+	}
+
+	if r.flag.CancelSchedule {
+		err = r.cancelSchedule(ctx, resource, dryRun)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	if r.flag.Reschedule {
+		scheduledRelease, ok := getScheduledRelease(resource)
+		if !ok {
+			return microerror.Maskf(noScheduledUpdateError, "Cluster '%s' has no scheduled update to reschedule.", name)
+		}
+		if targetRelease == "" && scheduledRelease == "" {
+			return microerror.Maskf(noScheduledUpdateError, "The scheduled update of cluster '%s' has no release version. Use --%s to choose it.", name, flagReleaseVersion)
+		}
+		if targetRelease == "" {
+			targetRelease = scheduledRelease
+		}
+	}
+
+	err = r.updateCluster(ctx, resource, targetRelease, scheduledTime, dryRun)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if r.flag.Wait {
+		err = r.getCtrlClient()
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		err = r.waitForRollout(ctx, r.ctrlClient, name, resource.Cluster.GetNamespace(), targetRelease)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+// updateCluster updates the given cluster to the target release, or
+// schedules the update if scheduledTime is set.
+func (r *runner) updateCluster(ctx context.Context, resource *cluster.Cluster, targetRelease string, scheduledTime time.Time, dryRun dryrun.Strategy) error {
+	var err error
+
+	name := resource.Cluster.GetName()
+
+	err = r.validateUpgradePath(ctx, r.getReleaseProvider(resource), name, getReleaseVersion(resource), targetRelease)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
 	var patches cluster.PatchOptions
 	var msg string
 
-	if scheduledTime != "" {
+	if !scheduledTime.IsZero() {
 		patchSpecs := make([]cluster.PatchSpec, 0)
 		if resource.Cluster.Annotations == nil {
 			patchSpecs = append(patchSpecs, cluster.PatchSpec{
@@ -121,14 +206,15 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		patchSpecs = append(patchSpecs, cluster.PatchSpec{
 			Op:    "add",
 			Path:  fmt.Sprintf("/metadata/annotations/%s", replaceToEscape(annotation.UpdateScheduleTargetTime)),
-			Value: scheduledTime,
+			Value: scheduledTime.Format(time.RFC822),
 		})
 
 		patches = cluster.PatchOptions{
+			DryRun:     dryRun,
 			PatchSpecs: patchSpecs,
 		}
-		messageFormat := "An upgrade of cluster %s to release %s has been scheduled for\n\n    %v, (%v)"
-		msg = fmt.Sprintf(messageFormat, name, targetRelease, t.Format(time.RFC1123), t.Local().Format(time.RFC1123))
+		messageFormat := "An upgrade of cluster %s to release %s has been scheduled for\n\n    %v, (%v)%s"
+		msg = fmt.Sprintf(messageFormat, name, targetRelease, scheduledTime.Format(time.RFC1123), scheduledTime.Local().Format(time.RFC1123), dryRun.Suffix())
 	} else if isCapiProvider(resource) {
 
 		currentVersion := getReleaseVersion(resource)
@@ -136,29 +222,43 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 			return microerror.Maskf(notFoundError, "Release version not found in cluster '%s'", name)
 		}
 
-		k8sclient, err := r.commonConfig.GetClient(r.logger)
+		err = r.getCtrlClient()
 		if err != nil {
 			return microerror.Mask(err)
 		}
 
 		cm := &corev1.ConfigMap{}
-		err = k8sclient.CtrlClient().Get(ctx, types.NamespacedName{Name: fmt.Sprintf("%s-userconfig", resource.Cluster.GetName()), Namespace: resource.Cluster.GetNamespace()}, cm)
+		err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("%s-userconfig", resource.Cluster.GetName()), Namespace: resource.Cluster.GetNamespace()}, cm)
 		if err != nil {
 			return microerror.Mask(err)
 		}
 
-		// Extract the values field and replace the current version with the target version
-		values := cm.Data["values"]
-		values = strings.Replace(values, fmt.Sprintf("version: %s", currentVersion), fmt.Sprintf("version: %s", targetRelease), -1)
+		values, err := setReleaseVersion(cm.Data["values"], targetRelease)
+		if IsPathNotFound(err) {
+			return microerror.Maskf(pathNotFoundError, "The release version of cluster '%s' cannot be updated, as '%s' is not set in the values of ConfigMap '%s/%s'. Only this path is supported for the release version.", name, strings.Join(releaseVersionPath, "."), cm.Namespace, cm.Name)
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+		original := cm.DeepCopy()
 		cm.Data["values"] = values
 
-		err = k8sclient.CtrlClient().Update(ctx, cm)
-		if err != nil {
-			return microerror.Mask(err)
+		if dryRun != dryrun.Client {
+			err = r.ctrlClient.Update(ctx, cm, dryRun.UpdateOptions()...)
+			if err != nil {
+				return microerror.Mask(err)
+			}
 		}
-		msg = fmt.Sprintf("Cluster '%s' is updated to release version '%s'\n", name, targetRelease)
+
+		if dryRun.IsDryRun() {
+			err = dryrun.PrintDiff(r.stdout, fmt.Sprintf("configmap %s/%s", cm.Namespace, cm.Name), original, cm)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+		msg = fmt.Sprintf("Cluster '%s' is updated to release version '%s'%s\n", name, targetRelease, dryRun.Suffix())
 	} else {
 		patches = cluster.PatchOptions{
+			DryRun: dryRun,
 			PatchSpecs: []cluster.PatchSpec{
 				{
 					Op:    "add",
@@ -167,18 +267,29 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 				},
 			},
 		}
-		msg = fmt.Sprintf("Cluster '%s' is updated to release version '%s'\n", name, targetRelease)
+		msg = fmt.Sprintf("Cluster '%s' is updated to release version '%s'%s\n", name, targetRelease, dryRun.Suffix())
 	}
 
 	if len(patches.PatchSpecs) > 0 {
-		err = r.service.Patch(ctx, resource.ClientObject(), patches)
+		object := resource.ClientObject()
+		original := object.DeepCopyObject()
+
+		err = r.service.Patch(ctx, object, patches)
 
 		if err != nil {
 			return microerror.Mask(err)
 		}
+
+		if dryRun.IsDryRun() {
+			err = dryrun.PrintDiff(r.stdout, fmt.Sprintf("cluster %s/%s", object.GetNamespace(), object.GetName()), original, object)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
 	}
 
 	fmt.Fprintln(r.stdout, msg)
+
 	return nil
 }
 
@@ -204,6 +315,83 @@ func (r *runner) getService() error {
 	return nil
 }
 
+// getOrganizationNamespace returns the namespace of the organization with
+// the given name.
+func (r *runner) getOrganizationNamespace(ctx context.Context, name string) (string, error) {
+	err := r.getOrganizationService()
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	org, err := r.organizationService.Get(ctx, organization.GetOptions{Name: name})
+	if organization.IsNotFound(err) {
+		return "", microerror.Maskf(notFoundError, "An organization '%s' cannot be found.\n", name)
+	} else if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	namespace := org.(*organization.Organization).Organization.Status.Namespace
+	if namespace == "" {
+		return "", microerror.Maskf(notFoundError, "The namespace of organization '%s' is not known yet.\n", name)
+	}
+
+	return namespace, nil
+}
+
+func (r *runner) getOrganizationService() error {
+	if r.organizationService != nil {
+		return nil
+	}
+
+	client, err := r.commonConfig.GetClient(r.logger)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.organizationService, err = organization.New(organization.Config{
+		Client: client,
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) getReleaseService() error {
+	if r.releaseService != nil {
+		return nil
+	}
+
+	err := r.getCtrlClient()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.releaseService, err = release.New(release.Config{
+		Client: r.ctrlClient,
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) getCtrlClient() error {
+	if r.ctrlClient != nil {
+		return nil
+	}
+
+	k8sclient, err := r.commonConfig.GetClient(r.logger)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	r.ctrlClient = k8sclient.CtrlClient()
+
+	return nil
+}
+
 func isCapiProvider(cluster *cluster.Cluster) bool {
 	labels := cluster.Cluster.GetLabels()
 	name, ok := labels["cluster.x-k8s.io/watch-filter"]
@@ -5,19 +5,26 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
 	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
 	"github.com/giantswarm/microerror"
+	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
 
 	"github.com/giantswarm/kubectl-gs/v5/internal/label"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/release"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/scheme"
 	"github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
@@ -25,9 +32,12 @@ import (
 
 func Test_run(t *testing.T) {
 	var testCases = []struct {
-		name    string
-		storage []runtime.Object
-		flags   flag
+		name           string
+		storage        []runtime.Object
+		flags          flag
+		expectedOutput string
+		// unchanged makes sure the stored cluster was not modified.
+		unchanged bool
 	}{
 		{
 			name:    "update cluster with a scheduled time",
@@ -39,6 +49,34 @@ func Test_run(t *testing.T) {
 			storage: []runtime.Object{newCluster("abcd1", "default", "16.0.1"), newAWSCluster("abcd1", "default", "16.0.1")},
 			flags:   flag{Name: "abcd1", ReleaseVersion: "16.1.0", Provider: "aws"},
 		},
+		{
+			name:    "preview cluster update with client dry run",
+			storage: []runtime.Object{newCluster("abcd1", "default", "16.0.1"), newAWSCluster("abcd1", "default", "16.0.1")},
+			flags:   flag{Name: "abcd1", ReleaseVersion: "16.1.0", Provider: "aws", DryRun: "client"},
+			expectedOutput: `No components or apps change from release v16.0.1 to v16.1.0.
+
+--- cluster default/abcd1 (current)
++++ cluster default/abcd1 (updated)
+@@ -6,7 +6,7 @@
+   creationTimestamp: null
+   labels:
+     cluster.x-k8s.io/cluster-name: abcd1
+-    release.giantswarm.io/version: 16.0.1
++    release.giantswarm.io/version: 16.1.0
+   name: abcd1
+   namespace: default
+   resourceVersion: "999"
+Cluster 'abcd1' is updated to release version '16.1.0' (dry run)
+
+`,
+			unchanged: true,
+		},
+		{
+			name:      "preview scheduled cluster update with server dry run",
+			storage:   []runtime.Object{newCluster("abcd1", "default", "16.0.1"), newAWSCluster("abcd1", "default", "16.0.1")},
+			flags:     flag{Name: "abcd1", ReleaseVersion: "16.1.0", ScheduledTime: "2022-01-01 01:00", Provider: "aws", DryRun: "server"},
+			unchanged: true,
+		},
 	}
 
 	for i, tc := range testCases {
@@ -54,21 +92,117 @@ func Test_run(t *testing.T) {
 			flag.print = genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault)
 
 			out := new(bytes.Buffer)
+			service := newClusterService(t, tc.storage...)
 			runner := &runner{
 				commonConfig: commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(fakeKubeConfig)),
 				flag:         flag,
 				stdout:       out,
-				service:      newClusterService(t, tc.storage...),
+				service:      service,
+
+				releaseService: newReleaseService(t),
 			}
 
 			err = runner.run(ctx, nil, []string{})
 			if err != nil {
 				t.Fatal(err)
 			}
+
+			if tc.expectedOutput != "" {
+				diff := cmp.Diff(tc.expectedOutput, out.String())
+				if diff != "" {
+					t.Fatalf("value not expected, got:\n %s", diff)
+				}
+			}
+
+			if tc.unchanged {
+				resource, err := service.Get(ctx, cluster.GetOptions{Name: flag.Name, Namespace: "default", Provider: flag.Provider})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				c := resource.(*cluster.Cluster).Cluster
+				if c.Labels[label.ReleaseVersion] != "16.0.1" {
+					t.Fatalf("expected release version label to be unchanged, got %q", c.Labels[label.ReleaseVersion])
+				}
+				if _, ok := c.Annotations[annotation.UpdateScheduleTargetRelease]; ok {
+					t.Fatalf("expected no scheduled update annotation")
+				}
+			}
+
 		})
 	}
 }
 
+func Test_run_capi(t *testing.T) {
+	defer func(p time.Duration) {
+		pollInterval = p
+	}(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	ctx := context.TODO()
+
+	userConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "abcd1-userconfig",
+			Namespace: "org-test",
+		},
+		Data: map[string]string{
+			"values": "global:\n  release:\n    version: 27.0.0 # current release\n",
+		},
+	}
+	ctrlClient := newFakeClient(t,
+		// The release version label is already set, as no controller renders
+		// the cluster app here.
+		newCAPICluster("abcd1", "28.1.0", readyCondition(corev1.ConditionTrue, "")),
+		newClusterApp("abcd1", "deployed"),
+		userConfig,
+	)
+
+	out := new(bytes.Buffer)
+	runner := &runner{
+		commonConfig: commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(kubeconfig.CreateFakeKubeConfig()).WithNamespace("org-test")),
+		flag: &flag{
+			// Without --provider, the releases are found by the
+			// infrastructure of the cluster.
+			Name:           "abcd1",
+			ReleaseVersion: "28.1.0",
+			StallTimeout:   time.Second,
+			Timeout:        time.Second,
+			Wait:           true,
+			print:          genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault),
+		},
+		stdout:     out,
+		ctrlClient: ctrlClient,
+		service:    cluster.New(cluster.Config{Client: ctrlClient}),
+
+		releaseService: newReleaseService(t),
+	}
+
+	err := runner.run(ctx, nil, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedOutput := `Cluster 'abcd1' is updated to release version '28.1.0'
+
+[0s] release: 28.1.0 | cluster app: deployed | node pools: 0/0 updated, 0/0 ready | ready: True
+Cluster 'abcd1' has been rolled out to release version '28.1.0'.
+`
+	diff := cmp.Diff(expectedOutput, out.String())
+	if diff != "" {
+		t.Fatalf("value not expected, got:\n %s", diff)
+	}
+
+	err = ctrlClient.Get(ctx, client.ObjectKeyFromObject(userConfig), userConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff("global:\n  release:\n    version: 28.1.0 # current release\n", userConfig.Data["values"])
+	if diff != "" {
+		t.Fatalf("value not expected, got:\n %s", diff)
+	}
+}
+
 func newCluster(name, namespace, targetRelease string) *capi.Cluster {
 	c := &capi.Cluster{
 		TypeMeta: metav1.TypeMeta{
@@ -112,6 +246,48 @@ func newAWSCluster(name, namespace, targetRelease string) *infrastructurev1alpha
 	return c
 }
 
+func newRelease(name string, state releasev1alpha1.ReleaseState, components ...releasev1alpha1.ReleaseSpecComponent) *releasev1alpha1.Release {
+	return &releasev1alpha1.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: releasev1alpha1.ReleaseSpec{
+			State:      state,
+			Components: components,
+		},
+	}
+}
+
+// newReleaseService returns a release service with the given releases, and
+// active releases of the versions used in the tests.
+func newReleaseService(t *testing.T, releases ...*releasev1alpha1.Release) release.Interface {
+	clientScheme, err := scheme.NewScheme()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", microerror.Pretty(err, true))
+	}
+
+	var object []runtime.Object
+	names := map[string]bool{}
+	for _, r := range releases {
+		object = append(object, r)
+		names[r.Name] = true
+	}
+	for _, name := range []string{"v16.0.1", "v16.1.0", "v16.2.0", "aws-27.0.0", "aws-28.1.0"} {
+		if !names[name] {
+			object = append(object, newRelease(name, releasev1alpha1.StateActive))
+		}
+	}
+
+	service, err := release.New(release.Config{
+		Client: fake.NewClientBuilder().WithScheme(clientScheme).WithRuntimeObjects(object...).Build(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", microerror.Pretty(err, true))
+	}
+
+	return service
+}
+
 func newClusterService(t *testing.T, object ...runtime.Object) *cluster.Service {
 	clientScheme, err := scheme.NewScheme()
 	if err != nil {
@@ -0,0 +1,67 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
+)
+
+// getScheduledRelease returns the release version of the scheduled update
+// of the cluster, if any.
+func getScheduledRelease(resource *cluster.Cluster) (string, bool) {
+	annotations := resource.Cluster.GetAnnotations()
+	_, hasTime := annotations[annotation.UpdateScheduleTargetTime]
+	release, hasRelease := annotations[annotation.UpdateScheduleTargetRelease]
+
+	return release, hasTime || hasRelease
+}
+
+// cancelSchedule removes the annotations scheduling an update of the
+// cluster.
+func (r *runner) cancelSchedule(ctx context.Context, resource *cluster.Cluster, dryRun dryrun.Strategy) error {
+	name := resource.Cluster.GetName()
+
+	scheduledRelease, ok := getScheduledRelease(resource)
+	if !ok {
+		return microerror.Maskf(noScheduledUpdateError, "Cluster '%s' has no scheduled update to cancel.", name)
+	}
+
+	var patchSpecs []cluster.PatchSpec
+	// Removing a missing annotation would fail the whole patch, so only the
+	// ones present are removed.
+	for _, key := range []string{annotation.UpdateScheduleTargetRelease, annotation.UpdateScheduleTargetTime} {
+		if _, exists := resource.Cluster.GetAnnotations()[key]; exists {
+			patchSpecs = append(patchSpecs, cluster.PatchSpec{
+				Op:   "remove",
+				Path: fmt.Sprintf("/metadata/annotations/%s", replaceToEscape(key)),
+			})
+		}
+	}
+
+	object := resource.ClientObject()
+	original := object.DeepCopyObject()
+
+	err := r.service.Patch(ctx, object, cluster.PatchOptions{
+		DryRun:     dryRun,
+		PatchSpecs: patchSpecs,
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if dryRun.IsDryRun() {
+		err = dryrun.PrintDiff(r.stdout, fmt.Sprintf("cluster %s/%s", object.GetNamespace(), object.GetName()), original, object)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	fmt.Fprintf(r.stdout, "The scheduled upgrade of cluster %s to release %s has been cancelled%s\n", name, scheduledRelease, dryRun.Suffix())
+
+	return nil
+}
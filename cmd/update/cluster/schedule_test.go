@@ -0,0 +1,160 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
+)
+
+func Test_run_schedule(t *testing.T) {
+	testCases := []struct {
+		name           string
+		storage        []runtime.Object
+		flags          flag
+		expectedOutput string
+		// expectedRelease and expectedTime are the scheduled update
+		// annotations after the command ran. Empty values mean the
+		// annotation must not be set.
+		expectedRelease string
+		expectedTime    string
+		errorMatcher    func(error) bool
+	}{
+		{
+			name:            "cancel a scheduled update",
+			storage:         []runtime.Object{withScheduledUpdate(newCluster("abcd1", "default", ""), "16.1.0", "01 Jan 22 01:00 UTC"), newAWSCluster("abcd1", "default", "")},
+			flags:           flag{Name: "abcd1", Provider: "aws", CancelSchedule: true},
+			expectedOutput:  "The scheduled upgrade of cluster abcd1 to release 16.1.0 has been cancelled\n",
+			expectedRelease: "",
+			expectedTime:    "",
+		},
+		{
+			name:    "preview cancelling a scheduled update",
+			storage: []runtime.Object{withScheduledUpdate(newCluster("abcd1", "default", ""), "16.1.0", "01 Jan 22 01:00 UTC"), newAWSCluster("abcd1", "default", "")},
+			flags:   flag{Name: "abcd1", Provider: "aws", CancelSchedule: true, DryRun: "client"},
+			expectedOutput: `--- cluster default/abcd1 (current)
++++ cluster default/abcd1 (updated)
+@@ -2,8 +2,6 @@
+ kind: Cluster
+ metadata:
+   annotations:
+-    alpha.giantswarm.io/update-schedule-target-release: 16.1.0
+-    alpha.giantswarm.io/update-schedule-target-time: 01 Jan 22 01:00 UTC
+     cluster.giantswarm.io/description: fake-cluster
+   creationTimestamp: null
+   labels:
+The scheduled upgrade of cluster abcd1 to release 16.1.0 has been cancelled (dry run)
+`,
+			expectedRelease: "16.1.0",
+			expectedTime:    "01 Jan 22 01:00 UTC",
+		},
+		{
+			name:         "cancel without a scheduled update",
+			storage:      []runtime.Object{newCluster("abcd1", "default", ""), newAWSCluster("abcd1", "default", "")},
+			flags:        flag{Name: "abcd1", Provider: "aws", CancelSchedule: true},
+			errorMatcher: IsNoScheduledUpdate,
+		},
+		{
+			name:            "reschedule an update, keeping the release version",
+			storage:         []runtime.Object{withScheduledUpdate(newCluster("abcd1", "default", ""), "16.1.0", "01 Jan 22 01:00 UTC"), newAWSCluster("abcd1", "default", "")},
+			flags:           flag{Name: "abcd1", Provider: "aws", Reschedule: true, ScheduledTime: "2022-02-01 03:00"},
+			expectedRelease: "16.1.0",
+			expectedTime:    "01 Feb 22 03:00 UTC",
+		},
+		{
+			name:            "reschedule an update to another release version",
+			storage:         []runtime.Object{withScheduledUpdate(newCluster("abcd1", "default", ""), "16.1.0", "01 Jan 22 01:00 UTC"), newAWSCluster("abcd1", "default", "")},
+			flags:           flag{Name: "abcd1", Provider: "aws", Reschedule: true, ScheduledTime: "2022-02-01 03:00", ReleaseVersion: "16.2.0"},
+			expectedRelease: "16.2.0",
+			expectedTime:    "01 Feb 22 03:00 UTC",
+		},
+		{
+			name:         "reschedule without a scheduled update",
+			storage:      []runtime.Object{newCluster("abcd1", "default", ""), newAWSCluster("abcd1", "default", "")},
+			flags:        flag{Name: "abcd1", Provider: "aws", Reschedule: true, ScheduledTime: "2022-02-01 03:00"},
+			errorMatcher: IsNoScheduledUpdate,
+		},
+		{
+			name:         "reschedule an update without a release version",
+			storage:      []runtime.Object{withScheduledTime(newCluster("abcd1", "default", ""), "01 Jan 22 01:00 UTC"), newAWSCluster("abcd1", "default", "")},
+			flags:        flag{Name: "abcd1", Provider: "aws", Reschedule: true, ScheduledTime: "2022-02-01 03:00"},
+			errorMatcher: IsNoScheduledUpdate,
+		},
+		{
+			name:            "reschedule an update without a release version, choosing one",
+			storage:         []runtime.Object{withScheduledTime(newCluster("abcd1", "default", ""), "01 Jan 22 01:00 UTC"), newAWSCluster("abcd1", "default", "")},
+			flags:           flag{Name: "abcd1", Provider: "aws", Reschedule: true, ScheduledTime: "2022-02-01 03:00", ReleaseVersion: "16.2.0"},
+			expectedRelease: "16.2.0",
+			expectedTime:    "01 Feb 22 03:00 UTC",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			ctx := context.TODO()
+
+			flag := &tc.flags
+			flag.print = genericclioptions.NewPrintFlags("").WithDefaultOutput(output.TypeDefault)
+
+			out := new(bytes.Buffer)
+			service := newClusterService(t, tc.storage...)
+			runner := &runner{
+				commonConfig: commonconfig.New(genericclioptions.NewTestConfigFlags().WithClientConfig(kubeconfig.CreateFakeKubeConfig())),
+				flag:         flag,
+				stdout:       out,
+				service:      service,
+
+				releaseService: newReleaseService(t),
+			}
+
+			err := runner.run(ctx, nil, []string{})
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if tc.expectedOutput != "" {
+				diff := cmp.Diff(tc.expectedOutput, out.String())
+				if diff != "" {
+					t.Fatalf("value not expected, got:\n %s", diff)
+				}
+			}
+
+			c := getStoredCluster(t, service, "abcd1")
+			if c.Annotations[annotation.UpdateScheduleTargetRelease] != tc.expectedRelease {
+				t.Fatalf("expected scheduled release %q, got %q", tc.expectedRelease, c.Annotations[annotation.UpdateScheduleTargetRelease])
+			}
+			if c.Annotations[annotation.UpdateScheduleTargetTime] != tc.expectedTime {
+				t.Fatalf("expected scheduled time %q, got %q", tc.expectedTime, c.Annotations[annotation.UpdateScheduleTargetTime])
+			}
+		})
+	}
+}
+
+func withScheduledUpdate(c *capi.Cluster, targetRelease, targetTime string) *capi.Cluster {
+	c.Annotations[annotation.UpdateScheduleTargetRelease] = targetRelease
+	c.Annotations[annotation.UpdateScheduleTargetTime] = targetTime
+
+	return c
+}
+
+func withScheduledTime(c *capi.Cluster, targetTime string) *capi.Cluster {
+	c.Annotations[annotation.UpdateScheduleTargetTime] = targetTime
+
+	return c
+}
@@ -0,0 +1,178 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/giantswarm/microerror"
+	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/release"
+)
+
+// capiInfrastructureGroup is the API group of the infrastructure of CAPI
+// clusters. Vintage clusters use the same kinds in another group.
+const capiInfrastructureGroup = "infrastructure.cluster.x-k8s.io"
+
+// releaseNamePrefixes maps CAPI providers to the prefix of their release
+// names. Releases of the other providers are named after the version, with
+// a "v" prefix.
+var releaseNamePrefixes = map[string]string{
+	key.ProviderCAPA:          "aws-",
+	key.ProviderCAPZ:          "azure-",
+	key.ProviderEKS:           "eks-",
+	key.ProviderVSphere:       "vsphere-",
+	key.ProviderCloudDirector: "cloud-director-",
+}
+
+// infrastructureProviders maps the infrastructure kinds of CAPI clusters to
+// their providers, to find the releases of a cluster without --provider.
+var infrastructureProviders = map[string]string{
+	"AWSCluster":        key.ProviderCAPA,
+	"AWSManagedCluster": key.ProviderEKS,
+	"AzureCluster":      key.ProviderCAPZ,
+	"VSphereCluster":    key.ProviderVSphere,
+	"VCDCluster":        key.ProviderCloudDirector,
+}
+
+// getReleaseProvider returns the provider the releases of the cluster are
+// named after. For CAPI clusters, it is taken from the kind of their
+// infrastructure, otherwise --provider is used.
+func (r *runner) getReleaseProvider(resource *cluster.Cluster) string {
+	if resource.Cluster != nil && resource.Cluster.Spec.InfrastructureRef != nil {
+		ref := resource.Cluster.Spec.InfrastructureRef
+		provider, ok := infrastructureProviders[ref.Kind]
+		if ok && strings.HasPrefix(ref.APIVersion, capiInfrastructureGroup+"/") {
+			return provider
+		}
+	}
+
+	return r.flag.Provider
+}
+
+func getReleaseName(provider, version string) string {
+	if prefix, ok := releaseNamePrefixes[provider]; ok {
+		return prefix + version
+	}
+
+	return fmt.Sprintf("v%s", strings.TrimPrefix(version, "v"))
+}
+
+// getRelease returns the release CR of the given version for the provider,
+// or nil if it does not exist.
+func (r *runner) getRelease(ctx context.Context, provider, version string) (*releasev1alpha1.Release, error) {
+	resource, err := r.releaseService.Get(ctx, release.GetOptions{
+		Name:      getReleaseName(provider, version),
+		Namespace: metav1.NamespaceAll,
+	})
+	if release.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return resource.(*release.Release).CR, nil
+}
+
+// checkUpgradePath returns the reasons why updating from the current to the
+// target release version is not allowed. target is nil if the release does
+// not exist.
+func checkUpgradePath(currentVersion, targetVersion string, target *releasev1alpha1.Release) []string {
+	if target == nil {
+		return []string{fmt.Sprintf("release %s does not exist", targetVersion)}
+	}
+
+	var violations []string
+	if target.Spec.State != releasev1alpha1.StateActive {
+		violations = append(violations, fmt.Sprintf("release %s is not active, but %s", targetVersion, target.Spec.State))
+	}
+
+	next, err := semver.NewVersion(targetVersion)
+	if err != nil {
+		return append(violations, fmt.Sprintf("release %s is not a valid semantic version", targetVersion))
+	}
+	current, err := semver.NewVersion(currentVersion)
+	if err != nil {
+		// Without a valid current release version, e.g. if the label is
+		// missing, the upgrade path cannot be checked.
+		return violations
+	}
+
+	if next.LessThan(current) {
+		violations = append(violations, fmt.Sprintf("release %s is a downgrade from %s", targetVersion, currentVersion))
+	} else if next.Major() > current.Major()+1 {
+		violations = append(violations, fmt.Sprintf("release %s skips major version %d, update to a %d.x.x release first", targetVersion, current.Major()+1, current.Major()+1))
+	}
+
+	return violations
+}
+
+// validateUpgradePath prints which components and apps change with the
+// update, and fails if the update is not allowed, unless forced.
+func (r *runner) validateUpgradePath(ctx context.Context, provider, name, currentVersion, targetVersion string) error {
+	err := r.getReleaseService()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	target, err := r.getRelease(ctx, provider, targetVersion)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var current *releasev1alpha1.Release
+	if currentVersion != "" && currentVersion != targetVersion {
+		current, err = r.getRelease(ctx, provider, currentVersion)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if current != nil && target != nil {
+		err = r.printReleaseChanges(release.Compare(current, target))
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	violations := checkUpgradePath(currentVersion, targetVersion, target)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if r.flag.Force {
+		for _, violation := range violations {
+			fmt.Fprintf(r.stderr, "Warning: %s, updating cluster '%s' anyway, as --%s is set.\n", violation, name, flagForce)
+		}
+
+		return nil
+	}
+
+	return microerror.Maskf(invalidUpgradePathError, "Cluster '%s' cannot be updated from release %s to %s: %s. Use --%s to update anyway.", name, currentVersion, targetVersion, strings.Join(violations, "; "), flagForce)
+}
+
+// printReleaseChanges prints the components and apps changing with the
+// update, as a pre-flight report.
+func (r *runner) printReleaseChanges(d *release.Diff) error {
+	if !d.HasChanges() {
+		fmt.Fprintf(r.stdout, "No components or apps change from release %s to %s.\n\n", d.From, d.To)
+		return nil
+	}
+
+	table := d.Table(false)
+
+	printer := printers.NewTablePrinter(printers.PrintOptions{})
+	err := printer.PrintObj(table, r.stdout)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	fmt.Fprintln(r.stdout)
+
+	return nil
+}
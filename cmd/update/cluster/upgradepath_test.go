@@ -0,0 +1,212 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/cluster"
+)
+
+func Test_checkUpgradePath(t *testing.T) {
+	testCases := []struct {
+		name               string
+		currentVersion     string
+		targetVersion      string
+		target             *releasev1alpha1.Release
+		expectedViolations []string
+	}{
+		{
+			name:           "minor update",
+			currentVersion: "27.0.0",
+			targetVersion:  "27.1.0",
+			target:         newRelease("aws-27.1.0", releasev1alpha1.StateActive),
+		},
+		{
+			name:           "major update",
+			currentVersion: "27.3.0",
+			targetVersion:  "28.0.0",
+			target:         newRelease("aws-28.0.0", releasev1alpha1.StateActive),
+		},
+		{
+			name:               "release does not exist",
+			currentVersion:     "27.0.0",
+			targetVersion:      "27.10.0",
+			expectedViolations: []string{"release 27.10.0 does not exist"},
+		},
+		{
+			name:               "deprecated release",
+			currentVersion:     "27.0.0",
+			targetVersion:      "27.1.0",
+			target:             newRelease("aws-27.1.0", releasev1alpha1.StateDeprecated),
+			expectedViolations: []string{"release 27.1.0 is not active, but deprecated"},
+		},
+		{
+			name:               "downgrade",
+			currentVersion:     "27.1.0",
+			targetVersion:      "27.0.1",
+			target:             newRelease("aws-27.0.1", releasev1alpha1.StateActive),
+			expectedViolations: []string{"release 27.0.1 is a downgrade from 27.1.0"},
+		},
+		{
+			name:               "skipped major version of a wip release",
+			currentVersion:     "27.1.0",
+			targetVersion:      "29.0.0",
+			target:             newRelease("aws-29.0.0", releasev1alpha1.StateWIP),
+			expectedViolations: []string{"release 29.0.0 is not active, but wip", "release 29.0.0 skips major version 28, update to a 28.x.x release first"},
+		},
+		{
+			name:           "unknown current release version",
+			currentVersion: "",
+			targetVersion:  "27.1.0",
+			target:         newRelease("aws-27.1.0", releasev1alpha1.StateActive),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			violations := checkUpgradePath(tc.currentVersion, tc.targetVersion, tc.target)
+
+			diff := cmp.Diff(tc.expectedViolations, violations)
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func Test_getReleaseProvider(t *testing.T) {
+	testCases := []struct {
+		name             string
+		provider         string
+		cluster          *capi.Cluster
+		expectedProvider string
+	}{
+		{
+			name:             "CAPA cluster without --provider",
+			cluster:          newCAPICluster("abcd1", "27.0.0"),
+			expectedProvider: "capa",
+		},
+		{
+			name:     "CAPZ cluster with another --provider",
+			provider: "capa",
+			cluster: withInfrastructureRef(newCAPICluster("abcd1", "27.0.0"), corev1.ObjectReference{
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Kind:       "AzureCluster",
+			}),
+			expectedProvider: "capz",
+		},
+		{
+			name:     "vintage AWS cluster",
+			provider: "aws",
+			cluster: withInfrastructureRef(newCAPICluster("abcd1", "20.0.0"), corev1.ObjectReference{
+				APIVersion: "infrastructure.giantswarm.io/v1alpha3",
+				Kind:       "AWSCluster",
+			}),
+			expectedProvider: "aws",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			r := &runner{
+				flag: &flag{Provider: tc.provider},
+			}
+
+			provider := r.getReleaseProvider(&cluster.Cluster{Cluster: tc.cluster})
+			if provider != tc.expectedProvider {
+				t.Fatalf("expected provider %q, got %q", tc.expectedProvider, provider)
+			}
+		})
+	}
+}
+
+func Test_validateUpgradePath(t *testing.T) {
+	current := newRelease("aws-27.0.0", releasev1alpha1.StateActive,
+		releasev1alpha1.ReleaseSpecComponent{Name: "cluster-aws", Version: "1.3.0"},
+		releasev1alpha1.ReleaseSpecComponent{Name: "flatcar", Version: "3815.2.0"},
+		releasev1alpha1.ReleaseSpecComponent{Name: "kubernetes", Version: "1.27.14"},
+	)
+	current.Spec.Apps = []releasev1alpha1.ReleaseSpecApp{{Name: "cilium", Version: "0.24.0"}}
+	target := newRelease("aws-27.1.0", releasev1alpha1.StateDeprecated,
+		releasev1alpha1.ReleaseSpecComponent{Name: "cluster-aws", Version: "2.0.0"},
+		releasev1alpha1.ReleaseSpecComponent{Name: "flatcar", Version: "3815.2.0"},
+		releasev1alpha1.ReleaseSpecComponent{Name: "kubernetes", Version: "1.28.9"},
+	)
+	target.Spec.Apps = []releasev1alpha1.ReleaseSpecApp{{Name: "cilium", Version: "0.25.1"}, {Name: "coredns", Version: "1.21.0"}}
+
+	testCases := []struct {
+		name           string
+		force          bool
+		expectedOutput string
+		expectedStderr string
+		errorMatcher   func(error) bool
+	}{
+		{
+			name: "invalid upgrade path",
+			expectedOutput: `TYPE        NAME          AWS-27.0.0   AWS-27.1.0   CHANGE
+component   cluster-aws   1.3.0        2.0.0        upgraded (major)
+component   kubernetes    1.27.14      1.28.9       upgraded
+app         cilium        0.24.0       0.25.1       upgraded
+app         coredns       n/a          1.21.0       added
+
+`,
+			errorMatcher: IsInvalidUpgradePath,
+		},
+		{
+			name:  "forced update",
+			force: true,
+			expectedOutput: `TYPE        NAME          AWS-27.0.0   AWS-27.1.0   CHANGE
+component   cluster-aws   1.3.0        2.0.0        upgraded (major)
+component   kubernetes    1.27.14      1.28.9       upgraded
+app         cilium        0.24.0       0.25.1       upgraded
+app         coredns       n/a          1.21.0       added
+
+`,
+			expectedStderr: "Warning: release 27.1.0 is not active, but deprecated, updating cluster 'abcd1' anyway, as --force is set.\n",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			out := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			r := &runner{
+				flag:   &flag{Force: tc.force},
+				stdout: out,
+				stderr: stderr,
+
+				releaseService: newReleaseService(t, current, target),
+			}
+
+			err := r.validateUpgradePath(context.Background(), "capa", "abcd1", "27.0.0", "27.1.0")
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			diff := cmp.Diff(tc.expectedOutput, out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+			diff = cmp.Diff(tc.expectedStderr, stderr.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func withInfrastructureRef(c *capi.Cluster, ref corev1.ObjectReference) *capi.Cluster {
+	c.Spec.InfrastructureRef = &ref
+	return c
+}
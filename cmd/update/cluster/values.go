@@ -0,0 +1,127 @@
+package cluster
+
+import (
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+)
+
+// releaseVersionPath is where the cluster-<provider> charts expect the
+// release version in the user values. It is the same for all providers,
+// other paths are not supported.
+var releaseVersionPath = []string{"global", "release", "version"}
+
+// setReleaseVersion sets the release version in the given values YAML.
+//
+// Only the version scalar is rewritten in the original text, so that
+// comments, key order, indentation and quoting of the rest of the document
+// stay untouched.
+func setReleaseVersion(values, version string) (string, error) {
+	var doc yaml.Node
+	err := yaml.Unmarshal([]byte(values), &doc)
+	if err != nil {
+		return "", microerror.Maskf(invalidValuesError, "values are not valid YAML: %s", err)
+	}
+
+	node, err := lookupPath(&doc, releaseVersionPath)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	lines := strings.SplitAfter(values, "\n")
+	if node.Line < 1 || node.Line > len(lines) {
+		return "", microerror.Maskf(invalidValuesError, "cannot locate %q in the values", strings.Join(releaseVersionPath, "."))
+	}
+	line := lines[node.Line-1]
+
+	start := node.Column - 1
+	end, err := scalarEnd(line, start, node)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	var replacement string
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		replacement = `"` + version + `"`
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		replacement = `'` + version + `'`
+	default:
+		replacement = version
+	}
+
+	lines[node.Line-1] = line[:start] + replacement + line[end:]
+
+	return strings.Join(lines, ""), nil
+}
+
+// lookupPath returns the scalar node at the given path of mapping keys.
+func lookupPath(doc *yaml.Node, path []string) (*yaml.Node, error) {
+	node := doc
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil, microerror.Maskf(pathNotFoundError, "%q not found in the values", strings.Join(path, "."))
+		}
+		node = node.Content[0]
+	}
+
+	for i, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return nil, microerror.Maskf(pathNotFoundError, "%q not found in the values, %q is not a map", strings.Join(path, "."), strings.Join(path[:i], "."))
+		}
+
+		var next *yaml.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == key {
+				next = node.Content[j+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil, microerror.Maskf(pathNotFoundError, "%q not found in the values", strings.Join(path, "."))
+		}
+		node = next
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return nil, microerror.Maskf(pathNotFoundError, "%q in the values is not a scalar value", strings.Join(path, "."))
+	}
+
+	return node, nil
+}
+
+// scalarEnd returns the offset in line right after the scalar starting at
+// the given offset.
+func scalarEnd(line string, start int, node *yaml.Node) (int, error) {
+	if start < 0 || start >= len(line) {
+		return 0, microerror.Maskf(invalidValuesError, "cannot locate %q in the values", strings.Join(releaseVersionPath, "."))
+	}
+
+	var quote byte
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		quote = '"'
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		quote = '\''
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		return 0, microerror.Maskf(invalidValuesError, "%q must not be a block scalar", strings.Join(releaseVersionPath, "."))
+	default:
+		end := start + len(node.Value)
+		if end > len(line) || line[start:end] != node.Value {
+			return 0, microerror.Maskf(invalidValuesError, "cannot locate %q in the values", strings.Join(releaseVersionPath, "."))
+		}
+
+		return end, nil
+	}
+
+	if line[start] != quote {
+		return 0, microerror.Maskf(invalidValuesError, "cannot locate %q in the values", strings.Join(releaseVersionPath, "."))
+	}
+	i := strings.IndexByte(line[start+1:], quote)
+	if i < 0 {
+		return 0, microerror.Maskf(invalidValuesError, "%q must be on a single line", strings.Join(releaseVersionPath, "."))
+	}
+
+	return start + 1 + i + 1, nil
+}
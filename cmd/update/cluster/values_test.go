@@ -0,0 +1,119 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_setReleaseVersion(t *testing.T) {
+	testCases := []struct {
+		name           string
+		values         string
+		version        string
+		expectedValues string
+		errorMatcher   func(error) bool
+	}{
+		{
+			name: "only the release version is updated",
+			values: `global:
+  metadata:
+    name: test1
+    # Keep in sync with the release version.
+    description: "version: 27.0.0"
+  release:
+    version: 27.0.0 # the current release
+  apps:
+    version: 27.0.0
+`,
+			version: "28.1.0",
+			expectedValues: `global:
+  metadata:
+    name: test1
+    # Keep in sync with the release version.
+    description: "version: 27.0.0"
+  release:
+    version: 28.1.0 # the current release
+  apps:
+    version: 27.0.0
+`,
+		},
+		{
+			name: "quoting and indentation are preserved",
+			values: `global:
+    connectivity:
+        cidrBlocks:
+        - 1.2.3.4/32
+    release: {version: "27.0.0"}
+`,
+			version: "28.1.0",
+			expectedValues: `global:
+    connectivity:
+        cidrBlocks:
+        - 1.2.3.4/32
+    release: {version: "28.1.0"}
+`,
+		},
+		{
+			name: "single quoted version",
+			values: `global:
+  release:
+    version: '27.0.0'
+`,
+			version: "28.1.0",
+			expectedValues: `global:
+  release:
+    version: '28.1.0'
+`,
+		},
+		{
+			name: "release version on a different path",
+			values: `release:
+  version: 27.0.0
+`,
+			version:      "28.1.0",
+			errorMatcher: IsPathNotFound,
+		},
+		{
+			name: "release is not a map",
+			values: `global:
+  release: 27.0.0
+`,
+			version:      "28.1.0",
+			errorMatcher: IsPathNotFound,
+		},
+		{
+			name:         "empty values",
+			values:       "",
+			version:      "28.1.0",
+			errorMatcher: IsPathNotFound,
+		},
+		{
+			name:         "invalid YAML",
+			values:       "global: [",
+			version:      "28.1.0",
+			errorMatcher: IsInvalidValues,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			values, err := setReleaseVersion(tc.values, tc.version)
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			diff := cmp.Diff(tc.expectedValues, values)
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
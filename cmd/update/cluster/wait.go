@@ -0,0 +1,282 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	capiexp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/label"
+)
+
+const (
+	appStatusDeployed = "deployed"
+	appStatusFailed   = "failed"
+)
+
+// pollInterval is how often the rollout status is checked.
+var pollInterval = 15 * time.Second
+
+type replicaStatus struct {
+	Desired int64
+	Updated int64
+	Ready   int64
+}
+
+func (s replicaStatus) done() bool {
+	return s.Updated >= s.Desired && s.Ready >= s.Desired
+}
+
+func (s replicaStatus) String() string {
+	return fmt.Sprintf("%d/%d updated, %d/%d ready", s.Updated, s.Desired, s.Ready, s.Desired)
+}
+
+// rolloutStatus summarizes the progress of a release update of a CAPI
+// cluster.
+type rolloutStatus struct {
+	ReleaseVersion string
+	ReleaseApplied bool
+	// AppStatus is the release status of the cluster app. It is empty if
+	// the cluster is not managed by an app.
+	AppStatus string
+	AppDone   bool
+	// ControlPlane is nil if the control plane does not report replicas,
+	// e.g. if it is managed by the cloud provider.
+	ControlPlane *replicaStatus
+	NodePools    replicaStatus
+	Ready        string
+	// Failure is set if the rollout failed.
+	Failure string
+}
+
+func (s *rolloutStatus) done() bool {
+	if !s.ReleaseApplied || !s.AppDone || s.Ready != string(corev1.ConditionTrue) {
+		return false
+	}
+	if s.ControlPlane != nil && !s.ControlPlane.done() {
+		return false
+	}
+
+	return s.NodePools.done()
+}
+
+func (s *rolloutStatus) String() string {
+	parts := []string{
+		fmt.Sprintf("release: %s", s.ReleaseVersion),
+	}
+	if s.AppStatus != "" {
+		parts = append(parts, fmt.Sprintf("cluster app: %s", s.AppStatus))
+	}
+	if s.ControlPlane != nil {
+		parts = append(parts, fmt.Sprintf("control plane: %s", s.ControlPlane))
+	}
+	parts = append(parts, fmt.Sprintf("node pools: %s", s.NodePools))
+	parts = append(parts, fmt.Sprintf("ready: %s", s.Ready))
+
+	return strings.Join(parts, " | ")
+}
+
+// waitForRollout follows the rollout of the given release to the cluster
+// and prints a summary whenever it progresses. It fails if the rollout
+// reports a failure, makes no progress for --stall-timeout, or does not
+// finish within --timeout or the deadline of the given context.
+func (r *runner) waitForRollout(ctx context.Context, c client.Client, name, namespace, targetRelease string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.flag.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	lastProgress := start
+	var lastSummary string
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := getRolloutStatus(ctx, c, name, namespace, targetRelease)
+		if ctx.Err() != nil {
+			return microerror.Maskf(rolloutTimeoutError, "The rollout of release version '%s' to cluster '%s' did not finish within %s.", targetRelease, name, r.flag.Timeout)
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		summary := status.String()
+		if summary != lastSummary {
+			fmt.Fprintf(r.stdout, "[%s] %s\n", time.Since(start).Truncate(time.Second), summary)
+			lastSummary = summary
+			lastProgress = time.Now()
+		}
+
+		if status.Failure != "" {
+			return microerror.Maskf(rolloutFailedError, "The rollout of release version '%s' to cluster '%s' failed: %s", targetRelease, name, status.Failure)
+		}
+		if status.done() {
+			fmt.Fprintf(r.stdout, "Cluster '%s' has been rolled out to release version '%s'.\n", name, targetRelease)
+			return nil
+		}
+		if time.Since(lastProgress) >= r.flag.StallTimeout {
+			return microerror.Maskf(rolloutStalledError, "The rollout of release version '%s' to cluster '%s' made no progress for %s.", targetRelease, name, r.flag.StallTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return microerror.Maskf(rolloutTimeoutError, "The rollout of release version '%s' to cluster '%s' did not finish within %s.", targetRelease, name, r.flag.Timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+func getRolloutStatus(ctx context.Context, c client.Client, name, namespace, targetRelease string) (*rolloutStatus, error) {
+	cluster := &capi.Cluster{}
+	err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cluster)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	status := &rolloutStatus{
+		ReleaseVersion: cluster.Labels[label.ReleaseVersion],
+		Ready:          string(corev1.ConditionUnknown),
+	}
+	// The release version label is rendered by the cluster app, so it only
+	// changes once the new release has been deployed.
+	status.ReleaseApplied = status.ReleaseVersion == targetRelease
+
+	for _, condition := range cluster.Status.Conditions {
+		if condition.Type == capi.ReadyCondition {
+			status.Ready = string(condition.Status)
+			if condition.Status != corev1.ConditionTrue && condition.Reason != "" {
+				status.Ready = fmt.Sprintf("%s (%s)", condition.Status, condition.Reason)
+			}
+		}
+		if condition.Status == corev1.ConditionFalse && condition.Severity == capi.ConditionSeverityError {
+			status.Failure = fmt.Sprintf("condition %s: %s", condition.Type, condition.Message)
+		}
+	}
+	if cluster.Status.FailureMessage != nil {
+		status.Failure = *cluster.Status.FailureMessage
+	}
+
+	{
+		app := &applicationv1alpha1.App{}
+		err = c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, app)
+		if apierrors.IsNotFound(err) {
+			status.AppDone = true
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		} else {
+			status.AppStatus = app.Status.Release.Status
+			if status.AppStatus == "" {
+				status.AppStatus = "unknown"
+			}
+			status.AppDone = app.Status.Release.Status == appStatusDeployed && app.Status.Version == app.Spec.Version
+			if app.Status.Release.Status == appStatusFailed {
+				status.Failure = fmt.Sprintf("cluster app: %s", app.Status.Release.Reason)
+			}
+		}
+	}
+
+	if ref := cluster.Spec.ControlPlaneRef; ref != nil {
+		status.ControlPlane, err = getControlPlaneStatus(ctx, c, ref)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	status.NodePools, err = getNodePoolsStatus(ctx, c, name, namespace)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return status, nil
+}
+
+// getControlPlaneStatus reads the replicas of any control plane
+// implementing the CAPI contract, like KubeadmControlPlane.
+func getControlPlaneStatus(ctx context.Context, c client.Client, ref *corev1.ObjectReference) (*replicaStatus, error) {
+	controlPlane := &unstructured.Unstructured{}
+	controlPlane.SetAPIVersion(ref.APIVersion)
+	controlPlane.SetKind(ref.Kind)
+
+	err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, controlPlane)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	desired, found, err := unstructured.NestedInt64(controlPlane.Object, "spec", "replicas")
+	if err != nil || !found {
+		// Managed control planes, e.g. EKS, do not have replicas.
+		return nil, nil
+	}
+
+	status := &replicaStatus{
+		Desired: desired,
+	}
+
+	// Until the controller has observed the latest spec, the replicas
+	// describe the previous release.
+	observedGeneration, found, _ := unstructured.NestedInt64(controlPlane.Object, "status", "observedGeneration")
+	if found && observedGeneration < controlPlane.GetGeneration() {
+		return status, nil
+	}
+
+	status.Updated, _, _ = unstructured.NestedInt64(controlPlane.Object, "status", "updatedReplicas")
+	status.Ready, _, _ = unstructured.NestedInt64(controlPlane.Object, "status", "readyReplicas")
+
+	return status, nil
+}
+
+func getNodePoolsStatus(ctx context.Context, c client.Client, name, namespace string) (replicaStatus, error) {
+	var status replicaStatus
+
+	machineDeployments := &capi.MachineDeploymentList{}
+	err := c.List(ctx, machineDeployments, client.InNamespace(namespace), client.MatchingLabels{capi.ClusterNameLabel: name})
+	if err != nil {
+		return status, microerror.Mask(err)
+	}
+	for _, md := range machineDeployments.Items {
+		desired := int64(md.Status.Replicas)
+		if md.Spec.Replicas != nil {
+			desired = int64(*md.Spec.Replicas)
+		}
+		status.Desired += desired
+		// Until the controller has observed the latest spec, the replicas
+		// describe the previous release.
+		if md.Status.ObservedGeneration < md.Generation {
+			continue
+		}
+		status.Updated += int64(md.Status.UpdatedReplicas)
+		status.Ready += int64(md.Status.ReadyReplicas)
+	}
+
+	machinePools := &capiexp.MachinePoolList{}
+	err = c.List(ctx, machinePools, client.InNamespace(namespace), client.MatchingLabels{capi.ClusterNameLabel: name})
+	if err != nil {
+		return status, microerror.Mask(err)
+	}
+	for _, mp := range machinePools.Items {
+		desired := int64(mp.Status.Replicas)
+		if mp.Spec.Replicas != nil {
+			desired = int64(*mp.Spec.Replicas)
+		}
+		status.Desired += desired
+		status.Ready += int64(mp.Status.ReadyReplicas)
+		// Machine pools do not report updated replicas, as replacing the
+		// nodes is up to the infrastructure provider. They are considered
+		// updated once the latest spec has been observed and all replicas
+		// are running.
+		if mp.Status.ObservedGeneration >= mp.Generation && mp.Status.GetTypedPhase() == capiexp.MachinePoolPhaseRunning {
+			status.Updated += int64(mp.Status.ReadyReplicas)
+		}
+	}
+
+	return status, nil
+}
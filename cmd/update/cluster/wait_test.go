@@ -0,0 +1,364 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/microerror"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	capiexp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/label"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/scheme"
+	testapp "github.com/giantswarm/kubectl-gs/v5/test/app"
+)
+
+func Test_getRolloutStatus(t *testing.T) {
+	testCases := []struct {
+		name            string
+		storage         []client.Object
+		expectedSummary string
+		expectedDone    bool
+		expectedFailure string
+	}{
+		{
+			name: "rollout finished",
+			storage: []client.Object{
+				withControlPlaneRef(newCAPICluster("abcd1", "28.1.0", readyCondition(corev1.ConditionTrue, ""))),
+				newClusterApp("abcd1", "deployed"),
+				newControlPlane("abcd1", 3, 3, 3),
+				newMachineDeployment("abcd1-md", "abcd1", 3, 3, 3),
+				newMachinePool("abcd1-mp", "abcd1", 2, 2),
+			},
+			expectedSummary: "release: 28.1.0 | cluster app: deployed | control plane: 3/3 updated, 3/3 ready | node pools: 5/5 updated, 5/5 ready | ready: True",
+			expectedDone:    true,
+		},
+		{
+			name: "release not applied yet",
+			storage: []client.Object{
+				withControlPlaneRef(newCAPICluster("abcd1", "27.0.0", readyCondition(corev1.ConditionTrue, ""))),
+				newClusterApp("abcd1", "pending-upgrade"),
+				newControlPlane("abcd1", 3, 3, 3),
+				newMachineDeployment("abcd1-md", "abcd1", 3, 3, 3),
+			},
+			expectedSummary: "release: 27.0.0 | cluster app: pending-upgrade | control plane: 3/3 updated, 3/3 ready | node pools: 3/3 updated, 3/3 ready | ready: True",
+		},
+		{
+			name: "nodes rolling",
+			storage: []client.Object{
+				withControlPlaneRef(newCAPICluster("abcd1", "28.1.0", readyCondition(corev1.ConditionFalse, "RollingUpdateInProgress"))),
+				newClusterApp("abcd1", "deployed"),
+				newControlPlane("abcd1", 3, 1, 2),
+				newMachineDeployment("abcd1-md", "abcd1", 3, 0, 3),
+			},
+			expectedSummary: "release: 28.1.0 | cluster app: deployed | control plane: 1/3 updated, 2/3 ready | node pools: 0/3 updated, 3/3 ready | ready: False (RollingUpdateInProgress)",
+		},
+		{
+			name: "release applied, but the new specs not observed yet",
+			storage: []client.Object{
+				withControlPlaneRef(newCAPICluster("abcd1", "28.1.0", readyCondition(corev1.ConditionTrue, ""))),
+				newClusterApp("abcd1", "deployed"),
+				withControlPlaneGeneration(newControlPlane("abcd1", 3, 3, 3), 2, 1),
+				withMachineDeploymentGeneration(newMachineDeployment("abcd1-md", "abcd1", 3, 3, 3), 2, 1),
+			},
+			expectedSummary: "release: 28.1.0 | cluster app: deployed | control plane: 0/3 updated, 0/3 ready | node pools: 0/3 updated, 0/3 ready | ready: True",
+		},
+		{
+			name: "cluster without app and control plane",
+			storage: []client.Object{
+				newCAPICluster("abcd1", "28.1.0", readyCondition(corev1.ConditionTrue, "")),
+			},
+			expectedSummary: "release: 28.1.0 | node pools: 0/0 updated, 0/0 ready | ready: True",
+			expectedDone:    true,
+		},
+		{
+			name: "failed condition",
+			storage: []client.Object{
+				newCAPICluster("abcd1", "28.1.0", capi.Condition{
+					Type:     capi.InfrastructureReadyCondition,
+					Status:   corev1.ConditionFalse,
+					Severity: capi.ConditionSeverityError,
+					Message:  "subnet not found",
+				}),
+			},
+			expectedSummary: "release: 28.1.0 | node pools: 0/0 updated, 0/0 ready | ready: Unknown",
+			expectedFailure: "condition InfrastructureReady: subnet not found",
+		},
+		{
+			name: "failed cluster app",
+			storage: []client.Object{
+				newCAPICluster("abcd1", "27.0.0", readyCondition(corev1.ConditionTrue, "")),
+				newClusterApp("abcd1", "failed"),
+			},
+			expectedSummary: "release: 27.0.0 | cluster app: failed | node pools: 0/0 updated, 0/0 ready | ready: True",
+			expectedFailure: "cluster app: helm upgrade failed",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			c := newFakeClient(t, tc.storage...)
+
+			status, err := getRolloutStatus(context.Background(), c, "abcd1", "org-test", "28.1.0")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			diff := cmp.Diff(tc.expectedSummary, status.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+			if status.done() != tc.expectedDone {
+				t.Fatalf("expected done to be %t, got %t", tc.expectedDone, status.done())
+			}
+			if status.Failure != tc.expectedFailure {
+				t.Fatalf("expected failure %q, got %q", tc.expectedFailure, status.Failure)
+			}
+		})
+	}
+}
+
+func Test_waitForRollout(t *testing.T) {
+	testCases := []struct {
+		name           string
+		storage        []client.Object
+		timeout        time.Duration
+		stallTimeout   time.Duration
+		expectedOutput string
+		errorMatcher   func(error) bool
+	}{
+		{
+			name: "rollout finished",
+			storage: []client.Object{
+				newCAPICluster("abcd1", "28.1.0", readyCondition(corev1.ConditionTrue, "")),
+				newClusterApp("abcd1", "deployed"),
+			},
+			timeout:      time.Second,
+			stallTimeout: time.Second,
+			expectedOutput: `[0s] release: 28.1.0 | cluster app: deployed | node pools: 0/0 updated, 0/0 ready | ready: True
+Cluster 'abcd1' has been rolled out to release version '28.1.0'.
+`,
+		},
+		{
+			name: "rollout failed",
+			storage: []client.Object{
+				newCAPICluster("abcd1", "27.0.0", readyCondition(corev1.ConditionTrue, "")),
+				newClusterApp("abcd1", "failed"),
+			},
+			timeout:      time.Second,
+			stallTimeout: time.Second,
+			errorMatcher: IsRolloutFailed,
+		},
+		{
+			name: "rollout stalled",
+			storage: []client.Object{
+				newCAPICluster("abcd1", "27.0.0", readyCondition(corev1.ConditionTrue, "")),
+			},
+			timeout:      5 * time.Second,
+			stallTimeout: 50 * time.Millisecond,
+			errorMatcher: IsRolloutStalled,
+		},
+		{
+			name: "release applied, but the new node pool spec not observed yet",
+			storage: []client.Object{
+				newCAPICluster("abcd1", "28.1.0", readyCondition(corev1.ConditionTrue, "")),
+				newClusterApp("abcd1", "deployed"),
+				withMachineDeploymentGeneration(newMachineDeployment("abcd1-md", "abcd1", 3, 3, 3), 2, 1),
+			},
+			timeout:      50 * time.Millisecond,
+			stallTimeout: 5 * time.Second,
+			errorMatcher: IsRolloutTimeout,
+		},
+		{
+			name: "rollout timed out",
+			storage: []client.Object{
+				newCAPICluster("abcd1", "27.0.0", readyCondition(corev1.ConditionTrue, "")),
+			},
+			timeout:      50 * time.Millisecond,
+			stallTimeout: 5 * time.Second,
+			errorMatcher: IsRolloutTimeout,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			defer func(p time.Duration) {
+				pollInterval = p
+			}(pollInterval)
+			pollInterval = 10 * time.Millisecond
+
+			out := new(bytes.Buffer)
+			r := &runner{
+				flag:   &flag{Timeout: tc.timeout, StallTimeout: tc.stallTimeout},
+				stdout: out,
+			}
+
+			err := r.waitForRollout(context.Background(), newFakeClient(t, tc.storage...), "abcd1", "org-test", "28.1.0")
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			diff := cmp.Diff(tc.expectedOutput, out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	clientScheme, err := scheme.NewScheme()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", microerror.Pretty(err, true))
+	}
+
+	return fake.NewClientBuilder().WithScheme(clientScheme).WithObjects(objects...).Build()
+}
+
+func readyCondition(status corev1.ConditionStatus, reason string) capi.Condition {
+	return capi.Condition{
+		Type:   capi.ReadyCondition,
+		Status: status,
+		Reason: reason,
+	}
+}
+
+func newCAPICluster(name, releaseVersion string, conditions ...capi.Condition) *capi.Cluster {
+	c := &capi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "org-test",
+			Labels: map[string]string{
+				capi.ClusterNameLabel:           name,
+				label.ReleaseVersion:            releaseVersion,
+				"cluster.x-k8s.io/watch-filter": "capi",
+			},
+		},
+		Spec: capi.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+				Kind:       "AWSCluster",
+				Name:       name,
+			},
+		},
+		Status: capi.ClusterStatus{
+			Conditions: conditions,
+		},
+	}
+
+	return c
+}
+
+func withControlPlaneRef(c *capi.Cluster) *capi.Cluster {
+	c.Spec.ControlPlaneRef = &corev1.ObjectReference{
+		APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+		Kind:       "KubeadmControlPlane",
+		Name:       c.Name,
+		Namespace:  c.Namespace,
+	}
+
+	return c
+}
+
+func newClusterApp(name, status string) *applicationv1alpha1.App {
+	reason := ""
+	if status == "failed" {
+		reason = "helm upgrade failed"
+	}
+
+	return testapp.WithReleaseStatus(testapp.WithVersion(testapp.NewClusterApp(name, "org-test", ""), "2.0.0"), status, reason)
+}
+
+// newControlPlane returns a KubeadmControlPlane. It is unstructured, as the
+// type is not part of our scheme.
+func newControlPlane(clusterName string, replicas, updated, ready int64) *unstructured.Unstructured {
+	cp := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+			},
+			"status": map[string]interface{}{
+				"updatedReplicas": updated,
+				"readyReplicas":   ready,
+			},
+		},
+	}
+	cp.SetAPIVersion("controlplane.cluster.x-k8s.io/v1beta1")
+	cp.SetKind("KubeadmControlPlane")
+	cp.SetName(clusterName)
+	cp.SetNamespace("org-test")
+
+	return cp
+}
+
+func withControlPlaneGeneration(cp *unstructured.Unstructured, generation, observedGeneration int64) *unstructured.Unstructured {
+	cp.SetGeneration(generation)
+	_ = unstructured.SetNestedField(cp.Object, observedGeneration, "status", "observedGeneration")
+
+	return cp
+}
+
+func withMachineDeploymentGeneration(md *capi.MachineDeployment, generation, observedGeneration int64) *capi.MachineDeployment {
+	md.Generation = generation
+	md.Status.ObservedGeneration = observedGeneration
+
+	return md
+}
+
+func newMachineDeployment(name, clusterName string, replicas, updated, ready int32) *capi.MachineDeployment {
+	return &capi.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "org-test",
+			Labels: map[string]string{
+				capi.ClusterNameLabel: clusterName,
+			},
+		},
+		Spec: capi.MachineDeploymentSpec{
+			ClusterName: clusterName,
+			Replicas:    &replicas,
+		},
+		Status: capi.MachineDeploymentStatus{
+			Replicas:        replicas,
+			UpdatedReplicas: updated,
+			ReadyReplicas:   ready,
+		},
+	}
+}
+
+func newMachinePool(name, clusterName string, replicas, ready int32) *capiexp.MachinePool {
+	return &capiexp.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "org-test",
+			Labels: map[string]string{
+				capi.ClusterNameLabel: clusterName,
+			},
+		},
+		Spec: capiexp.MachinePoolSpec{
+			ClusterName: clusterName,
+			Replicas:    &replicas,
+		},
+		Status: capiexp.MachinePoolStatus{
+			Replicas:      replicas,
+			ReadyReplicas: ready,
+			Phase:         string(capiexp.MachinePoolPhaseRunning),
+		},
+	}
+}
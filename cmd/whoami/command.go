@@ -0,0 +1,92 @@
+package whoami
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+)
+
+const (
+	name = "whoami"
+
+	shortDescription = "Show the user of the current context"
+	longDescription  = `Show the user of the current context
+
+Shows the user name, groups, issuer and expiry of the credentials used
+with the current context, or the context given with --context.
+
+The ID token of OIDC contexts created by 'kubectl gs login' is decoded
+locally, without verifying its signature. This works for contexts using
+the oidc auth provider as well as for those using the credential plugin
+(--exec-credential). The same applies to a token given with --token.
+
+For any other credentials, like client certificates, the user is looked
+up with a SelfSubjectReview, as seen by the API server.
+
+Use --output json or --output yaml for further processing.`
+
+	examples = `  # Show the user of the current context
+  kubectl gs whoami
+
+  # Show the user of another context
+  kubectl gs whoami --context gs-mymc
+
+  # Print the groups of the user as JSON
+  kubectl gs whoami --output json`
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	ConfigFlags *genericclioptions.RESTClientGetter
+
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.ConfigFlags == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ConfigFlags must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		commonConfig: &commonconfig.CommonConfig{
+			ConfigFlags: config.ConfigFlags,
+		},
+		flag:   f,
+		logger: config.Logger,
+
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     name,
+		Short:   shortDescription,
+		Long:    longDescription,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE:    r.Run,
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
@@ -0,0 +1,31 @@
+package whoami
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+)
+
+type flag struct {
+	print *genericclioptions.PrintFlags
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	f.print = genericclioptions.NewPrintFlags("")
+
+	// Merging current command flags and config flags,
+	// to be able to override kubectl-specific ones.
+	f.print.AddFlags(cmd)
+}
+
+func (f *flag) Validate() error {
+	switch *f.print.OutputFormat {
+	case output.TypeDefault, output.TypeJSON, output.TypeYAML:
+	default:
+		return microerror.Maskf(invalidFlagError, "--output must be %q or %q", output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
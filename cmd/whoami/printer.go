@@ -0,0 +1,84 @@
+package whoami
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+)
+
+const (
+	naValue = "n/a"
+)
+
+func (r *runner) printOutput(id *identity) error {
+	switch *r.flag.print.OutputFormat {
+	case output.TypeJSON:
+		data, err := json.MarshalIndent(id, "", "    ")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprintln(r.stdout, string(data))
+
+	case output.TypeYAML:
+		data, err := yaml.Marshal(id)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		fmt.Fprint(r.stdout, string(data))
+
+	case output.TypeDefault:
+		groups := naValue
+		if len(id.Groups) > 0 {
+			groups = strings.Join(id.Groups, ", ")
+		}
+
+		w := tabwriter.NewWriter(r.stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintf(w, "Context:\t%s\n", id.Context)
+		fmt.Fprintf(w, "Source:\t%s\n", id.Source)
+		fmt.Fprintf(w, "Username:\t%s\n", valueOrNA(id.Username))
+		fmt.Fprintf(w, "Email:\t%s\n", valueOrNA(id.Email))
+		fmt.Fprintf(w, "Groups:\t%s\n", groups)
+		fmt.Fprintf(w, "Issuer:\t%s\n", valueOrNA(id.Issuer))
+		fmt.Fprintf(w, "Expiry:\t%s\n", formatExpiry(id.Expiry, time.Now()))
+		err := w.Flush()
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+	default:
+		return microerror.Maskf(invalidFlagError, "output format %q is not supported, use %q or %q", *r.flag.print.OutputFormat, output.TypeJSON, output.TypeYAML)
+	}
+
+	return nil
+}
+
+// formatExpiry returns the expiry, and whether it has passed.
+func formatExpiry(expiry *metav1.Time, now time.Time) string {
+	if expiry == nil {
+		return naValue
+	}
+
+	formatted := expiry.UTC().Format(time.RFC3339)
+	if !expiry.After(now) {
+		return fmt.Sprintf("%s (expired)", formatted)
+	}
+
+	return fmt.Sprintf("%s (expires in %s)", formatted, duration.HumanDuration(expiry.Sub(now)))
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return naValue
+	}
+
+	return value
+}
@@ -0,0 +1,211 @@
+package whoami
+
+import (
+	"context"
+	"io"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/oidc"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/tokencache"
+)
+
+const (
+	sourceIDToken           = "ID token"
+	sourceSelfSubjectReview = "SelfSubjectReview"
+
+	// Keys of the config of the oidc auth provider.
+	idpIssuerURLKey = "idp-issuer-url"
+	idTokenKey      = "id-token"
+)
+
+// identity is the user of a context.
+type identity struct {
+	Context  string       `json:"context"`
+	Source   string       `json:"source"`
+	Username string       `json:"username"`
+	Email    string       `json:"email,omitempty"`
+	Groups   []string     `json:"groups,omitempty"`
+	Issuer   string       `json:"issuer,omitempty"`
+	Expiry   *metav1.Time `json:"expiry,omitempty"`
+}
+
+type runner struct {
+	commonConfig *commonconfig.CommonConfig
+	flag         *flag
+	logger       micrologger.Logger
+
+	// client is used for the SelfSubjectReview.
+	client k8sclient.Interface
+	// cacheDir holds the tokens of the credential plugin.
+	cacheDir string
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	config, err := r.commonConfig.GetConfigAccess().GetStartingConfig()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	contextName := r.commonConfig.GetContextOverride()
+	if contextName == "" {
+		contextName = config.CurrentContext
+	}
+	if _, ok := config.Contexts[contextName]; !ok {
+		return microerror.Maskf(contextNotFoundError, "The context '%s' cannot be found in the kubeconfig.", contextName)
+	}
+
+	id, err := r.getIdentityFromIDToken(config, contextName)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if id == nil {
+		id, err = r.getIdentityFromSelfSubjectReview(ctx)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+	id.Context = contextName
+
+	err = r.printOutput(id)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// getIdentityFromIDToken decodes the ID token used with the context, if
+// there is one.
+func (r *runner) getIdentityFromIDToken(config *clientcmdapi.Config, contextName string) (*identity, error) {
+	var rawIDToken, issuer string
+
+	if token := r.commonConfig.GetTokenOverride(); token != "" {
+		rawIDToken = token
+	} else if authProvider, ok := kubeconfig.GetAuthProvider(config, contextName); ok {
+		rawIDToken = authProvider.Config[idTokenKey]
+		issuer = authProvider.Config[idpIssuerURLKey]
+	} else if exec, ok := kubeconfig.GetExecConfig(config, contextName); ok {
+		var clientID string
+		issuer, clientID, ok = kubeconfig.GetCredentialExecParams(exec)
+		if !ok {
+			return nil, nil
+		}
+
+		if r.cacheDir == "" {
+			var err error
+			r.cacheDir, err = key.GetCacheDir()
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+		}
+
+		entry, err := tokencache.Load(r.cacheDir, issuer, clientID)
+		if tokencache.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		rawIDToken = entry.IDToken
+	}
+
+	if rawIDToken == "" {
+		return nil, nil
+	}
+
+	claims, err := oidc.ParseIDTokenClaims(rawIDToken)
+	if err != nil {
+		// Not a JWT, e.g. a service account token of older clusters.
+		return nil, nil
+	}
+
+	id := &identity{
+		Source:   sourceIDToken,
+		Username: getUsername(claims),
+		Email:    claims.Email,
+		Groups:   claims.Groups,
+		Issuer:   claims.Issuer,
+	}
+	if id.Issuer == "" {
+		id.Issuer = issuer
+	}
+	if !claims.Expiry.IsZero() {
+		expiry := metav1.NewTime(claims.Expiry.UTC())
+		id.Expiry = &expiry
+	}
+
+	return id, nil
+}
+
+// getIdentityFromSelfSubjectReview asks the API server who the user is.
+func (r *runner) getIdentityFromSelfSubjectReview(ctx context.Context) (*identity, error) {
+	err := r.getClient()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	review, err := r.client.K8sClient().AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return &identity{
+		Source:   sourceSelfSubjectReview,
+		Username: review.Status.UserInfo.Username,
+		Groups:   review.Status.UserInfo.Groups,
+	}, nil
+}
+
+func (r *runner) getClient() error {
+	if r.client != nil {
+		return nil
+	}
+
+	var err error
+	r.client, err = r.commonConfig.GetClient(r.logger)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// getUsername returns the most readable claim identifying the user.
+func getUsername(claims oidc.IDTokenClaims) string {
+	for _, name := range []string{claims.Email, claims.PreferredUsername, claims.Name} {
+		if name != "" {
+			return name
+		}
+	}
+
+	return claims.Subject
+}
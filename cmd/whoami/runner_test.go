@@ -0,0 +1,242 @@
+package whoami
+
+import (
+	"bytes"
+	"context"
+	goflag "flag"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/go-cmp/cmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/ptr"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/output"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/tokencache"
+	"github.com/giantswarm/kubectl-gs/v5/test/goldenfile"
+	"github.com/giantswarm/kubectl-gs/v5/test/kubeclient"
+)
+
+var update = goflag.Bool("update", false, "update .golden reference test files")
+
+const (
+	issuer   = "https://dex.test.example.com"
+	clientID = "dex-k8s-authenticator"
+)
+
+// Test_run uses golden files.
+//
+// go test ./cmd/whoami -run Test_run -update
+func Test_run(t *testing.T) {
+	// The tokens have expired, so that the output does not depend on the
+	// time the test runs.
+	expiry := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	idToken := newIDToken(t, jwt.MapClaims{
+		"iss":    issuer,
+		"sub":    "CgVqZG9lEgNnaXQ",
+		"aud":    clientID,
+		"email":  "jane.doe@example.com",
+		"groups": []string{"acme:admins", "acme:developers"},
+		"exp":    expiry.Unix(),
+	})
+	execIDToken := newIDToken(t, jwt.MapClaims{
+		"iss":                issuer,
+		"sub":                "CgVqZG9lEgNnaXQ",
+		"aud":                clientID,
+		"preferred_username": "jdoe",
+		"groups":             "acme:admins",
+		"exp":                expiry.Add(time.Hour).Unix(),
+	})
+
+	testCases := []struct {
+		name               string
+		context            string
+		token              string
+		outputType         string
+		expectedGoldenFile string
+		errorMatcher       func(error) bool
+	}{
+		{
+			name:               "case 0: oidc auth provider context",
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_whoami_auth_provider.golden",
+		},
+		{
+			name:               "case 1: credential plugin context",
+			context:            "gs-exec",
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_whoami_exec.golden",
+		},
+		{
+			name:               "case 2: client certificate context, using a SelfSubjectReview",
+			context:            "gs-mymc-mywc-clientcert",
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_whoami_self_subject_review.golden",
+		},
+		{
+			name:               "case 3: token given with --token",
+			context:            "gs-mymc-mywc-clientcert",
+			token:              execIDToken,
+			outputType:         output.TypeDefault,
+			expectedGoldenFile: "run_whoami_token.golden",
+		},
+		{
+			name:               "case 4: oidc auth provider context, with json output",
+			outputType:         output.TypeJSON,
+			expectedGoldenFile: "run_whoami_json_output.golden",
+		},
+		{
+			name:         "case 5: context which does not exist",
+			context:      "gs-unknown",
+			outputType:   output.TypeDefault,
+			errorMatcher: IsContextNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheDir := t.TempDir()
+			err := tokencache.Persist(cacheDir, issuer, clientID, tokencache.Entry{
+				IDToken:      execIDToken,
+				RefreshToken: "refresh-token",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			cf := genericclioptions.NewConfigFlags(true)
+			cf.KubeConfig = ptr.To[string](filepath.Join(t.TempDir(), "config.yaml"))
+			if tc.context != "" {
+				cf.Context = ptr.To[string](tc.context)
+			}
+			if tc.token != "" {
+				cf.BearerToken = ptr.To[string](tc.token)
+			}
+			err = clientcmd.WriteToFile(*newConfig(idToken), *cf.KubeConfig)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			client := kubeclient.FakeK8sClient()
+			client.AddSelfSubjectReview(authenticationv1.UserInfo{
+				Username: "jane.doe@example.com",
+				Groups:   []string{"acme:admins", "system:authenticated"},
+			})
+
+			flag := &flag{
+				print: genericclioptions.NewPrintFlags("").WithDefaultOutput(tc.outputType),
+			}
+
+			out := new(bytes.Buffer)
+			runner := &runner{
+				commonConfig: commonconfig.New(cf),
+				flag:         flag,
+				client:       client,
+				cacheDir:     cacheDir,
+				stdout:       out,
+				stderr:       new(bytes.Buffer),
+			}
+
+			err = runner.run(context.TODO(), nil, nil)
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			var expectedResult []byte
+			{
+				gf := goldenfile.New("testdata", tc.expectedGoldenFile)
+				if *update {
+					err = gf.Update(out.Bytes())
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+					expectedResult = out.Bytes()
+				} else {
+					expectedResult, err = gf.Read()
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err.Error())
+					}
+				}
+			}
+
+			diff := cmp.Diff(string(expectedResult), out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func Test_formatExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	future := ptr.To(metav1.NewTime(now.Add(90 * time.Minute)))
+	past := ptr.To(metav1.NewTime(now.Add(-time.Minute)))
+
+	if got := formatExpiry(future, now); got != "2026-01-02T16:34:05Z (expires in 90m)" {
+		t.Fatalf("unexpected expiry: %s", got)
+	}
+	if got := formatExpiry(past, now); got != "2026-01-02T15:03:05Z (expired)" {
+		t.Fatalf("unexpected expiry: %s", got)
+	}
+	if got := formatExpiry(nil, now); got != naValue {
+		t.Fatalf("unexpected expiry: %s", got)
+	}
+}
+
+func newConfig(idToken string) *clientcmdapi.Config {
+	return &clientcmdapi.Config{
+		CurrentContext: "gs-mymc",
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"gs-mymc":      {Server: "https://api.mymc.example.com"},
+			"gs-mymc-mywc": {Server: "https://api.mywc.example.com"},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"gs-user-mymc": {
+				AuthProvider: &clientcmdapi.AuthProviderConfig{
+					Name: "oidc",
+					Config: map[string]string{
+						"client-id":      clientID,
+						"id-token":       idToken,
+						"idp-issuer-url": issuer,
+						"refresh-token":  "refresh-token",
+					},
+				},
+			},
+			"gs-user-exec": {
+				Exec: kubeconfig.NewCredentialExecConfig(issuer, clientID),
+			},
+			"gs-mymc-mywc-user": {
+				ClientCertificateData: []byte("certificate"),
+				ClientKeyData:         []byte("key"),
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"gs-mymc":                 {Cluster: "gs-mymc", AuthInfo: "gs-user-mymc"},
+			"gs-exec":                 {Cluster: "gs-mymc", AuthInfo: "gs-user-exec"},
+			"gs-mymc-mywc-clientcert": {Cluster: "gs-mymc-mywc", AuthInfo: "gs-mymc-mywc-user"},
+		},
+	}
+}
+
+func newIDToken(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	return token
+}
@@ -0,0 +1,7 @@
+package annotation
+
+const (
+	// AppVersionHistory lists the versions an app has been updated to with
+	// kubectl gs update app, oldest first, separated by commas.
+	AppVersionHistory = "kubectl-gs.giantswarm.io/version-history"
+)
@@ -5,6 +5,8 @@ import (
 
 	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
 	"github.com/xeipuuv/gojsonschema"
+
+	catalogdata "github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/catalog"
 )
 
 type ValidateOptions struct {
@@ -41,9 +43,18 @@ type ValidationResult struct {
 	Err error
 }
 
+// UserValues are the values of the user config of an app, replacing the
+// ones of its ConfigMap or Secret when validating the app.
+type UserValues struct {
+	// Secret is true if the values are the ones of the user config Secret,
+	// rather than of the ConfigMap.
+	Secret bool
+	Values map[string]interface{}
+}
+
 type CatalogFetchResult struct {
 	catalog *applicationv1alpha1.Catalog
-	index   *IndexFile
+	index   *catalogdata.IndexFile
 
 	err error
 }
@@ -53,26 +64,11 @@ type SchemaFetchResult struct {
 	err    error
 }
 
-type IndexFile struct {
-	APIVersion string                   `yaml:"apiVersion"`
-	Entries    map[string]ChartVersions `yaml:"entries"`
-}
-
-// ChartVersions is a list of versioned chart references.
-type ChartVersions []*ChartVersion
-
-// ChartVersion represents a chart entry in the IndexFile
-type ChartVersion struct {
-	Name        string            `json:"name,omitempty"`
-	Version     string            `json:"version,omitempty"`
-	Annotations map[string]string `json:"annotations,omitempty"`
-	URLs        []string          `json:"urls"`
-}
-
 // Interface represents the contract for the apps service.
 // Using this instead of a regular 'struct' makes mocking the
 // service in tests much simpler.
 type Interface interface {
 	Validate(context.Context, ValidateOptions) (ValidationResults, error)
 	ValidateApp(context.Context, *applicationv1alpha1.App, string, map[string]interface{}) (string, *gojsonschema.Result, error)
+	ValidateAppUserValues(context.Context, *applicationv1alpha1.App, UserValues) (string, *gojsonschema.Result, error)
 }
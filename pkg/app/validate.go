@@ -147,6 +147,18 @@ func (s *Service) validateMultiple(ctx context.Context, namespace string, labelS
 }
 
 func (s *Service) ValidateApp(ctx context.Context, app *applicationv1alpha1.App, customValuesSchema string, yamlData map[string]interface{}) (string, *gojsonschema.Result, error) {
+	return s.validateApp(ctx, app, customValuesSchema, yamlData, nil)
+}
+
+// ValidateAppUserValues validates the values an app would have with the
+// given user values in place of the ones of its user config ConfigMap or
+// Secret, merged with the chart values and the catalog and cluster config
+// like any other values of the app.
+func (s *Service) ValidateAppUserValues(ctx context.Context, app *applicationv1alpha1.App, userValues UserValues) (string, *gojsonschema.Result, error) {
+	return s.validateApp(ctx, app, "", nil, &userValues)
+}
+
+func (s *Service) validateApp(ctx context.Context, app *applicationv1alpha1.App, customValuesSchema string, yamlData map[string]interface{}, userValues *UserValues) (string, *gojsonschema.Result, error) {
 	catalogName := app.Spec.Catalog
 	catalogNamespace := app.Spec.CatalogNamespace
 
@@ -165,7 +177,7 @@ func (s *Service) ValidateApp(ctx context.Context, app *applicationv1alpha1.App,
 		// Q: Why not just check for schema.values.json in the tarball?
 		// A: For now trying to stick to the spec as we made it. Didn't foresee
 		// that I'd be downloading the Tarball anyways.
-		valuesSchema, err = s.fetchValuesSchema(index.Entries[app.Spec.Name], app.Spec.Version)
+		valuesSchema, err = s.fetchValuesSchema(ctx, index.Entries[app.Spec.Name], app.Spec.Version)
 		if err != nil {
 			return "", nil, microerror.Mask(err)
 		}
@@ -205,13 +217,13 @@ func (s *Service) ValidateApp(ctx context.Context, app *applicationv1alpha1.App,
 		// 2. Catalog values (configmap & secret)
 		// 3. Cluster values (configmap & secret)
 		// 4. User values (configmap & secret)
-		providedValues, err := s.ValuesService.MergeAll(ctx, *app, *catalog)
+		providedValues, err := s.mergeProvidedValues(ctx, app, catalog, userValues)
 		if err != nil {
 			return "", nil, microerror.Maskf(ioError, "failed fetch and/or merge user provided values: %s", err.Error())
 		}
 
 		// Finally, merge the user & admin provided values with the chart values.
-		yamlData = mergeValues(chartValues, providedValues)
+		yamlData = MergeValues(chartValues, providedValues)
 	}
 
 	// Validate the merged values against the schema using gojsonschema.
@@ -223,7 +235,75 @@ func (s *Service) ValidateApp(ctx context.Context, app *applicationv1alpha1.App,
 	return valuesSchema, result, nil
 }
 
-func (s *Service) fetchValuesSchema(entries ChartVersions, version string) (string, error) {
+// mergeProvidedValues merges the catalog, cluster and user config of the
+// app. Given user values replace the ones of the user config ConfigMap or
+// Secret. They are merged after the extra configs of the app though, even
+// the ones with a priority above the user config.
+func (s *Service) mergeProvidedValues(ctx context.Context, app *applicationv1alpha1.App, catalog *applicationv1alpha1.Catalog, userValues *UserValues) (map[string]interface{}, error) {
+	if userValues == nil {
+		values, err := s.ValuesService.MergeAll(ctx, *app, *catalog)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		return values, nil
+	}
+
+	withoutUserConfig := app.DeepCopy()
+	if userValues.Secret {
+		withoutUserConfig.Spec.UserConfig.Secret = applicationv1alpha1.AppSpecUserConfigSecret{}
+	} else {
+		withoutUserConfig.Spec.UserConfig.ConfigMap = applicationv1alpha1.AppSpecUserConfigConfigMap{}
+	}
+
+	configMapValues, err := s.ValuesService.MergeConfigMapData(ctx, *withoutUserConfig, *catalog)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	secretValues, err := s.ValuesService.MergeSecretData(ctx, *withoutUserConfig, *catalog)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// The values are copied, as merging modifies the nested maps.
+	values, err := copyValues(userValues.Values)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if userValues.Secret {
+		secretValues = MergeValues(nonNilValues(secretValues), values)
+	} else {
+		configMapValues = MergeValues(nonNilValues(configMapValues), values)
+	}
+
+	return MergeValues(nonNilValues(configMapValues), secretValues), nil
+}
+
+func copyValues(values map[string]interface{}) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	copied := map[string]interface{}{}
+	err = yaml.Unmarshal(data, &copied)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return copied, nil
+}
+
+func nonNilValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return map[string]interface{}{}
+	}
+
+	return values
+}
+
+func (s *Service) fetchValuesSchema(ctx context.Context, entries catalogdata.ChartVersions, version string) (string, error) {
 	valuesSchemaURL := findValuesSchemaURL(entries, version)
 
 	// Don't try to fetch something that isn't defined.
@@ -239,7 +319,16 @@ func (s *Service) fetchValuesSchema(entries ChartVersions, version string) (stri
 	// Fetch the values.schema.json file.
 	// nosec is applied here because this is not a web server. The only thing we do
 	// with this response is attempt to unmarshal it into a jsonschema.Schema.
-	resp, err := http.Get(valuesSchemaURL) // #nosec
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, valuesSchemaURL, nil) // #nosec
+	if err != nil {
+		err = microerror.Maskf(fetchError, "unable to fetch values.schema.json, invalid URL: %s", err.Error())
+		s.SchemaFetchResults[valuesSchemaURL] = SchemaFetchResult{
+			err: err,
+		}
+		return "", err
+	}
+
+	resp, err := catalogdata.HTTPClient.Do(req)
 	if err != nil {
 		err = microerror.Maskf(fetchError, "unable to fetch values.schema.json, http error: %s", err.Error())
 		s.SchemaFetchResults[valuesSchemaURL] = SchemaFetchResult{
@@ -266,7 +355,7 @@ func (s *Service) fetchValuesSchema(entries ChartVersions, version string) (stri
 	return string(body), nil
 }
 
-func (s *Service) fetchCatalogIndex(ctx context.Context, catalogName, catalogNamespace string) (*IndexFile, *applicationv1alpha1.Catalog, error) {
+func (s *Service) fetchCatalogIndex(ctx context.Context, catalogName, catalogNamespace string) (*catalogdata.IndexFile, *applicationv1alpha1.Catalog, error) {
 	var err error
 
 	// Don't try to fetch something that is undefined.
@@ -304,70 +393,8 @@ func (s *Service) fetchCatalogIndex(ctx context.Context, catalogName, catalogNam
 		return nil, nil, microerror.Maskf(invalidTypeError, "unexpected type %T found", c)
 	}
 
-	// Pick a repository with type="helm", since we don't know how to fetch
-	// indexes from other storage types yet.
-	var catalogURL string
-	var foundHelmRepository bool
-	for _, repo := range catalog.Spec.Repositories {
-		if repo.Type == "helm" {
-			foundHelmRepository = true
-			catalogURL = repo.URL
-			break
-		}
-	}
-	// Legacy: use deprecated .spec.storage in case .spec.repositories is empty.
-	if catalogURL == "" && !foundHelmRepository && catalog.Spec.Storage.Type == "helm" {
-		catalogURL = catalog.Spec.Storage.URL
-		foundHelmRepository = true
-	}
-	// Error for catalogs where we for sure can't fetch the index because we don't
-	// know about the storage type yet.
-	if !foundHelmRepository {
-		err = microerror.Maskf(fetchError, "unable to fetch index, only \"helm\" storage type is supported")
-		s.CatalogFetchResults[catalogName] = CatalogFetchResult{
-			err: err,
-		}
-
-		return nil, nil, err
-	}
-
-	// Error for catalogs where we for sure can't fetch the index because the
-	// URL is missing in the Catalog CR.
-	if catalogURL == "" {
-		err = microerror.Maskf(fetchError, "unable to fetch index, the URL for the helm repo's index.yaml is missing from 'Spec.Repositories[].URL' in the Catalog CR")
-		s.CatalogFetchResults[catalogName] = CatalogFetchResult{
-			err: err,
-		}
-
-		return nil, nil, err
-	}
-
-	// Fetch the index.
-	resp, err := http.Get(catalogURL + "/index.yaml")
-	if err != nil {
-		err = microerror.Maskf(fetchError, "unable to fetch index, http request failed: %s", err.Error())
-		s.CatalogFetchResults[catalogName] = CatalogFetchResult{
-			err: err,
-		}
-
-		return nil, nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		err = microerror.Maskf(fetchError, "unable to fetch index, error processing http response body: %s", err.Error())
-		s.CatalogFetchResults[catalogName] = CatalogFetchResult{
-			err: err,
-		}
-
-		return nil, nil, err
-	}
-
-	index := &IndexFile{}
-	err = yaml.Unmarshal(body, index)
+	index, err := catalogdata.FetchIndex(ctx, catalog)
 	if err != nil {
-		err = microerror.Maskf(fetchError, "unable to fetch index, error unmarshalling body: %s", err.Error())
 		s.CatalogFetchResults[catalogName] = CatalogFetchResult{
 			err: err,
 		}
@@ -399,7 +426,7 @@ func ValidateSchema(valuesSchema string, yamlData map[string]interface{}) (*gojs
 	return result, nil
 }
 
-func findValuesSchemaURL(entries ChartVersions, version string) string {
+func findValuesSchemaURL(entries catalogdata.ChartVersions, version string) string {
 	for _, entry := range entries {
 		_, hasValuesSchema := entry.Annotations[valuesSchemaAnnotationKey]
 
@@ -412,7 +439,7 @@ func findValuesSchemaURL(entries ChartVersions, version string) string {
 	return ""
 }
 
-func findTarballURL(entries ChartVersions, version string) string {
+func findTarballURL(entries catalogdata.ChartVersions, version string) string {
 	for _, entry := range entries {
 		if entry.Version == version {
 			return entry.URLs[0]
@@ -422,12 +449,12 @@ func findTarballURL(entries ChartVersions, version string) string {
 	return ""
 }
 
-// mergeValues implements the merge logic. It performs a deep merge. If a value
+// MergeValues implements the merge logic. It performs a deep merge. If a value
 // is present in both then the source map is preferred.
 //
 // Logic is based on the upstream logic implemented by Helm.
 // https://github.com/helm/helm/blob/240e539cec44e2b746b3541529d41f4ba01e77df/cmd/helm/install.go#L358
-func mergeValues(dest, src map[string]interface{}) map[string]interface{} {
+func MergeValues(dest, src map[string]interface{}) map[string]interface{} {
 	for k, v := range src {
 		if _, exists := dest[k]; !exists {
 			// If the key doesn't exist already. Set the key to that value.
@@ -451,7 +478,7 @@ func mergeValues(dest, src map[string]interface{}) map[string]interface{} {
 		}
 
 		// If we got to this point. It is a map in both so merge them.
-		dest[k] = mergeValues(destMap, nextMap)
+		dest[k] = MergeValues(destMap, nextMap)
 	}
 
 	return dest
@@ -0,0 +1,180 @@
+package certificate
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/clientcert"
+)
+
+const (
+	KeyCertCRT = "crt"
+	KeyCertKey = "key"
+	KeyCertCA  = "ca"
+)
+
+// Config holds the parameters used to sign a workload cluster
+// client certificate with the cluster's CA, or to request one from
+// cert-operator.
+type Config struct {
+	ClusterName      string
+	ClusterNamespace string
+	ClusterBasePath  string
+	Groups           []string
+	TTL              string
+	CNPrefix         string
+
+	// The fields below are only used for certificates issued by cert-operator.
+	CertOperatorVersion string
+	Organization        string
+	Provider            string
+}
+
+// Issue fetches the CA of the workload cluster from the management cluster
+// and uses it to sign a new client certificate.
+func Issue(ctx context.Context, clientCertService clientcert.Interface, config Config) (*corev1.Secret, error) {
+	ca, err := clientCertService.GetCredential(ctx, config.ClusterNamespace, config.ClusterName+"-ca")
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	secret, err := Generate(ca, config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return secret, nil
+}
+
+// Generate signs a new client certificate with the CA contained in the given secret.
+func Generate(ca *corev1.Secret, config Config) (*corev1.Secret, error) {
+	var caPEM, certPEM, keyPEM []byte
+	{
+		// Get the WCs CA data
+		caPEM = ca.Data["tls.crt"]
+		caCert, err := DecodeCertificatePEM(caPEM)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		caPrivKey, err := DecodePrivateKeyPEM(ca.Data["tls.key"])
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		// Create the Certificate
+		exp, err := time.ParseDuration(config.TTL)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+		if err != nil {
+			return nil, microerror.Maskf(invalidConfigError, "Failed to generate client certificate serial number.")
+		}
+		var clientCertCNPrefix string
+		{
+			if config.CNPrefix != "" {
+				clientCertCNPrefix = config.CNPrefix
+			} else {
+				clientCertCNPrefix = serial.String()
+			}
+		}
+		certificate := &x509.Certificate{
+			SerialNumber:       serial,
+			SignatureAlgorithm: x509.SHA256WithRSA,
+			Subject: pkix.Name{
+				Organization: config.Groups,
+				CommonName:   fmt.Sprintf("%s.%s.%s", clientCertCNPrefix, config.ClusterName, config.ClusterBasePath),
+			},
+			NotBefore:   time.Now(),
+			NotAfter:    time.Now().Add(exp),
+			KeyUsage:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement,
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		}
+
+		// Create the key
+		certPrivKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		keyPEM = EncodePrivateKeyPEM(certPrivKey)
+
+		// Sign the certificate
+		certBytes, err := x509.CreateCertificate(rand.Reader, certificate, caCert, &certPrivKey.PublicKey, caPrivKey)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		certPEM = EncodeCertificatePEM(certBytes)
+	}
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			KeyCertCA:  caPEM,
+			KeyCertCRT: certPEM,
+			KeyCertKey: keyPEM,
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+	return secret, nil
+}
+
+// GetExpiry returns the expiration time of a PEM encoded certificate.
+func GetExpiry(certPEM []byte) (time.Time, error) {
+	cert, err := DecodeCertificatePEM(certPEM)
+	if err != nil {
+		return time.Time{}, microerror.Mask(err)
+	}
+
+	return cert.NotAfter, nil
+}
+
+func EncodeCertificatePEM(cert []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: cert,
+	})
+}
+
+func DecodeCertificatePEM(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, microerror.Maskf(invalidCertificateError, "no PEM encoded certificate found")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, microerror.Maskf(invalidCertificateError, "%s", err.Error())
+	}
+
+	return cert, nil
+}
+
+func EncodePrivateKeyPEM(key *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+}
+
+func DecodePrivateKeyPEM(keyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, microerror.Maskf(invalidCertificateError, "no PEM encoded private key found")
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, microerror.Maskf(invalidCertificateError, "%s", err.Error())
+	}
+
+	return key, nil
+}
@@ -0,0 +1,150 @@
+package certificate
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	corev1alpha1 "github.com/giantswarm/apiextensions/v6/pkg/apis/core/v1alpha1"
+	"github.com/giantswarm/backoff"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	kgslabel "github.com/giantswarm/kubectl-gs/v5/internal/label"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/clientcert"
+)
+
+const (
+	credentialRetryTimeout    = 1 * time.Second
+	credentialMaxRetryTimeout = 10 * time.Second
+)
+
+// Request creates a CertConfig for a new client certificate and waits for
+// cert-operator to issue it. The returned CertConfig should be deleted once
+// the secret holding the certificate is not needed anymore.
+func Request(ctx context.Context, clientCertService clientcert.Interface, config Config) (*clientcert.ClientCert, *corev1.Secret, error) {
+	clientCert := NewCertConfig(config)
+
+	err := clientCertService.Create(ctx, clientCert)
+	if err != nil {
+		return nil, nil, microerror.Mask(err)
+	}
+
+	secret, err := fetchCredential(ctx, config.Provider, clientCertService, clientCert)
+	if err != nil {
+		return nil, nil, microerror.Mask(err)
+	}
+
+	return clientCert, secret, nil
+}
+
+// IssueWithCertOperator requests a new client certificate from
+// cert-operator and deletes the CertConfig once the certificate is issued.
+func IssueWithCertOperator(ctx context.Context, clientCertService clientcert.Interface, config Config) (*corev1.Secret, error) {
+	clientCert, secret, err := Request(ctx, clientCertService, config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	err = clientCertService.Delete(ctx, clientCert)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return secret, nil
+}
+
+// NewCertConfig returns the CertConfig requesting a client certificate from
+// cert-operator.
+func NewCertConfig(config Config) *clientcert.ClientCert {
+	clientCertUID := generateClientCertUID()
+	clientCertName := fmt.Sprintf("%s-%s", config.ClusterName, clientCertUID)
+	var clientCertCNPrefix string
+	{
+		if config.CNPrefix != "" {
+			clientCertCNPrefix = config.CNPrefix
+		} else {
+			clientCertCNPrefix = clientCertUID
+		}
+	}
+	commonName := fmt.Sprintf("%s.%s.k8s.%s", clientCertCNPrefix, config.ClusterName, config.ClusterBasePath)
+
+	certConfig := &corev1alpha1.CertConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clientCertName,
+			Namespace: config.ClusterNamespace,
+			Labels: map[string]string{
+				kgslabel.CertOperatorVersion: config.CertOperatorVersion,
+				kgslabel.Certificate:         clientCertUID,
+				label.Cluster:                config.ClusterName,
+				label.Organization:           config.Organization,
+			},
+		},
+		Spec: corev1alpha1.CertConfigSpec{
+			Cert: corev1alpha1.CertConfigSpecCert{
+				AllowBareDomains:    true,
+				ClusterComponent:    clientCertUID,
+				ClusterID:           config.ClusterName,
+				CommonName:          commonName,
+				DisableRegeneration: true,
+				Organizations:       config.Groups,
+				TTL:                 config.TTL,
+			},
+			VersionBundle: corev1alpha1.CertConfigSpecVersionBundle{
+				Version: config.CertOperatorVersion,
+			},
+		},
+	}
+
+	return &clientcert.ClientCert{
+		CertConfig: certConfig,
+	}
+}
+
+func generateClientCertUID() string {
+	hash := sha256.New()
+	_, _ = hash.Write([]byte(time.Now().String()))
+
+	uid := fmt.Sprintf("%x", hash.Sum(nil))
+
+	return uid[:16]
+}
+
+// fetchCredential tries to fetch the client certificate credential
+// for a couple of times, until the resource is fetched, or until the timeout is reached.
+func fetchCredential(ctx context.Context, provider string, clientCertService clientcert.Interface, clientCert *clientcert.ClientCert) (*corev1.Secret, error) {
+	var secret *corev1.Secret
+	var err error
+
+	o := func() error {
+		secret, err = clientCertService.GetCredential(ctx, clientCert.CertConfig.GetNamespace(), clientCert.CertConfig.Name)
+		if clientcert.IsNotFound(err) {
+			// Client certificate credential has not been created yet, try again until it is.
+			return microerror.Mask(err)
+		} else if err != nil {
+			return backoff.Permanent(microerror.Mask(err))
+		}
+
+		return nil
+	}
+	b := backoff.NewConstant(credentialMaxRetryTimeout, credentialRetryTimeout)
+
+	err = backoff.Retry(o, b)
+	if clientcert.IsNotFound(err) {
+		if provider == key.ProviderAzure {
+			// Try in default namespace for legacy azure clusters.
+			secret, err = clientCertService.GetCredential(ctx, metav1.NamespaceDefault, clientCert.CertConfig.Name)
+		}
+		if clientcert.IsNotFound(err) {
+			return nil, microerror.Maskf(credentialRetrievalTimedOutError, "failed to get the client certificate credential on time")
+		}
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,32 @@
+package certificate
+
+import (
+	"github.com/giantswarm/microerror"
+)
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var invalidCertificateError = &microerror.Error{
+	Kind: "invalidCertificateError",
+}
+
+// IsInvalidCertificate asserts invalidCertificateError.
+func IsInvalidCertificate(err error) bool {
+	return microerror.Cause(err) == invalidCertificateError
+}
+
+var credentialRetrievalTimedOutError = &microerror.Error{
+	Kind: "credentialRetrievalTimedOutError",
+}
+
+// IsCredentialRetrievalTimedOut asserts credentialRetrievalTimedOutError.
+func IsCredentialRetrievalTimedOut(err error) bool {
+	return microerror.Cause(err) == credentialRetrievalTimedOutError
+}
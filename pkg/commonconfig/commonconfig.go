@@ -10,6 +10,7 @@ import (
 	"github.com/giantswarm/micrologger"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/kubectl-gs/v5/internal/key"
 	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
@@ -20,8 +21,21 @@ const (
 	providerRegexpPattern = `.+\.%s\..+`
 )
 
+type watchingClients struct {
+	k8sclient.Interface
+	ctrlClient client.WithWatch
+}
+
+func (c *watchingClients) CtrlClient() client.Client {
+	return c.ctrlClient
+}
+
 type CommonConfig struct {
-	ConfigFlags  *genericclioptions.RESTClientGetter
+	ConfigFlags *genericclioptions.RESTClientGetter
+	// InstallationCache configures the on-disk cache of installation info.
+	// If nil, installation info is always fetched from Athena.
+	InstallationCache *installation.CacheConfig
+
 	installation *installation.Installation
 }
 
@@ -37,6 +51,29 @@ func (cc *CommonConfig) GetConfigFlags() genericclioptions.RESTClientGetter {
 	return *cc.ConfigFlags
 }
 
+// WithKubeconfig returns a config reading and writing the given kubeconfig
+// file, instead of the kubeconfig chosen by flags or the environment. The
+// other connection flags, like --context and --token, are kept.
+func (cc *CommonConfig) WithKubeconfig(path string) *CommonConfig {
+	cf := genericclioptions.NewConfigFlags(true)
+	cf.KubeConfig = &path
+
+	if c, ok := cc.GetConfigFlags().(*genericclioptions.ConfigFlags); ok {
+		cf.Context = c.Context
+		cf.BearerToken = c.BearerToken
+		cf.Namespace = c.Namespace
+		cf.Insecure = c.Insecure
+		cf.CAFile = c.CAFile
+		cf.TLSServerName = c.TLSServerName
+		cf.Timeout = c.Timeout
+	}
+
+	config := New(cf)
+	config.InstallationCache = cc.InstallationCache
+
+	return config
+}
+
 func (cc *CommonConfig) GetInstallation(ctx context.Context, path, athenaUrl string) (*installation.Installation, error) {
 	if path == "" {
 		config, err := cc.GetConfigFlags().ToRESTConfig()
@@ -46,7 +83,7 @@ func (cc *CommonConfig) GetInstallation(ctx context.Context, path, athenaUrl str
 		path = config.Host
 	}
 	if cc.installation == nil || cc.installation.SourcePath != path {
-		i, err := installation.New(ctx, path, athenaUrl)
+		i, err := cc.NewInstallation(ctx, path, athenaUrl)
 		cc.installation = i
 		if err != nil {
 			return nil, microerror.Mask(err)
@@ -55,6 +92,23 @@ func (cc *CommonConfig) GetInstallation(ctx context.Context, path, athenaUrl str
 	return cc.installation, nil
 }
 
+// NewInstallation fetches the installation info, using the on-disk cache if
+// it is set up. Unlike GetInstallation, the result is not kept.
+func (cc *CommonConfig) NewInstallation(ctx context.Context, path, athenaUrl string) (*installation.Installation, error) {
+	var i *installation.Installation
+	var err error
+	if cc.InstallationCache != nil {
+		i, err = installation.NewFromCache(ctx, path, athenaUrl, *cc.InstallationCache)
+	} else {
+		i, err = installation.New(ctx, path, athenaUrl)
+	}
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return i, nil
+}
+
 func (cc *CommonConfig) GetProviderFromConfig(ctx context.Context, athenaUrl string) (string, error) {
 	config, err := cc.GetConfigFlags().ToRESTConfig()
 	if err != nil {
@@ -105,6 +159,37 @@ func (cc *CommonConfig) GetClient(logger micrologger.Logger) (k8sclient.Interfac
 	return k8sClients, nil
 }
 
+// GetWatchingClient returns the same clients as GetClient, but with a
+// controller-runtime client which is able to watch resources as well.
+func (cc *CommonConfig) GetWatchingClient(logger micrologger.Logger) (k8sclient.Interface, error) {
+	k8sClients, err := cc.GetClient(logger)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	restConfig, err := cc.GetConfigFlags().ToRESTConfig()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	s, err := scheme.NewScheme()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	ctrlClient, err := client.NewWithWatch(restConfig, client.Options{Scheme: s})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	c := &watchingClients{
+		Interface:  k8sClients,
+		ctrlClient: ctrlClient,
+	}
+
+	return c, nil
+}
+
 func (cc *CommonConfig) GetContextOverride() string {
 	if c, ok := cc.GetConfigFlags().(*genericclioptions.ConfigFlags); ok && c.Context != nil && len(*c.Context) > 0 {
 		return *c.Context
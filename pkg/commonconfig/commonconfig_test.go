@@ -10,6 +10,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/installation"
 )
 
 func TestCommonConfig_GetProviderFromInstallation(t *testing.T) {
@@ -82,6 +83,22 @@ func TestCommonConfig_GetProviderFromInstallation(t *testing.T) {
 	}
 }
 
+func TestCommonConfig_WithKubeconfig(t *testing.T) {
+	cacheConfig := &installation.CacheConfig{Dir: t.TempDir()}
+
+	cc := New(genericclioptions.NewConfigFlags(false))
+	cc.InstallationCache = cacheConfig
+
+	result := cc.WithKubeconfig("/path/to/kubeconfig")
+	if result.InstallationCache != cacheConfig {
+		t.Fatalf("expected the installation cache config to be kept")
+	}
+	kubeconfig := result.GetConfigAccess().GetExplicitFile()
+	if kubeconfig != "/path/to/kubeconfig" {
+		t.Fatalf("expected kubeconfig %q, got %q", "/path/to/kubeconfig", kubeconfig)
+	}
+}
+
 func graphqlResponseBody(provider string) []byte {
 	if provider == "" {
 		return []byte{}
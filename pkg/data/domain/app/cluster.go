@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsClusterApp tells whether the app belongs to the workload cluster with
+// the given name. These are the apps labelled with the cluster name and, for
+// apps without a cluster label, the cluster app itself and the apps named
+// with the cluster name as prefix, like the default apps.
+//
+// The names of the other clusters in the namespace of the app are needed to
+// tell these apart from the apps of a cluster with a longer name. E.g. app
+// foo-bar-default-apps belongs to cluster foo-bar, not to cluster foo, if
+// both clusters exist.
+func IsClusterApp(appCR *applicationv1alpha1.App, clusterName string, clusterNames []string) bool {
+	if cluster, ok := appCR.Labels[label.Cluster]; ok {
+		return cluster == clusterName
+	}
+
+	if !isNamedAfter(appCR.Name, clusterName) {
+		return false
+	}
+	for _, other := range clusterNames {
+		if len(other) > len(clusterName) && isNamedAfter(appCR.Name, other) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isNamedAfter(appName, clusterName string) bool {
+	return appName == clusterName || strings.HasPrefix(appName, clusterName+"-")
+}
+
+// GetClusterNames returns the names of the clusters in the namespace, to be
+// passed to IsClusterApp. If clusters are not known to the API server, no
+// names are returned.
+func (s *Service) GetClusterNames(ctx context.Context, namespace string) ([]string, error) {
+	clusters := &capi.ClusterList{}
+	err := s.client.List(ctx, clusters, client.InNamespace(namespace))
+	if apimeta.IsNoMatchError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var names []string
+	for _, cluster := range clusters.Items {
+		names = append(names, cluster.Name)
+	}
+
+	return names, nil
+}
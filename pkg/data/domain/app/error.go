@@ -49,6 +49,15 @@ func IsInvalidType(err error) bool {
 	return microerror.Cause(err) == invalidTypeError
 }
 
+var alreadyLatestError = &microerror.Error{
+	Kind: "alreadyLatestError",
+}
+
+// IsAlreadyLatest asserts alreadyLatestError.
+func IsAlreadyLatest(err error) bool {
+	return microerror.Cause(err) == alreadyLatestError
+}
+
 var fetchError = &microerror.Error{
 	Kind: "fetchError",
 }
@@ -57,3 +66,12 @@ var fetchError = &microerror.Error{
 func IsFetch(err error) bool {
 	return microerror.Cause(err) == fetchError
 }
+
+var invalidVersionError = &microerror.Error{
+	Kind: "invalidVersionError",
+}
+
+// IsInvalidVersion asserts invalidVersionError.
+func IsInvalidVersion(err error) bool {
+	return microerror.Cause(err) == invalidVersionError
+}
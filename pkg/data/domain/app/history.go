@@ -0,0 +1,243 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/annotation"
+)
+
+const (
+	// maxVersionHistory is the number of versions kept in the version
+	// history annotation.
+	maxVersionHistory = 10
+
+	// kubeConfigSecretKey is the key of the kubeconfig in the secret an app
+	// references to reach its target cluster.
+	kubeConfigSecretKey = "value"
+
+	// targetClusterTimeout bounds the requests to the target cluster of an
+	// app, so that an unreachable cluster does not hold up falling back to
+	// the version history annotation.
+	targetClusterTimeout = 10 * time.Second
+)
+
+// helmRelease is the part of a Helm release, as stored in its release
+// secret, needed to reconstruct the history.
+type helmRelease struct {
+	Version int `json:"version"`
+	Info    struct {
+		LastDeployed time.Time `json:"last_deployed"`
+		Status       string    `json:"status"`
+	} `json:"info"`
+	Chart struct {
+		Metadata struct {
+			Version string `json:"version"`
+		} `json:"metadata"`
+	} `json:"chart"`
+}
+
+// GetHistory returns the versions an app has been deployed with. The history
+// is reconstructed from the Helm release secrets in the target cluster. If
+// they cannot be read, e.g. because the cluster is not reachable, the version
+// history annotation maintained by Patch is used instead.
+func (s *Service) GetHistory(ctx context.Context, options GetOptions) (*History, error) {
+	resource, err := s.getByName(ctx, options.Namespace, options.Name)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	appCR := resource.(*App).CR
+
+	entries, err := s.getHelmHistory(ctx, appCR)
+	if err == nil && len(entries) > 0 {
+		return &History{Source: HistorySourceHelm, Entries: entries}, nil
+	}
+
+	history := &History{
+		Source:  HistorySourceAnnotation,
+		Entries: getAnnotationHistory(appCR),
+	}
+	if err != nil {
+		history.Warning = err.Error()
+	} else {
+		history.Warning = "no Helm release secrets found"
+	}
+
+	return history, nil
+}
+
+// getHelmHistory reads the Helm release secrets of the app in its target
+// cluster.
+func (s *Service) getHelmHistory(ctx context.Context, appCR *applicationv1alpha1.App) ([]HistoryEntry, error) {
+	targetClient, err := s.getTargetClient(ctx, appCR)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	secrets := &corev1.SecretList{}
+	err = targetClient.List(ctx, secrets,
+		client.InNamespace(appCR.Spec.Namespace),
+		client.MatchingLabels{"owner": "helm", "name": appCR.Name},
+	)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var entries []HistoryEntry
+	for _, secret := range secrets.Items {
+		release, err := decodeHelmRelease(secret.Data["release"])
+		if err != nil {
+			return nil, microerror.Maskf(invalidTypeError, "unable to decode Helm release secret %#q: %s", secret.Name, err)
+		}
+
+		entry := HistoryEntry{
+			Revision: release.Version,
+			Version:  release.Chart.Metadata.Version,
+			Status:   release.Info.Status,
+		}
+		if !release.Info.LastDeployed.IsZero() {
+			entry.Deployed = &metav1.Time{Time: release.Info.LastDeployed}
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Revision < entries[j].Revision
+	})
+
+	return entries, nil
+}
+
+// getTargetClient returns a client for the cluster the app is deployed to.
+func (s *Service) getTargetClient(ctx context.Context, appCR *applicationv1alpha1.App) (client.Client, error) {
+	if appCR.Spec.KubeConfig.InCluster {
+		return s.client, nil
+	}
+
+	ref := appCR.Spec.KubeConfig.Secret
+	if ref.Name == "" {
+		return nil, microerror.Maskf(notFoundError, "app %#q references no kubeconfig of its target cluster", appCR.Name)
+	}
+
+	secret := &corev1.Secret{}
+	err := s.client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[kubeConfigSecretKey])
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	restConfig.Timeout = targetClusterTimeout
+
+	targetClient, err := client.New(restConfig, client.Options{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return targetClient, nil
+}
+
+// decodeHelmRelease decodes the release stored in a Helm release secret,
+// which is gzipped JSON, base64 encoded once more on top of the secret
+// encoding.
+func decodeHelmRelease(data []byte) (*helmRelease, error) {
+	decoded, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if bytes.HasPrefix(decoded, []byte{0x1f, 0x8b}) {
+		reader, err := gzip.NewReader(bytes.NewReader(decoded))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		defer reader.Close()
+
+		decoded, err = io.ReadAll(reader)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	release := &helmRelease{}
+	err = json.Unmarshal(decoded, release)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return release, nil
+}
+
+// getAnnotationHistory returns the history kept in the version history
+// annotation. The current version carries the deployment status of the
+// app.
+func getAnnotationHistory(appCR *applicationv1alpha1.App) []HistoryEntry {
+	versions := parseVersionHistory(appCR.GetAnnotations()[annotation.AppVersionHistory])
+	if len(versions) == 0 || versions[len(versions)-1] != appCR.Spec.Version {
+		versions = append(versions, appCR.Spec.Version)
+	}
+
+	var entries []HistoryEntry
+	for i, version := range versions {
+		entries = append(entries, HistoryEntry{
+			Revision: i + 1,
+			Version:  version,
+		})
+	}
+
+	current := &entries[len(entries)-1]
+	if appCR.Status.Version == current.Version {
+		current.Status = appCR.Status.Release.Status
+		if !appCR.Status.Release.LastDeployed.IsZero() {
+			deployed := appCR.Status.Release.LastDeployed
+			current.Deployed = &deployed
+		}
+	}
+
+	return entries
+}
+
+// recordVersion appends the version of the app to its version history
+// annotation. The history starts with the previous version.
+func recordVersion(appCR *applicationv1alpha1.App, previousVersion string) {
+	annotations := appCR.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	versions := parseVersionHistory(annotations[annotation.AppVersionHistory])
+	if previousVersion != "" && (len(versions) == 0 || versions[len(versions)-1] != previousVersion) {
+		versions = append(versions, previousVersion)
+	}
+	versions = append(versions, appCR.Spec.Version)
+	if len(versions) > maxVersionHistory {
+		versions = versions[len(versions)-maxVersionHistory:]
+	}
+
+	annotations[annotation.AppVersionHistory] = strings.Join(versions, ",")
+	appCR.SetAnnotations(annotations)
+}
+
+func parseVersionHistory(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
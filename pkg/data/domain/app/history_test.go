@@ -0,0 +1,202 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/annotation"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/scheme"
+)
+
+func Test_GetHistory(t *testing.T) {
+	deployed := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	var testCases = []struct {
+		name            string
+		storage         []runtime.Object
+		expectedHistory *History
+	}{
+		{
+			name: "history from the Helm release secrets",
+			storage: []runtime.Object{
+				newInClusterApp("fake-app", "0.2.0", ""),
+				newHelmReleaseSecret(t, "fake-app", 2, "0.2.0", "deployed", deployed.Add(time.Hour)),
+				newHelmReleaseSecret(t, "fake-app", 1, "0.1.0", "superseded", deployed),
+			},
+			expectedHistory: &History{
+				Source: HistorySourceHelm,
+				Entries: []HistoryEntry{
+					{Revision: 1, Version: "0.1.0", Deployed: &metav1.Time{Time: deployed}, Status: "superseded"},
+					{Revision: 2, Version: "0.2.0", Deployed: &metav1.Time{Time: deployed.Add(time.Hour)}, Status: "deployed"},
+				},
+			},
+		},
+		{
+			name: "history from the annotation without Helm release secrets",
+			storage: []runtime.Object{
+				newInClusterApp("fake-app", "0.3.0", "0.1.0,0.2.0,0.3.0"),
+			},
+			expectedHistory: &History{
+				Source: HistorySourceAnnotation,
+				Entries: []HistoryEntry{
+					{Revision: 1, Version: "0.1.0"},
+					{Revision: 2, Version: "0.2.0"},
+					{Revision: 3, Version: "0.3.0"},
+				},
+				Warning: "no Helm release secrets found",
+			},
+		},
+		{
+			name: "history of an app never updated",
+			storage: []runtime.Object{
+				newInClusterApp("fake-app", "0.1.0", ""),
+			},
+			expectedHistory: &History{
+				Source: HistorySourceAnnotation,
+				Entries: []HistoryEntry{
+					{Revision: 1, Version: "0.1.0"},
+				},
+				Warning: "no Helm release secrets found",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			clientScheme, err := scheme.NewScheme()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			service, err := New(Config{
+				Client: fake.NewClientBuilder().WithScheme(clientScheme).WithRuntimeObjects(tc.storage...).Build(),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			history, err := service.GetHistory(context.TODO(), GetOptions{Namespace: "default", Name: "fake-app"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			diff := cmp.Diff(tc.expectedHistory, history)
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func Test_recordVersion(t *testing.T) {
+	var testCases = []struct {
+		name            string
+		history         string
+		previousVersion string
+		version         string
+		expectedHistory string
+	}{
+		{
+			name:            "first update",
+			previousVersion: "0.1.0",
+			version:         "0.2.0",
+			expectedHistory: "0.1.0,0.2.0",
+		},
+		{
+			name:            "following update",
+			history:         "0.1.0,0.2.0",
+			previousVersion: "0.2.0",
+			version:         "0.3.0",
+			expectedHistory: "0.1.0,0.2.0,0.3.0",
+		},
+		{
+			name:            "history is trimmed",
+			history:         "0.1.0,0.2.0,0.3.0,0.4.0,0.5.0,0.6.0,0.7.0,0.8.0,0.9.0,0.10.0",
+			previousVersion: "0.10.0",
+			version:         "0.11.0",
+			expectedHistory: "0.2.0,0.3.0,0.4.0,0.5.0,0.6.0,0.7.0,0.8.0,0.9.0,0.10.0,0.11.0",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			appCR := newInClusterApp("fake-app", tc.version, tc.history)
+
+			recordVersion(appCR, tc.previousVersion)
+
+			diff := cmp.Diff(tc.expectedHistory, appCR.Annotations[annotation.AppVersionHistory])
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func newInClusterApp(name, version, history string) *applicationv1alpha1.App {
+	appCR := &applicationv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: applicationv1alpha1.AppSpec{
+			Catalog:   "fake-catalog",
+			Name:      name,
+			Namespace: "kube-system",
+			Version:   version,
+			KubeConfig: applicationv1alpha1.AppSpecKubeConfig{
+				InCluster: true,
+			},
+		},
+	}
+	if history != "" {
+		appCR.Annotations = map[string]string{
+			annotation.AppVersionHistory: history,
+		}
+	}
+
+	return appCR
+}
+
+// newHelmReleaseSecret returns a Helm release secret, with the release
+// gzipped and base64 encoded the way Helm stores it.
+func newHelmReleaseSecret(t *testing.T, name string, revision int, version, status string, deployed time.Time) *corev1.Secret {
+	release := fmt.Sprintf(`{"name":%q,"version":%d,"info":{"last_deployed":%q,"status":%q},"chart":{"metadata":{"name":%q,"version":%q}}}`,
+		name, revision, deployed.Format(time.RFC3339), status, name, version)
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write([]byte(release))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	err = writer.Close()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("sh.helm.release.v1.%s.v%d", name, revision),
+			Namespace: "kube-system",
+			Labels: map[string]string{
+				"owner": "helm",
+				"name":  name,
+			},
+		},
+		Data: map[string][]byte{
+			"release": []byte(base64.StdEncoding.EncodeToString(buf.Bytes())),
+		},
+	}
+}
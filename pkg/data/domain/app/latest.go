@@ -0,0 +1,160 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/microerror"
+
+	catalogdata "github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/catalog"
+)
+
+// LatestVersion returns the latest version of the app available in its
+// catalog, within the given constraint. Only versions higher than the
+// current version of the app are picked. If there is none, an
+// alreadyLatestError is returned.
+func (s *Service) LatestVersion(ctx context.Context, app *applicationv1alpha1.App, constraint VersionConstraint) (string, error) {
+	current, err := semver.NewVersion(app.Spec.Version)
+	if err != nil && constraint != LatestAny {
+		return "", microerror.Maskf(invalidVersionError, "version %#q of app %#q is not a valid semantic version", app.Spec.Version, app.Name)
+	} else if err != nil {
+		// Without a valid current version, the latest version is picked.
+		current = nil
+	}
+
+	latest, err := s.findLatestVersion(ctx, app, current, constraint)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return latest, nil
+}
+
+// findLatestVersion returns the latest version of the app available in its
+// catalog, within the given constraint, which is higher than the current
+// version, if given.
+//
+// For LatestAny, the AppCatalogEntry CR labelled with latest=true is used.
+// Otherwise the versions are taken from the AppCatalogEntry CRs of the app.
+// As we only keep these for the most recent versions, the catalog's
+// index.yaml is consulted when none of them matches the constraint.
+func (s *Service) findLatestVersion(ctx context.Context, app *applicationv1alpha1.App, current *semver.Version, constraint VersionConstraint) (string, error) {
+	if constraint == LatestAny {
+		selector := fmt.Sprintf(
+			"application.giantswarm.io/catalog=%s,app.kubernetes.io/name=%s,latest=true",
+			app.Spec.Catalog,
+			app.Spec.Name,
+		)
+		catalogEntries, err := s.catalogDataService.GetEntries(ctx, selector)
+		if err != nil && !catalogdata.IsNoResources(err) {
+			return "", microerror.Mask(err)
+		}
+		if err == nil && len(catalogEntries.Items) > 0 {
+			latest, found := pickLatestVersion([]string{catalogEntries.Items[0].Spec.Version}, current, constraint)
+			if latest != "" {
+				return latest, nil
+			} else if found {
+				return "", newAlreadyLatestError(app, constraint)
+			}
+		}
+	}
+
+	selector := fmt.Sprintf(
+		"application.giantswarm.io/catalog=%s,app.kubernetes.io/name=%s",
+		app.Spec.Catalog,
+		app.Spec.Name,
+	)
+	catalogEntries, err := s.catalogDataService.GetEntries(ctx, selector)
+	if err != nil && !catalogdata.IsNoResources(err) {
+		return "", microerror.Mask(err)
+	}
+	if err == nil {
+		var versions []string
+		for _, entry := range catalogEntries.Items {
+			versions = append(versions, entry.Spec.Version)
+		}
+
+		latest, found := pickLatestVersion(versions, current, constraint)
+		if latest != "" {
+			return latest, nil
+		} else if found {
+			return "", newAlreadyLatestError(app, constraint)
+		}
+	}
+
+	catalog, err := s.fetchCatalog(ctx, app.Spec.Catalog, app.Spec.CatalogNamespace, selector)
+	if catalogdata.IsNoResources(err) {
+		return "", microerror.Maskf(noResourcesError, "app %#q cannot be found in catalog %#q", app.Spec.Name, app.Spec.Catalog)
+	} else if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	index, err := catalogdata.FetchIndex(ctx, catalog)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	var versions []string
+	for _, entry := range index.Entries[app.Spec.Name] {
+		versions = append(versions, entry.Version)
+	}
+
+	latest, found := pickLatestVersion(versions, current, constraint)
+	if latest == "" && found {
+		return "", newAlreadyLatestError(app, constraint)
+	} else if latest == "" {
+		return "", microerror.Maskf(noResourcesError, "no %s version of app %#q found in catalog %#q", constraint, app.Spec.Name, app.Spec.Catalog)
+	}
+
+	return latest, nil
+}
+
+func newAlreadyLatestError(app *applicationv1alpha1.App, constraint VersionConstraint) error {
+	if constraint == LatestAny {
+		return microerror.Maskf(alreadyLatestError, "app %#q is already on the latest version %#q", app.Name, app.Spec.Version)
+	}
+
+	return microerror.Maskf(alreadyLatestError, "app %#q is already on the latest %s version %#q", app.Name, constraint, app.Spec.Version)
+}
+
+// pickLatestVersion returns the highest of the given versions within the
+// constraint, relative to the current version, if it is higher than the
+// current version. Pre-releases and versions that are not valid semantic
+// versions are ignored. It also returns whether any version within the
+// constraint was found, to tell an app on the latest version from one whose
+// versions are unknown.
+func pickLatestVersion(versions []string, current *semver.Version, constraint VersionConstraint) (string, bool) {
+	var found bool
+	var latest *semver.Version
+	var latestVersion string
+	for _, version := range versions {
+		v, err := semver.NewVersion(version)
+		if err != nil || v.Prerelease() != "" {
+			continue
+		}
+
+		switch constraint {
+		case LatestMinor:
+			if v.Major() != current.Major() {
+				continue
+			}
+		case LatestPatch:
+			if v.Major() != current.Major() || v.Minor() != current.Minor() {
+				continue
+			}
+		}
+		found = true
+
+		if current != nil && !v.GreaterThan(current) {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+			latestVersion = version
+		}
+	}
+
+	return latestVersion, found
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+func Test_pickLatestVersion(t *testing.T) {
+	versions := []string{"1.0.0", "1.0.1", "1.1.0", "1.2.0-rc.1", "2.0.0", "main"}
+
+	testCases := []struct {
+		name            string
+		current         string
+		constraint      VersionConstraint
+		expectedVersion string
+		expectedFound   bool
+	}{
+		{
+			name:            "latest version",
+			current:         "1.0.0",
+			constraint:      LatestAny,
+			expectedVersion: "2.0.0",
+			expectedFound:   true,
+		},
+		{
+			name:            "latest minor version",
+			current:         "1.0.0",
+			constraint:      LatestMinor,
+			expectedVersion: "1.1.0",
+			expectedFound:   true,
+		},
+		{
+			name:            "latest patch version",
+			current:         "1.0.0",
+			constraint:      LatestPatch,
+			expectedVersion: "1.0.1",
+			expectedFound:   true,
+		},
+		{
+			name:          "already on the latest version",
+			current:       "2.0.0",
+			constraint:    LatestAny,
+			expectedFound: true,
+		},
+		{
+			name:          "ahead of the latest patch version",
+			current:       "1.0.2",
+			constraint:    LatestPatch,
+			expectedFound: true,
+		},
+		{
+			name:       "no version within the constraint",
+			current:    "3.0.0",
+			constraint: LatestMinor,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			current := semver.MustParse(tc.current)
+
+			version, found := pickLatestVersion(versions, current, tc.constraint)
+			if version != tc.expectedVersion {
+				t.Fatalf("expected version %q, got %q", tc.expectedVersion, version)
+			}
+			if found != tc.expectedFound {
+				t.Fatalf("expected found %t, got %t", tc.expectedFound, found)
+			}
+		})
+	}
+}
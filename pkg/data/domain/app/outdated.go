@@ -0,0 +1,222 @@
+package app
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+)
+
+// Lag tells how far the deployed version of an app is behind the latest
+// version in its catalog.
+type Lag string
+
+const (
+	LagNone    Lag = "none"
+	LagPatch   Lag = "patch"
+	LagMinor   Lag = "minor"
+	LagMajor   Lag = "major"
+	LagUnknown Lag = "unknown"
+)
+
+// organizationNamespacePrefix is the prefix of the namespaces of
+// organizations, in which the apps of workload clusters are created.
+const organizationNamespacePrefix = "org-"
+
+// OutdatedReport compares the deployed versions of apps with the latest
+// versions in their catalogs.
+type OutdatedReport struct {
+	Apps          []OutdatedApp     `json:"apps"`
+	Clusters      []OutdatedSummary `json:"clusters"`
+	Organizations []OutdatedSummary `json:"organizations"`
+}
+
+// OutdatedApp is the deployed and latest version of a single app.
+type OutdatedApp struct {
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	Cluster       string `json:"cluster,omitempty"`
+	Organization  string `json:"organization,omitempty"`
+	Version       string `json:"version"`
+	LatestVersion string `json:"latestVersion,omitempty"`
+	Behind        Lag    `json:"behind"`
+	// Reason explains why the lag is unknown.
+	Reason string `json:"reason,omitempty"`
+}
+
+// OutdatedSummary counts the apps of a cluster or organization by how far
+// they are behind.
+type OutdatedSummary struct {
+	Organization string `json:"organization,omitempty"`
+	Cluster      string `json:"cluster,omitempty"`
+	Apps         int    `json:"apps"`
+	Patch        int    `json:"patch"`
+	Minor        int    `json:"minor"`
+	Major        int    `json:"major"`
+	Unknown      int    `json:"unknown"`
+}
+
+// Outdated returns the number of apps behind the latest version.
+func (s OutdatedSummary) Outdated() int {
+	return s.Patch + s.Minor + s.Major
+}
+
+// GetOutdated compares the deployed version of the apps with the latest
+// version in their catalog, as returned by LatestVersion.
+func (s *Service) GetOutdated(ctx context.Context, options GetOptions) (*OutdatedReport, error) {
+	resource, err := s.Get(ctx, options)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var apps []*applicationv1alpha1.App
+	switch r := resource.(type) {
+	case *App:
+		apps = append(apps, r.CR)
+	case *Collection:
+		for _, item := range r.Items {
+			apps = append(apps, item.CR)
+		}
+	}
+
+	// Many clusters run the same apps, so the latest version is looked up
+	// once per app and catalog.
+	type latestResult struct {
+		version string
+		err     error
+	}
+	latestVersions := map[string]latestResult{}
+
+	report := &OutdatedReport{}
+	for _, appCR := range apps {
+		outdated := OutdatedApp{
+			Namespace:    appCR.Namespace,
+			Name:         appCR.Name,
+			Cluster:      appCR.Labels[label.Cluster],
+			Organization: getOrganization(appCR),
+			Version:      appCR.Status.Version,
+		}
+		if outdated.Version == "" {
+			// The app has not been deployed yet.
+			outdated.Version = appCR.Spec.Version
+		}
+
+		cacheKey := strings.Join([]string{appCR.Spec.CatalogNamespace, appCR.Spec.Catalog, appCR.Spec.Name}, "/")
+		latest, ok := latestVersions[cacheKey]
+		if !ok {
+			// Not compared with the version of the app, as the result
+			// is shared by the apps of all clusters.
+			latest.version, latest.err = s.findLatestVersion(ctx, appCR, nil, LatestAny)
+			latestVersions[cacheKey] = latest
+		}
+
+		if latest.err != nil {
+			outdated.Behind = LagUnknown
+			outdated.Reason = latest.err.Error()
+			if IsNoResources(latest.err) {
+				outdated.Reason = "no version found in the catalog"
+			}
+		} else {
+			outdated.LatestVersion = latest.version
+			outdated.Behind = getLag(outdated.Version, latest.version)
+		}
+
+		report.Apps = append(report.Apps, outdated)
+	}
+
+	sort.Slice(report.Apps, func(i, j int) bool {
+		a, b := report.Apps[i], report.Apps[j]
+		if a.Organization != b.Organization {
+			return a.Organization < b.Organization
+		}
+		if a.Cluster != b.Cluster {
+			return a.Cluster < b.Cluster
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	report.Clusters = summarize(report.Apps, func(a OutdatedApp) OutdatedSummary {
+		return OutdatedSummary{Organization: a.Organization, Cluster: a.Cluster}
+	})
+	report.Organizations = summarize(report.Apps, func(a OutdatedApp) OutdatedSummary {
+		return OutdatedSummary{Organization: a.Organization}
+	})
+
+	return report, nil
+}
+
+// getLag classifies how far the version is behind the latest version.
+func getLag(version, latestVersion string) Lag {
+	current, err := semver.NewVersion(version)
+	if err != nil {
+		return LagUnknown
+	}
+	latest, err := semver.NewVersion(latestVersion)
+	if err != nil {
+		return LagUnknown
+	}
+
+	switch {
+	case !latest.GreaterThan(current):
+		return LagNone
+	case latest.Major() != current.Major():
+		return LagMajor
+	case latest.Minor() != current.Minor():
+		return LagMinor
+	default:
+		return LagPatch
+	}
+}
+
+// getOrganization returns the organization owning the app, from its
+// organization label or namespace. It is empty for apps outside of
+// organization namespaces.
+func getOrganization(appCR *applicationv1alpha1.App) string {
+	if organization := appCR.Labels[label.Organization]; organization != "" {
+		return organization
+	}
+
+	if organization, ok := strings.CutPrefix(appCR.Namespace, organizationNamespacePrefix); ok {
+		return organization
+	}
+
+	return ""
+}
+
+// summarize counts the apps by the summary key returned for each app,
+// keeping the order of the apps.
+func summarize(apps []OutdatedApp, key func(OutdatedApp) OutdatedSummary) []OutdatedSummary {
+	var summaries []OutdatedSummary
+	index := map[OutdatedSummary]int{}
+	for _, a := range apps {
+		k := key(a)
+		i, ok := index[k]
+		if !ok {
+			i = len(summaries)
+			index[k] = i
+			summaries = append(summaries, k)
+		}
+
+		summary := &summaries[i]
+		summary.Apps++
+		switch a.Behind {
+		case LagPatch:
+			summary.Patch++
+		case LagMinor:
+			summary.Minor++
+		case LagMajor:
+			summary.Major++
+		case LagUnknown:
+			summary.Unknown++
+		}
+	}
+
+	return summaries
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/scheme"
+)
+
+func Test_GetOutdated(t *testing.T) {
+	storage := []runtime.Object{
+		newClusterApp("a01-coredns", "org-acme", "a01", "coredns", "1.2.3"),
+		newClusterApp("a01-ingress", "org-acme", "a01", "ingress", "2.0.0"),
+		newClusterApp("a02-coredns", "org-acme", "a02", "coredns", "1.1.0"),
+		newClusterApp("a02-ingress", "org-acme", "a02", "ingress", "1.9.0"),
+		newClusterApp("b01-coredns", "org-beta", "b01", "coredns", "1.2.0"),
+		newClusterApp("b01-unlisted", "org-beta", "b01", "unlisted", "0.1.0"),
+		newCatalogEntry("coredns", "1.2.4", "true"),
+		newCatalogEntry("ingress", "2.0.0", "true"),
+		newCatalog(),
+	}
+
+	clientScheme, err := scheme.NewScheme()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	service, err := New(Config{
+		Client: fake.NewClientBuilder().WithScheme(clientScheme).WithRuntimeObjects(storage...).Build(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	report, err := service.GetOutdated(context.TODO(), GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := &OutdatedReport{
+		Apps: []OutdatedApp{
+			{Namespace: "org-acme", Name: "a01-coredns", Cluster: "a01", Organization: "acme", Version: "1.2.3", LatestVersion: "1.2.4", Behind: LagPatch},
+			{Namespace: "org-acme", Name: "a01-ingress", Cluster: "a01", Organization: "acme", Version: "2.0.0", LatestVersion: "2.0.0", Behind: LagNone},
+			{Namespace: "org-acme", Name: "a02-coredns", Cluster: "a02", Organization: "acme", Version: "1.1.0", LatestVersion: "1.2.4", Behind: LagMinor},
+			{Namespace: "org-acme", Name: "a02-ingress", Cluster: "a02", Organization: "acme", Version: "1.9.0", LatestVersion: "2.0.0", Behind: LagMajor},
+			{Namespace: "org-beta", Name: "b01-coredns", Cluster: "b01", Organization: "beta", Version: "1.2.0", LatestVersion: "1.2.4", Behind: LagPatch},
+			{Namespace: "org-beta", Name: "b01-unlisted", Cluster: "b01", Organization: "beta", Version: "0.1.0", Behind: LagUnknown, Reason: "no version found in the catalog"},
+		},
+		Clusters: []OutdatedSummary{
+			{Organization: "acme", Cluster: "a01", Apps: 2, Patch: 1},
+			{Organization: "acme", Cluster: "a02", Apps: 2, Minor: 1, Major: 1},
+			{Organization: "beta", Cluster: "b01", Apps: 2, Patch: 1, Unknown: 1},
+		},
+		Organizations: []OutdatedSummary{
+			{Organization: "acme", Apps: 4, Patch: 1, Minor: 1, Major: 1},
+			{Organization: "beta", Apps: 2, Patch: 1, Unknown: 1},
+		},
+	}
+
+	diff := cmp.Diff(expected, report)
+	if diff != "" {
+		t.Fatalf("value not expected, got:\n %s", diff)
+	}
+}
+
+func Test_getLag(t *testing.T) {
+	testCases := []struct {
+		version       string
+		latestVersion string
+		expectedLag   Lag
+	}{
+		{version: "1.2.3", latestVersion: "1.2.3", expectedLag: LagNone},
+		{version: "1.3.0", latestVersion: "1.2.3", expectedLag: LagNone},
+		{version: "1.2.3", latestVersion: "1.2.4", expectedLag: LagPatch},
+		{version: "1.2.3", latestVersion: "1.3.0", expectedLag: LagMinor},
+		{version: "1.2.3", latestVersion: "2.0.0", expectedLag: LagMajor},
+		{version: "main", latestVersion: "2.0.0", expectedLag: LagUnknown},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s to %s", i, tc.version, tc.latestVersion), func(t *testing.T) {
+			lag := getLag(tc.version, tc.latestVersion)
+			if lag != tc.expectedLag {
+				t.Fatalf("expected %q, got %q", tc.expectedLag, lag)
+			}
+		})
+	}
+}
+
+func newClusterApp(name, namespace, cluster, appName, version string) *applicationv1alpha1.App {
+	return &applicationv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				label.Cluster: cluster,
+			},
+		},
+		Spec: applicationv1alpha1.AppSpec{
+			Catalog: "fake-catalog",
+			Name:    appName,
+			Version: version,
+		},
+		Status: applicationv1alpha1.AppStatus{
+			Version: version,
+		},
+	}
+}
+
+func newCatalogEntry(appName, version, latest string) *applicationv1alpha1.AppCatalogEntry {
+	return &applicationv1alpha1.AppCatalogEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("fake-catalog-%s-%s", appName, version),
+			Namespace: "default",
+			Labels: map[string]string{
+				label.CatalogName:          "fake-catalog",
+				label.AppKubernetesName:    appName,
+				label.AppKubernetesVersion: version,
+				"latest":                   latest,
+			},
+		},
+		Spec: applicationv1alpha1.AppCatalogEntrySpec{
+			AppName: appName,
+			Catalog: applicationv1alpha1.AppCatalogEntrySpecCatalog{
+				Name:      "fake-catalog",
+				Namespace: "default",
+			},
+			Version: version,
+		},
+	}
+}
+
+// newCatalog returns a catalog without repositories, so only its
+// AppCatalogEntry CRs are looked at.
+func newCatalog() *applicationv1alpha1.Catalog {
+	return &applicationv1alpha1.Catalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-catalog",
+			Namespace: "default",
+		},
+	}
+}
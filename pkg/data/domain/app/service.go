@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	catalogdata "github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/catalog"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
 )
 
 var _ Interface = &Service{}
@@ -62,7 +63,7 @@ func New(config Config) (*Service, error) {
 }
 
 // Get fetches a list of app CRs filtered by namespace and optionally by
-// name.
+// name or label selector.
 func (s *Service) Get(ctx context.Context, options GetOptions) (Resource, error) {
 	var resource Resource
 	var err error
@@ -76,7 +77,7 @@ func (s *Service) Get(ctx context.Context, options GetOptions) (Resource, error)
 		return resource, nil
 	}
 
-	resource, err = s.getAll(ctx, options.Namespace)
+	resource, err = s.getAll(ctx, options.Namespace, options.LabelSelector)
 	if err != nil {
 		return nil, microerror.Mask(err)
 	}
@@ -85,19 +86,24 @@ func (s *Service) Get(ctx context.Context, options GetOptions) (Resource, error)
 }
 
 // Patch patches an app CR given its name and namespace.
-func (s *Service) Patch(ctx context.Context, options PatchOptions) ([]string, error) {
-	state, err := s.patchVersion(ctx, options.Namespace, options.Name, options.SuspendReconciliation, options.Version)
+//
+// In dry run mode, the changes are either only computed locally, or sent to
+// the API server without being persisted.
+func (s *Service) Patch(ctx context.Context, options PatchOptions) (*PatchResult, error) {
+	result, err := s.patch(ctx, options)
 	if err != nil {
 		return nil, microerror.Mask(err)
 	}
 
-	return state, nil
+	return result, nil
 }
 
-func (s *Service) patchVersion(ctx context.Context, namespace string, name string, suspendReconciliation bool, version string) (state []string, err error) {
+func (s *Service) patch(ctx context.Context, options PatchOptions) (result *PatchResult, err error) {
+	var state []string
+
 	var appResource Resource
 	{
-		appResource, err = s.getByName(ctx, namespace, name)
+		appResource, err = s.getByName(ctx, options.Namespace, options.Name)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
@@ -112,9 +118,10 @@ func (s *Service) patchVersion(ctx context.Context, namespace string, name strin
 		return nil, microerror.Maskf(invalidTypeError, "unexpected type %T found", a)
 	}
 
-	patch := client.MergeFrom(appCR.DeepCopy())
+	original := appCR.DeepCopy()
+	patch := client.MergeFrom(original)
 
-	if len(version) > 0 {
+	if len(options.Version) > 0 {
 		// Make sure the requested version is available
 		// Easy way:
 		// (1) Reuse `catalogdata.GetEntries(ctx, options)` to get Catalog with AppCatalogEntry CR using version-specific label selector.
@@ -123,11 +130,24 @@ func (s *Service) patchVersion(ctx context.Context, namespace string, name strin
 		//     the `catalogdata.Get(ctx, options)` again. Catalog CR carries the URL of the given catalog, we can use it as a fallback.
 		// (3) Now, fall back to checking the Helm Repository (Catalog) directly. Use HEAD request for the Chart archive, without fetching
 		//     the whole index.yaml which is more "expensive".
-		err = s.findVersion(ctx, appCR, version, appCR.Spec.Catalog, appCR.Spec.CatalogNamespace)
+		err = s.findVersion(ctx, appCR, options.Version, appCR.Spec.Catalog, appCR.Spec.CatalogNamespace)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 		state = append(state, fmt.Sprintf("version=%s", appCR.Spec.Version))
+
+		if appCR.Spec.Version != original.Spec.Version {
+			recordVersion(appCR, original.Spec.Version)
+		}
+	}
+
+	if options.UserConfigConfigMap != nil {
+		appCR.Spec.UserConfig.ConfigMap = *options.UserConfigConfigMap
+		state = append(state, fmt.Sprintf("userConfig.configMap=%s/%s", options.UserConfigConfigMap.Namespace, options.UserConfigConfigMap.Name))
+	}
+	if options.UserConfigSecret != nil {
+		appCR.Spec.UserConfig.Secret = *options.UserConfigSecret
+		state = append(state, fmt.Sprintf("userConfig.secret=%s/%s", options.UserConfigSecret.Namespace, options.UserConfigSecret.Name))
 	}
 
 	// Handle Flux reconcile annotation used to suspend reconciliation.
@@ -146,7 +166,7 @@ func (s *Service) patchVersion(ctx context.Context, namespace string, name strin
 		if annotations == nil {
 			annotations = make(map[string]string)
 		}
-		if suspendReconciliation {
+		if options.SuspendReconciliation {
 			annotations[k8smetadataAnnotation.FluxKustomizeReconcile] = "disabled"
 			state = append(state, fmt.Sprintf("added annotations[\"%s\"]=%s", k8smetadataAnnotation.FluxKustomizeReconcile, "disabled"))
 		} else {
@@ -160,12 +180,20 @@ func (s *Service) patchVersion(ctx context.Context, namespace string, name strin
 		accessor.SetAnnotations(annotations)
 	}
 
-	err = s.client.Patch(ctx, appCR, patch)
-	if err != nil {
-		return nil, microerror.Mask(err)
+	if options.DryRun != dryrun.Client {
+		err = s.client.Patch(ctx, appCR, patch, options.DryRun.PatchOptions()...)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
 	}
 
-	return state, nil
+	result = &PatchResult{
+		State:    state,
+		Original: original,
+		Patched:  appCR,
+	}
+
+	return result, nil
 }
 
 func (s *Service) findVersion(ctx context.Context, app *applicationv1alpha1.App, appVersion, appCatalog, appCatalogNamespace string) error {
@@ -212,16 +240,7 @@ func (s *Service) findVersion(ctx context.Context, app *applicationv1alpha1.App,
 		return microerror.Mask(err)
 	}
 
-	var storageURL string
-	if len(catalog.Spec.Repositories) > 0 {
-		// The new way - Catalogs support more than one chart repository.
-		storageURL = catalog.Spec.Repositories[0].URL
-	} else {
-		// DEPRECATED: The legacy way - failsafe in case somebody forgets to
-		// set repositories.
-		storageURL = catalog.Spec.Storage.URL
-	}
-	tarbalURL, err := appcatalog.NewTarballURL(storageURL, app.Spec.Name, appVersion)
+	tarbalURL, err := appcatalog.NewTarballURL(getStorageURL(catalog), app.Spec.Name, appVersion)
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -244,6 +263,18 @@ func (s *Service) findVersion(ctx context.Context, app *applicationv1alpha1.App,
 	return nil
 }
 
+// getStorageURL returns the URL of the chart repository of the catalog.
+func getStorageURL(catalog *applicationv1alpha1.Catalog) string {
+	if len(catalog.Spec.Repositories) > 0 {
+		// The new way - Catalogs support more than one chart repository.
+		return catalog.Spec.Repositories[0].URL
+	}
+
+	// DEPRECATED: The legacy way - failsafe in case somebody forgets to
+	// set repositories.
+	return catalog.Spec.Storage.URL
+}
+
 func (s *Service) fetchCatalog(ctx context.Context, name, namespace, selector string) (*applicationv1alpha1.Catalog, error) {
 	var err error
 
@@ -279,14 +310,21 @@ func (s *Service) fetchCatalog(ctx context.Context, name, namespace, selector st
 	return catalogCR, nil
 }
 
-func (s *Service) getAll(ctx context.Context, namespace string) (Resource, error) {
+func (s *Service) getAll(ctx context.Context, namespace, labelSelector string) (Resource, error) {
 	var err error
 
 	appCollection := &Collection{}
 
 	{
+		var selector labels.Selector
+		selector, err = labels.Parse(labelSelector)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
 		lo := &client.ListOptions{
-			Namespace: namespace,
+			Namespace:     namespace,
+			LabelSelector: selector,
 		}
 
 		apps := &applicationv1alpha1.AppList{}
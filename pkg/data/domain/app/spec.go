@@ -6,6 +6,9 @@ import (
 	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
 )
 
 // App abstracts away the custom resource so it can be returned as a runtime
@@ -28,10 +31,72 @@ type GetOptions struct {
 
 // PatchOptions are the parameters that the Patch method takes.
 type PatchOptions struct {
+	DryRun                dryrun.Strategy
 	Name                  string
 	Namespace             string
 	SuspendReconciliation bool
-	Version               string
+	// UserConfigConfigMap and UserConfigSecret replace the references to the
+	// user config of the app, if set.
+	UserConfigConfigMap *applicationv1alpha1.AppSpecUserConfigConfigMap
+	UserConfigSecret    *applicationv1alpha1.AppSpecUserConfigSecret
+	Version             string
+}
+
+// VersionConstraint limits the versions LatestVersion picks from, relative
+// to the current version of the app.
+type VersionConstraint string
+
+const (
+	// LatestAny picks the latest version, including major updates.
+	LatestAny VersionConstraint = "latest"
+	// LatestMinor picks the latest version within the current major version.
+	LatestMinor VersionConstraint = "minor"
+	// LatestPatch picks the latest version within the current minor version.
+	LatestPatch VersionConstraint = "patch"
+)
+
+// PatchResult is returned by the Patch method.
+type PatchResult struct {
+	// State lists the applied changes in a human readable form.
+	State []string
+	// Original is the app CR before patching.
+	Original *applicationv1alpha1.App
+	// Patched is the app CR after patching. In dry run mode, it has not
+	// been persisted.
+	Patched *applicationv1alpha1.App
+}
+
+// HistorySource tells where the version history of an app has been
+// reconstructed from.
+type HistorySource string
+
+const (
+	// HistorySourceHelm is the Helm release secrets in the target cluster.
+	HistorySourceHelm HistorySource = "helm"
+	// HistorySourceAnnotation is the version history annotation maintained
+	// by the Patch method.
+	HistorySourceAnnotation HistorySource = "annotation"
+)
+
+// History is the version history of an app, oldest first.
+type History struct {
+	Source  HistorySource  `json:"source"`
+	Entries []HistoryEntry `json:"entries"`
+	// Warning explains why the Helm release secrets could not be used, if
+	// the history has been reconstructed from the annotation instead.
+	Warning string `json:"warning,omitempty"`
+}
+
+// HelmStatusFailed is the status of a history entry whose deployment
+// failed.
+const HelmStatusFailed = "failed"
+
+// HistoryEntry is a version the app has been deployed with.
+type HistoryEntry struct {
+	Revision int          `json:"revision"`
+	Version  string       `json:"version"`
+	Deployed *metav1.Time `json:"deployed,omitempty"`
+	Status   string       `json:"status,omitempty"`
 }
 
 type Resource interface {
@@ -43,7 +108,12 @@ type Resource interface {
 // service in tests much simpler.
 type Interface interface {
 	Get(context.Context, GetOptions) (Resource, error)
-	Patch(context.Context, PatchOptions) ([]string, error)
+	GetClusterNames(context.Context, string) ([]string, error)
+	GetHistory(context.Context, GetOptions) (*History, error)
+	GetOutdated(context.Context, GetOptions) (*OutdatedReport, error)
+	LatestVersion(context.Context, *applicationv1alpha1.App, VersionConstraint) (string, error)
+	Patch(context.Context, PatchOptions) (*PatchResult, error)
+	Watch(context.Context, GetOptions) (<-chan watcher.Event, error)
 }
 
 func (a *App) Object() runtime.Object {
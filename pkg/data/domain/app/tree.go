@@ -0,0 +1,165 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+)
+
+const (
+	// StatusDeployed is the release status of an app deployed successfully.
+	StatusDeployed = "deployed"
+
+	// managedByCluster is the managed-by label value of the apps created by
+	// the cluster chart, like the default apps.
+	managedByCluster = "cluster"
+)
+
+// TreeNode is an app in the tree of apps built by BuildTree.
+type TreeNode struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Status    string `json:"status"`
+	// RolledUpStatus is the status of the app, unless the app is deployed
+	// and one of its descendants is not. Then it is the status of that
+	// descendant.
+	RolledUpStatus string `json:"rolledUpStatus"`
+	// Descendants and DeployedDescendants count the apps below this one.
+	Descendants         int         `json:"descendants"`
+	DeployedDescendants int         `json:"deployedDescendants"`
+	Children            []*TreeNode `json:"children,omitempty"`
+
+	CR *applicationv1alpha1.App `json:"-"`
+}
+
+// BuildTree groups apps by their owners. The owner of an app is given by
+// its giantswarm.io/managed-by label, which refers to another app in the
+// same namespace by its name, by its name without the cluster prefix, as
+// done by the bundles, or to the cluster app. Apps without an owner are
+// returned as roots, sorted by namespace and name.
+func BuildTree(apps []*applicationv1alpha1.App) []*TreeNode {
+	nodes := map[string]*TreeNode{}
+	for _, appCR := range apps {
+		nodes[treeKey(appCR.Namespace, appCR.Name)] = &TreeNode{
+			Namespace: appCR.Namespace,
+			Name:      appCR.Name,
+			Version:   appCR.Spec.Version,
+			Status:    appCR.Status.Release.Status,
+			CR:        appCR,
+		}
+	}
+
+	var roots []*TreeNode
+	for _, appCR := range apps {
+		node := nodes[treeKey(appCR.Namespace, appCR.Name)]
+
+		parent := findOwner(nodes, appCR)
+		if parent == nil || isAncestor(nodes, node, parent) {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	sortTree(roots)
+	for _, root := range roots {
+		rollUp(root)
+	}
+
+	return roots
+}
+
+// FindTreeNode returns the node of the app with the given name, or nil.
+func FindTreeNode(nodes []*TreeNode, name string) *TreeNode {
+	for _, node := range nodes {
+		if node.Name == name {
+			return node
+		}
+		if found := FindTreeNode(node.Children, name); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+func findOwner(nodes map[string]*TreeNode, appCR *applicationv1alpha1.App) *TreeNode {
+	managedBy := appCR.Labels[label.ManagedBy]
+	if managedBy == "" {
+		return nil
+	}
+
+	candidates := []string{managedBy}
+	if cluster := appCR.Labels[label.Cluster]; cluster != "" {
+		if managedBy == managedByCluster {
+			candidates = append(candidates, cluster)
+		} else {
+			candidates = append(candidates, fmt.Sprintf("%s-%s", cluster, managedBy))
+		}
+	}
+
+	for _, name := range candidates {
+		if name == appCR.Name {
+			continue
+		}
+		if node, ok := nodes[treeKey(appCR.Namespace, name)]; ok {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// isAncestor tells whether node is an ancestor of the other node, which
+// would make attaching the other node to node's tree a cycle.
+func isAncestor(nodes map[string]*TreeNode, node, other *TreeNode) bool {
+	seen := map[*TreeNode]bool{}
+	for current := other; current != nil && !seen[current]; current = findOwner(nodes, current.CR) {
+		if current == node {
+			return true
+		}
+		seen[current] = true
+	}
+
+	return false
+}
+
+func sortTree(nodes []*TreeNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Namespace != nodes[j].Namespace {
+			return nodes[i].Namespace < nodes[j].Namespace
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	for _, node := range nodes {
+		sortTree(node.Children)
+	}
+}
+
+func rollUp(node *TreeNode) {
+	node.RolledUpStatus = node.Status
+	node.Descendants = 0
+	node.DeployedDescendants = 0
+
+	for _, child := range node.Children {
+		rollUp(child)
+
+		node.Descendants += child.Descendants + 1
+		node.DeployedDescendants += child.DeployedDescendants
+		if child.Status == StatusDeployed {
+			node.DeployedDescendants++
+		}
+
+		if node.RolledUpStatus == StatusDeployed && child.RolledUpStatus != StatusDeployed {
+			node.RolledUpStatus = child.RolledUpStatus
+		}
+	}
+}
+
+func treeKey(namespace, name string) string {
+	return namespace + "/" + name
+}
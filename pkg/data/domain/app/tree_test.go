@@ -0,0 +1,103 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_BuildTree(t *testing.T) {
+	testCases := []struct {
+		name         string
+		apps         []*applicationv1alpha1.App
+		expectedTree []*TreeNode
+	}{
+		{
+			name: "bundles of a cluster",
+			apps: []*applicationv1alpha1.App{
+				newTreeApp("a01", "a01", "", "deployed"),
+				newTreeApp("a01-default-apps", "a01", "cluster", "deployed"),
+				newTreeApp("a01-security-bundle", "a01", "a01-default-apps", "deployed"),
+				newTreeApp("a01-kyverno", "a01", "security-bundle", "failed"),
+				newTreeApp("a01-trivy", "a01", "security-bundle", "deployed"),
+				newTreeApp("a01-coredns", "a01", "a01-default-apps", "deployed"),
+				newTreeApp("hello-world", "a01", "", ""),
+			},
+			expectedTree: []*TreeNode{
+				{
+					Namespace: "org-acme", Name: "a01", Version: "1.0.0", Status: "deployed", RolledUpStatus: "failed", Descendants: 5, DeployedDescendants: 4,
+					Children: []*TreeNode{
+						{
+							Namespace: "org-acme", Name: "a01-default-apps", Version: "1.0.0", Status: "deployed", RolledUpStatus: "failed", Descendants: 4, DeployedDescendants: 3,
+							Children: []*TreeNode{
+								{Namespace: "org-acme", Name: "a01-coredns", Version: "1.0.0", Status: "deployed", RolledUpStatus: "deployed"},
+								{
+									Namespace: "org-acme", Name: "a01-security-bundle", Version: "1.0.0", Status: "deployed", RolledUpStatus: "failed", Descendants: 2, DeployedDescendants: 1,
+									Children: []*TreeNode{
+										{Namespace: "org-acme", Name: "a01-kyverno", Version: "1.0.0", Status: "failed", RolledUpStatus: "failed"},
+										{Namespace: "org-acme", Name: "a01-trivy", Version: "1.0.0", Status: "deployed", RolledUpStatus: "deployed"},
+									},
+								},
+							},
+						},
+					},
+				},
+				{Namespace: "org-acme", Name: "hello-world", Version: "1.0.0"},
+			},
+		},
+		{
+			name: "apps managed by each other",
+			apps: []*applicationv1alpha1.App{
+				newTreeApp("a01-first", "a01", "a01-second", "deployed"),
+				newTreeApp("a01-second", "a01", "a01-first", "deployed"),
+				newTreeApp("a01-self", "a01", "a01-self", "deployed"),
+			},
+			expectedTree: []*TreeNode{
+				{Namespace: "org-acme", Name: "a01-first", Version: "1.0.0", Status: "deployed", RolledUpStatus: "deployed"},
+				{Namespace: "org-acme", Name: "a01-second", Version: "1.0.0", Status: "deployed", RolledUpStatus: "deployed"},
+				{Namespace: "org-acme", Name: "a01-self", Version: "1.0.0", Status: "deployed", RolledUpStatus: "deployed"},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			tree := BuildTree(tc.apps)
+
+			diff := cmp.Diff(tc.expectedTree, tree, cmpopts.IgnoreFields(TreeNode{}, "CR"))
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func newTreeApp(name, cluster, managedBy, status string) *applicationv1alpha1.App {
+	appCR := &applicationv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "org-acme",
+			Labels: map[string]string{
+				label.Cluster: cluster,
+			},
+		},
+		Spec: applicationv1alpha1.AppSpec{
+			Version: "1.0.0",
+		},
+		Status: applicationv1alpha1.AppStatus{
+			Release: applicationv1alpha1.AppStatusRelease{
+				Status: status,
+			},
+		},
+	}
+	if managedBy != "" {
+		appCR.Labels[label.ManagedBy] = managedBy
+	}
+
+	return appCR
+}
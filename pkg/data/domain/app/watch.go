@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
+)
+
+// Watch streams the changes of the app CRs matching the given options.
+func (s *Service) Watch(ctx context.Context, options GetOptions) (<-chan watcher.Event, error) {
+	selector, err := labels.Parse(options.LabelSelector)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	config := watcher.Config{
+		Client:        s.client,
+		List:          &applicationv1alpha1.AppList{},
+		Namespace:     options.Namespace,
+		Name:          options.Name,
+		LabelSelector: selector,
+		Convert: func(ctx context.Context, eventType watch.EventType, obj client.Object) (watcher.Resource, bool) {
+			cr, ok := obj.(*applicationv1alpha1.App)
+			if !ok {
+				return nil, false
+			}
+
+			return &App{CR: omitManagedFields(cr)}, true
+		},
+	}
+
+	events, err := watcher.Watch(ctx, config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return events, nil
+}
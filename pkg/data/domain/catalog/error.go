@@ -4,6 +4,15 @@ import (
 	"github.com/giantswarm/microerror"
 )
 
+var fetchError = &microerror.Error{
+	Kind: "fetchError",
+}
+
+// IsFetch asserts fetchError.
+func IsFetch(err error) bool {
+	return microerror.Cause(err) == fetchError
+}
+
 var invalidConfigError = &microerror.Error{
 	Kind: "invalidConfigError",
 }
@@ -0,0 +1,102 @@
+package catalog
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/microerror"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	requestTimeout = 30 * time.Second
+)
+
+// HTTPClient fetches files from the Helm repositories of catalogs, like
+// their index and the values schemas of their charts. Not using
+// http.DefaultClient, as it has no timeout.
+var HTTPClient = &http.Client{Timeout: requestTimeout}
+
+type IndexFile struct {
+	APIVersion string                   `yaml:"apiVersion"`
+	Entries    map[string]ChartVersions `yaml:"entries"`
+}
+
+// ChartVersions is a list of versioned chart references.
+type ChartVersions []*ChartVersion
+
+// ChartVersion represents a chart entry in the IndexFile
+type ChartVersion struct {
+	Name        string            `json:"name,omitempty"`
+	Version     string            `json:"version,omitempty"`
+	AppVersion  string            `json:"appVersion,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Keywords    []string          `json:"keywords,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	URLs        []string          `json:"urls"`
+}
+
+// FetchIndex fetches the index.yaml of the Helm repository of the catalog.
+func FetchIndex(ctx context.Context, catalog *applicationv1alpha1.Catalog) (*IndexFile, error) {
+	// Pick a repository with type="helm", since we don't know how to fetch
+	// indexes from other storage types yet.
+	var catalogURL string
+	var foundHelmRepository bool
+	for _, repo := range catalog.Spec.Repositories {
+		if repo.Type == "helm" {
+			foundHelmRepository = true
+			catalogURL = repo.URL
+			break
+		}
+	}
+	// Legacy: use deprecated .spec.storage in case .spec.repositories is empty.
+	if catalogURL == "" && !foundHelmRepository && catalog.Spec.Storage.Type == "helm" {
+		catalogURL = catalog.Spec.Storage.URL
+		foundHelmRepository = true
+	}
+	// Error for catalogs where we for sure can't fetch the index because we don't
+	// know about the storage type yet.
+	if !foundHelmRepository {
+		return nil, microerror.Maskf(fetchError, "unable to fetch index, only \"helm\" storage type is supported")
+	}
+
+	// Error for catalogs where we for sure can't fetch the index because the
+	// URL is missing in the Catalog CR.
+	if catalogURL == "" {
+		return nil, microerror.Maskf(fetchError, "unable to fetch index, the URL for the helm repo's index.yaml is missing from 'Spec.Repositories[].URL' in the Catalog CR")
+	}
+
+	// Fetch the index.
+	indexURL := strings.TrimSuffix(catalogURL, "/") + "/index.yaml"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, indexURL, nil)
+	if err != nil {
+		return nil, microerror.Maskf(fetchError, "unable to fetch index, invalid URL: %s", err.Error())
+	}
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return nil, microerror.Maskf(fetchError, "unable to fetch index, http request failed: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, microerror.Maskf(fetchError, "unable to fetch index, %s returned status %d", indexURL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, microerror.Maskf(fetchError, "unable to fetch index, error processing http response body: %s", err.Error())
+	}
+
+	index := &IndexFile{}
+	err = yaml.Unmarshal(body, index)
+	if err != nil {
+		return nil, microerror.Maskf(fetchError, "unable to fetch index, error unmarshalling body: %s", err.Error())
+	}
+
+	return index, nil
+}
@@ -7,6 +7,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
 )
 
 // Catalog abstracts away the custom resource so it can be returned as a runtime
@@ -39,6 +41,7 @@ type Resource interface {
 type Interface interface {
 	Get(context.Context, GetOptions) (Resource, error)
 	GetEntries(context.Context, string) (*applicationv1alpha1.AppCatalogEntryList, error)
+	Watch(context.Context, GetOptions) (<-chan watcher.Event, error)
 }
 
 func (a *Catalog) Object() runtime.Object {
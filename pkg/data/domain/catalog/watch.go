@@ -0,0 +1,51 @@
+package catalog
+
+import (
+	"context"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
+)
+
+// Watch streams the changes of the catalog CRs matching the given options.
+// The entries of a catalog are only fetched when watching it by name.
+func (s *Service) Watch(ctx context.Context, options GetOptions) (<-chan watcher.Event, error) {
+	config := watcher.Config{
+		Client:    s.client,
+		List:      &applicationv1alpha1.CatalogList{},
+		Namespace: options.Namespace,
+		Name:      options.Name,
+		Convert: func(ctx context.Context, eventType watch.EventType, obj client.Object) (watcher.Resource, bool) {
+			cr, ok := obj.(*applicationv1alpha1.Catalog)
+			if !ok {
+				return nil, false
+			}
+
+			// We hide catalog CRs from the giantswarm namespace by
+			// default as these are internal.
+			if options.Name == "" && options.AllNamespaces && cr.Namespace == "giantswarm" {
+				return nil, false
+			}
+
+			if options.Name != "" && eventType != watch.Deleted {
+				resource, err := s.getByName(ctx, cr.Namespace, cr.Name, options.LabelSelector)
+				if err == nil {
+					return resource, true
+				}
+			}
+
+			return &Catalog{CR: omitManagedFields(cr)}, true
+		},
+	}
+
+	events, err := watcher.Watch(ctx, config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return events, nil
+}
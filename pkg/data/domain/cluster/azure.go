@@ -6,12 +6,13 @@ import (
 	"github.com/giantswarm/microerror"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	capz "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (s *Service) getAllAzure(ctx context.Context, namespace string) (Resource, error) {
+func (s *Service) getAllAzure(ctx context.Context, namespace string, selector labels.Selector) (Resource, error) {
 	var err error
 
 	inNamespace := runtimeClient.InNamespace(namespace)
@@ -37,7 +38,7 @@ func (s *Service) getAllAzure(ctx context.Context, namespace string) (Resource,
 
 	clusters := &capi.ClusterList{}
 	{
-		err = s.client.List(ctx, clusters, inNamespace)
+		err = s.client.List(ctx, clusters, inNamespace, runtimeClient.MatchingLabelsSelector{Selector: selector})
 		if apierrors.IsForbidden(err) {
 			return nil, microerror.Mask(insufficientPermissionsError)
 		} else if err != nil {
@@ -3,12 +3,16 @@ package cluster
 import (
 	"context"
 	"encoding/json"
+	"reflect"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
 )
 
 func (s *Service) Get(ctx context.Context, options GetOptions) (Resource, error) {
@@ -21,7 +25,7 @@ func (s *Service) Get(ctx context.Context, options GetOptions) (Resource, error)
 			return nil, microerror.Mask(err)
 		}
 	} else {
-		resource, err = s.getAll(ctx, options.Provider, options.Namespace, options.FallbackToCapi)
+		resource, err = s.getAll(ctx, options.Provider, options.Namespace, options.LabelSelector, options.FallbackToCapi)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
@@ -75,32 +79,35 @@ func (s *Service) getByName(ctx context.Context, provider, name, namespace strin
 	return cluster, nil
 }
 
-func (s *Service) getAll(ctx context.Context, provider, namespace string, fallbackToCapi bool) (Resource, error) {
-	var err error
+func (s *Service) getAll(ctx context.Context, provider, namespace, labelSelector string, fallbackToCapi bool) (Resource, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
 
 	var clusterCollection Resource
 	{
 		switch provider {
 		case key.ProviderAWS:
-			clusterCollection, err = s.getAllAWS(ctx, namespace)
+			clusterCollection, err = s.getAllAWS(ctx, namespace, selector)
 			if err != nil {
 				return nil, microerror.Mask(err)
 			}
 
 		case key.ProviderAzure:
-			clusterCollection, err = s.getAllAzure(ctx, namespace)
+			clusterCollection, err = s.getAllAzure(ctx, namespace, selector)
 			if err != nil {
 				return nil, microerror.Mask(err)
 			}
 
 		case key.ProviderGCP:
-			clusterCollection, err = s.getAllGCP(ctx, namespace)
+			clusterCollection, err = s.getAllGCP(ctx, namespace, selector)
 			if err != nil {
 				return nil, microerror.Mask(err)
 			}
 
 		case key.ProviderOpenStack, key.ProviderCAPA, key.ProviderKVM, key.ProviderVSphere, key.ProviderCloudDirector:
-			clusterCollection, err = s.getAllCommonCapi(ctx, namespace)
+			clusterCollection, err = s.getAllCommonCapi(ctx, namespace, selector)
 			if err != nil {
 				return nil, microerror.Mask(err)
 			}
@@ -110,7 +117,7 @@ func (s *Service) getAll(ctx context.Context, provider, namespace string, fallba
 				return nil, microerror.Mask(invalidProviderError)
 			}
 
-			clusterCollection, err = s.getAllCommonCapi(ctx, namespace)
+			clusterCollection, err = s.getAllCommonCapi(ctx, namespace, selector)
 			if err != nil {
 				return nil, microerror.Mask(err)
 			}
@@ -120,6 +127,9 @@ func (s *Service) getAll(ctx context.Context, provider, namespace string, fallba
 	return clusterCollection, nil
 }
 
+// Patch applies the JSON patch to the given object. The object is updated
+// with the result, also in dry run mode, where the patch is either applied
+// locally or sent to the API server without being persisted.
 func (s *Service) Patch(ctx context.Context, object client.Object, options PatchOptions) error {
 	var err error
 
@@ -128,7 +138,45 @@ func (s *Service) Patch(ctx context.Context, object client.Object, options Patch
 		return microerror.Mask(err)
 	}
 
-	err = s.client.Patch(ctx, object, client.RawPatch(types.JSONPatchType, bytes))
+	if options.DryRun == dryrun.Client {
+		err = applyJSONPatch(object, bytes)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err = s.client.Patch(ctx, object, client.RawPatch(types.JSONPatchType, bytes), options.DryRun.PatchOptions()...)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func applyJSONPatch(object client.Object, patchBytes []byte) error {
+	patch, err := jsonpatch.DecodePatch(patchBytes)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	original, err := json.Marshal(object)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	patched, err := patch.Apply(original)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// Reset the object first, as unmarshalling into it would keep the map
+	// entries removed by the patch.
+	v := reflect.ValueOf(object).Elem()
+	v.Set(reflect.Zero(v.Type()))
+
+	err = json.Unmarshal(patched, object)
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -9,14 +9,15 @@ import (
 	"github.com/giantswarm/microerror"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (s *Service) getAllCommonCapi(ctx context.Context, namespace string) (Resource, error) {
+func (s *Service) getAllCommonCapi(ctx context.Context, namespace string, selector labels.Selector) (Resource, error) {
 	var clusterList capi.ClusterList
 	{
-		err := s.client.List(ctx, &clusterList, runtimeClient.InNamespace(namespace))
+		err := s.client.List(ctx, &clusterList, runtimeClient.InNamespace(namespace), runtimeClient.MatchingLabelsSelector{Selector: selector})
 		if apierrors.IsForbidden(err) {
 			return nil, microerror.Mask(insufficientPermissionsError)
 		} else if err != nil {
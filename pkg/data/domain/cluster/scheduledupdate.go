@@ -0,0 +1,46 @@
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	scheduledTimeLayout = "2006-01-02 15:04 MST"
+
+	// noScheduledRelease is shown for an update scheduled without a
+	// release version.
+	noScheduledRelease = "n/a"
+)
+
+// FormatScheduledUpdate returns the release version and time of the update
+// scheduled for the cluster, in UTC and, if different, in local time. It
+// returns an empty string if no update is scheduled.
+func FormatScheduledUpdate(res *capi.Cluster) string {
+	annotations := res.GetAnnotations()
+	targetRelease := annotations[annotation.UpdateScheduleTargetRelease]
+	targetTime := annotations[annotation.UpdateScheduleTargetTime]
+	if targetRelease == "" && targetTime == "" {
+		return ""
+	}
+	if targetRelease == "" {
+		targetRelease = noScheduledRelease
+	}
+
+	t, err := time.Parse(time.RFC822, targetTime)
+	if err != nil {
+		// Show the annotation as is, so that an invalid time can be spotted.
+		return fmt.Sprintf("%s at %s", targetRelease, targetTime)
+	}
+
+	utc := t.UTC().Format(scheduledTimeLayout)
+	local := t.Local().Format(scheduledTimeLayout)
+	if local == utc {
+		return fmt.Sprintf("%s at %s", targetRelease, utc)
+	}
+
+	return fmt.Sprintf("%s at %s (%s)", targetRelease, utc, local)
+}
@@ -10,6 +10,9 @@ import (
 	capz "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/dryrun"
 )
 
 type GetOptions struct {
@@ -17,9 +20,12 @@ type GetOptions struct {
 	Provider       string
 	Namespace      string
 	FallbackToCapi bool
+	// LabelSelector filters the clusters when listing them.
+	LabelSelector string
 }
 
 type PatchOptions struct {
+	DryRun     dryrun.Strategy
 	PatchSpecs []PatchSpec
 }
 
@@ -35,6 +41,7 @@ type PatchSpec struct {
 type Interface interface {
 	Get(context.Context, GetOptions) (Resource, error)
 	Patch(context.Context, client.Object, PatchOptions) error
+	Watch(context.Context, GetOptions) (<-chan watcher.Event, error)
 }
 
 type Resource interface {
@@ -0,0 +1,52 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
+)
+
+// Watch streams the changes of the clusters matching the given options.
+func (s *Service) Watch(ctx context.Context, options GetOptions) (<-chan watcher.Event, error) {
+	selector, err := labels.Parse(options.LabelSelector)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	config := watcher.Config{
+		Client:        s.client,
+		List:          &capi.ClusterList{},
+		Namespace:     options.Namespace,
+		Name:          options.Name,
+		LabelSelector: selector,
+		Convert: func(ctx context.Context, eventType watch.EventType, obj client.Object) (watcher.Resource, bool) {
+			cr, ok := obj.(*capi.Cluster)
+			if !ok {
+				return nil, false
+			}
+
+			if eventType != watch.Deleted {
+				// Fetch the provider specific resources along with the cluster.
+				resource, err := s.getByName(ctx, options.Provider, cr.Name, cr.Namespace, options.FallbackToCapi)
+				if err == nil {
+					return resource, true
+				}
+			}
+
+			return &Cluster{Cluster: cr}, true
+		},
+	}
+
+	events, err := watcher.Watch(ctx, config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return events, nil
+}
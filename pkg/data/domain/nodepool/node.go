@@ -0,0 +1,131 @@
+package nodepool
+
+import (
+	"context"
+	"sort"
+
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Node is a node of a node pool in the workload cluster.
+type Node struct {
+	Name         string `json:"name"`
+	Ready        bool   `json:"ready"`
+	InstanceType string `json:"instanceType,omitempty"`
+}
+
+// Nodes are the nodes of a node pool, as found in the workload cluster.
+type Nodes struct {
+	Items []Node `json:"items"`
+}
+
+// Ready returns the number of ready nodes.
+func (n *Nodes) Ready() int {
+	var ready int
+	for _, node := range n.Items {
+		if node.Ready {
+			ready++
+		}
+	}
+
+	return ready
+}
+
+// InstanceTypes returns the distinct instance types of the nodes, sorted.
+func (n *Nodes) InstanceTypes() []string {
+	seen := map[string]bool{}
+	var instanceTypes []string
+	for _, node := range n.Items {
+		if node.InstanceType == "" || seen[node.InstanceType] {
+			continue
+		}
+		seen[node.InstanceType] = true
+		instanceTypes = append(instanceTypes, node.InstanceType)
+	}
+	sort.Strings(instanceTypes)
+
+	return instanceTypes
+}
+
+// AddNodes lists the nodes of the workload cluster with the given client and
+// assigns them to the node pools. Nodes are joined by their
+// giantswarm.io/machine-deployment or giantswarm.io/machine-pool label,
+// which holds the name or ID of their node pool.
+func AddNodes(ctx context.Context, wcClient client.Client, nodePools []*Nodepool) error {
+	nodeList := &corev1.NodeList{}
+	err := wcClient.List(ctx, nodeList)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	byPool := map[string][]Node{}
+	for _, n := range nodeList.Items {
+		pool := n.Labels[label.MachineDeployment]
+		if pool == "" {
+			pool = n.Labels[label.MachinePool]
+		}
+		if pool == "" {
+			continue
+		}
+
+		byPool[pool] = append(byPool[pool], Node{
+			Name:         n.Name,
+			Ready:        isNodeReady(n),
+			InstanceType: getInstanceType(n),
+		})
+	}
+
+	for _, np := range nodePools {
+		nodes := &Nodes{}
+		for id := range np.ids() {
+			nodes.Items = append(nodes.Items, byPool[id]...)
+		}
+		sort.Slice(nodes.Items, func(i, j int) bool {
+			return nodes.Items[i].Name < nodes.Items[j].Name
+		})
+		np.Nodes = nodes
+	}
+
+	return nil
+}
+
+// ids returns the values the nodes of the node pool may carry in their
+// node pool label.
+func (n *Nodepool) ids() map[string]bool {
+	ids := map[string]bool{}
+	if n.MachineDeployment != nil {
+		ids[n.MachineDeployment.Name] = true
+		if id := n.MachineDeployment.Labels[label.MachineDeployment]; id != "" {
+			ids[id] = true
+		}
+	}
+	if n.MachinePool != nil {
+		ids[n.MachinePool.Name] = true
+		if id := n.MachinePool.Labels[label.MachinePool]; id != "" {
+			ids[id] = true
+		}
+	}
+
+	return ids
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func getInstanceType(node corev1.Node) string {
+	if instanceType := node.Labels[corev1.LabelInstanceTypeStable]; instanceType != "" {
+		return instanceType
+	}
+
+	return node.Labels[corev1.LabelInstanceType]
+}
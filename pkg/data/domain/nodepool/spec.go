@@ -4,12 +4,15 @@ import (
 	"context"
 
 	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/k8smetadata/pkg/label"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	capaexp "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	capzexp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	capiexp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
 )
 
 type GetOptions struct {
@@ -21,6 +24,7 @@ type GetOptions struct {
 
 type Interface interface {
 	Get(context.Context, GetOptions) (Resource, error)
+	Watch(context.Context, GetOptions) (<-chan watcher.Event, error)
 }
 
 type Resource interface {
@@ -37,6 +41,10 @@ type Nodepool struct {
 	AzureMachinePool      *capzexp.AzureMachinePool
 	CAPAMachinePool       *capaexp.AWSMachinePool
 	EKSManagedMachinePool *capaexp.AWSManagedMachinePool
+
+	// Nodes are the nodes of the node pool in the workload cluster. They are
+	// only known after calling AddNodes.
+	Nodes *Nodes
 }
 
 func (n *Nodepool) Object() runtime.Object {
@@ -49,6 +57,41 @@ func (n *Nodepool) Object() runtime.Object {
 	return nil
 }
 
+// ClusterName returns the name of the cluster the node pool belongs to.
+func (n *Nodepool) ClusterName() string {
+	switch {
+	case n.MachineDeployment != nil:
+		if n.MachineDeployment.Spec.ClusterName != "" {
+			return n.MachineDeployment.Spec.ClusterName
+		}
+		return n.MachineDeployment.Labels[label.Cluster]
+	case n.MachinePool != nil:
+		if n.MachinePool.Spec.ClusterName != "" {
+			return n.MachinePool.Spec.ClusterName
+		}
+		return n.MachinePool.Labels[label.Cluster]
+	}
+
+	return ""
+}
+
+// Nodepools returns the node pools of the resource, which is a single node
+// pool or a collection.
+func Nodepools(resource Resource) []*Nodepool {
+	switch r := resource.(type) {
+	case *Nodepool:
+		return []*Nodepool{r}
+	case *Collection:
+		var nodePools []*Nodepool
+		for i := range r.Items {
+			nodePools = append(nodePools, &r.Items[i])
+		}
+		return nodePools
+	}
+
+	return nil
+}
+
 // Collection wraps a list of nodepools.
 type Collection struct {
 	Items []Nodepool
@@ -0,0 +1,116 @@
+package nodepool
+
+import (
+	"context"
+
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	capiexp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/internal/key"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
+)
+
+// Watch streams the changes of the node pools matching the given options.
+// For CAPI clusters, changes of both MachineDeployments and MachinePools
+// are streamed.
+func (s *Service) Watch(ctx context.Context, options GetOptions) (<-chan watcher.Event, error) {
+	machineDeploymentEvents, err := s.watchMachineDeployments(ctx, options)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if options.Provider == key.ProviderAWS {
+		return machineDeploymentEvents, nil
+	}
+
+	machinePoolEvents, err := s.watchMachinePools(ctx, options)
+	if meta.IsNoMatchError(err) {
+		// MachinePools are not installed on the management cluster.
+		return machineDeploymentEvents, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return watcher.Merge(ctx, machineDeploymentEvents, machinePoolEvents), nil
+}
+
+func (s *Service) watchMachineDeployments(ctx context.Context, options GetOptions) (<-chan watcher.Event, error) {
+	selector := k8slabels.Set{}
+	if len(options.Name) > 0 {
+		selector[label.MachineDeployment] = options.Name
+	}
+	if len(options.ClusterName) > 0 {
+		if options.Provider == key.ProviderAWS {
+			selector[label.Cluster] = options.ClusterName
+		} else {
+			selector[capi.ClusterNameLabel] = options.ClusterName
+		}
+	}
+
+	config := watcher.Config{
+		Client:        s.client,
+		List:          &capi.MachineDeploymentList{},
+		Namespace:     options.Namespace,
+		LabelSelector: selector.AsSelector(),
+		Convert: func(ctx context.Context, eventType watch.EventType, obj client.Object) (watcher.Resource, bool) {
+			cr, ok := obj.(*capi.MachineDeployment)
+			if !ok {
+				return nil, false
+			}
+
+			if eventType != watch.Deleted && options.Provider == key.ProviderAWS {
+				// Fetch the provider specific resources along with the machine deployment.
+				resource, err := s.getByIdAWS(ctx, cr.Labels[label.MachineDeployment], cr.Namespace, options.ClusterName)
+				if err == nil {
+					return resource, true
+				}
+			}
+
+			return &Nodepool{MachineDeployment: cr}, true
+		},
+	}
+
+	events, err := watcher.Watch(ctx, config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return events, nil
+}
+
+func (s *Service) watchMachinePools(ctx context.Context, options GetOptions) (<-chan watcher.Event, error) {
+	selector := k8slabels.Set{}
+	if len(options.Name) > 0 {
+		selector[label.MachinePool] = options.Name
+	}
+	if len(options.ClusterName) > 0 {
+		selector[capi.ClusterNameLabel] = options.ClusterName
+	}
+
+	config := watcher.Config{
+		Client:        s.client,
+		List:          &capiexp.MachinePoolList{},
+		Namespace:     options.Namespace,
+		LabelSelector: selector.AsSelector(),
+		Convert: func(ctx context.Context, eventType watch.EventType, obj client.Object) (watcher.Resource, bool) {
+			cr, ok := obj.(*capiexp.MachinePool)
+			if !ok {
+				return nil, false
+			}
+
+			return &Nodepool{MachinePool: cr}, true
+		},
+	}
+
+	events, err := watcher.Watch(ctx, config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return events, nil
+}
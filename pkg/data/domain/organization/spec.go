@@ -8,6 +8,8 @@ import (
 	securityv1alpha1 "github.com/giantswarm/organization-operator/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
 )
 
 type GetOptions struct {
@@ -16,6 +18,7 @@ type GetOptions struct {
 
 type Interface interface {
 	Get(context.Context, GetOptions) (Resource, error)
+	Watch(context.Context, GetOptions) (<-chan watcher.Event, error)
 }
 
 type Resource interface {
@@ -0,0 +1,37 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	securityv1alpha1 "github.com/giantswarm/organization-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
+)
+
+// Watch streams the changes of the organizations matching the given options.
+// Unlike Get, this requires permissions to list and watch all organizations.
+func (s *Service) Watch(ctx context.Context, options GetOptions) (<-chan watcher.Event, error) {
+	config := watcher.Config{
+		Client: s.client.CtrlClient(),
+		List:   &securityv1alpha1.OrganizationList{},
+		Name:   options.Name,
+		Convert: func(ctx context.Context, eventType watch.EventType, obj client.Object) (watcher.Resource, bool) {
+			cr, ok := obj.(*securityv1alpha1.Organization)
+			if !ok {
+				return nil, false
+			}
+
+			return &Organization{Organization: omitManagedFields(cr)}, true
+		},
+	}
+
+	events, err := watcher.Watch(ctx, config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,146 @@
+package release
+
+import (
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
+)
+
+type ChangeType string
+
+const (
+	ChangeAdded      ChangeType = "added"
+	ChangeRemoved    ChangeType = "removed"
+	ChangeUpgraded   ChangeType = "upgraded"
+	ChangeDowngraded ChangeType = "downgraded"
+	ChangeChanged    ChangeType = "changed"
+	ChangeUnchanged  ChangeType = "unchanged"
+)
+
+// Diff describes the differences between the components and apps of two releases.
+type Diff struct {
+	From       string      `json:"from"`
+	To         string      `json:"to"`
+	Components []EntryDiff `json:"components"`
+	Apps       []EntryDiff `json:"apps"`
+}
+
+// EntryDiff describes how a single component or app differs between two releases.
+type EntryDiff struct {
+	Name        string     `json:"name"`
+	FromVersion string     `json:"fromVersion,omitempty"`
+	ToVersion   string     `json:"toVersion,omitempty"`
+	Change      ChangeType `json:"change"`
+	MajorBump   bool       `json:"majorBump,omitempty"`
+}
+
+// Compare returns the differences between the components and apps of two releases.
+func Compare(from, to *releasev1alpha1.Release) *Diff {
+	d := &Diff{
+		From: from.GetName(),
+		To:   to.GetName(),
+	}
+
+	{
+		fromVersions := map[string]string{}
+		for _, c := range from.Spec.Components {
+			fromVersions[c.Name] = c.Version
+		}
+		toVersions := map[string]string{}
+		for _, c := range to.Spec.Components {
+			toVersions[c.Name] = c.Version
+		}
+		d.Components = compareVersions(fromVersions, toVersions)
+	}
+
+	{
+		fromVersions := map[string]string{}
+		for _, a := range from.Spec.Apps {
+			fromVersions[a.Name] = a.Version
+		}
+		toVersions := map[string]string{}
+		for _, a := range to.Spec.Apps {
+			toVersions[a.Name] = a.Version
+		}
+		d.Apps = compareVersions(fromVersions, toVersions)
+	}
+
+	return d
+}
+
+// HasChanges returns true if any component or app differs between the releases.
+func (d *Diff) HasChanges() bool {
+	for _, e := range append(d.Components, d.Apps...) {
+		if e.Change != ChangeUnchanged {
+			return true
+		}
+	}
+
+	return false
+}
+
+func compareVersions(from, to map[string]string) []EntryDiff {
+	var entries []EntryDiff
+
+	for name, fromVersion := range from {
+		toVersion, exists := to[name]
+		if !exists {
+			entries = append(entries, EntryDiff{
+				Name:        name,
+				FromVersion: fromVersion,
+				Change:      ChangeRemoved,
+			})
+			continue
+		}
+
+		entries = append(entries, compareVersion(name, fromVersion, toVersion))
+	}
+
+	for name, toVersion := range to {
+		if _, exists := from[name]; !exists {
+			entries = append(entries, EntryDiff{
+				Name:      name,
+				ToVersion: toVersion,
+				Change:    ChangeAdded,
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries
+}
+
+func compareVersion(name, fromVersion, toVersion string) EntryDiff {
+	e := EntryDiff{
+		Name:        name,
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Change:      ChangeUnchanged,
+	}
+
+	if fromVersion == toVersion {
+		return e
+	}
+
+	fromSemver, fromErr := semver.NewVersion(fromVersion)
+	toSemver, toErr := semver.NewVersion(toVersion)
+	if fromErr != nil || toErr != nil {
+		// Versions we cannot compare are only reported as changed.
+		e.Change = ChangeChanged
+		return e
+	}
+
+	switch fromSemver.Compare(toSemver) {
+	case -1:
+		e.Change = ChangeUpgraded
+		e.MajorBump = toSemver.Major() > fromSemver.Major()
+	case 1:
+		e.Change = ChangeDowngraded
+	}
+
+	return e
+}
@@ -0,0 +1,150 @@
+package release
+
+import (
+	"testing"
+
+	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Compare(t *testing.T) {
+	testCases := []struct {
+		name               string
+		from               *releasev1alpha1.Release
+		to                 *releasev1alpha1.Release
+		expectedComponents []EntryDiff
+		expectedApps       []EntryDiff
+		expectChanges      bool
+	}{
+		{
+			name: "case 0: identical releases",
+			from: newRelease("aws-25.0.0", map[string]string{"kubernetes": "1.25.16"}, map[string]string{"cilium": "0.9.0"}),
+			to:   newRelease("aws-25.0.0", map[string]string{"kubernetes": "1.25.16"}, map[string]string{"cilium": "0.9.0"}),
+			expectedComponents: []EntryDiff{
+				{Name: "kubernetes", FromVersion: "1.25.16", ToVersion: "1.25.16", Change: ChangeUnchanged},
+			},
+			expectedApps: []EntryDiff{
+				{Name: "cilium", FromVersion: "0.9.0", ToVersion: "0.9.0", Change: ChangeUnchanged},
+			},
+		},
+		{
+			name: "case 1: added, removed, upgraded and downgraded entries",
+			from: newRelease("aws-25.0.0",
+				map[string]string{"kubernetes": "1.25.16", "flatcar": "3815.2.0", "os-tooling": "1.0.0"},
+				map[string]string{"cilium": "0.9.0", "coredns": "1.21.0", "net-exporter": "1.18.0"},
+			),
+			to: newRelease("aws-26.0.0",
+				map[string]string{"kubernetes": "1.26.11", "flatcar": "3815.2.0"},
+				map[string]string{"cilium": "1.0.0", "coredns": "1.20.0", "observability-bundle": "1.2.0", "net-exporter": "1.18.0-dev"},
+			),
+			expectedComponents: []EntryDiff{
+				{Name: "flatcar", FromVersion: "3815.2.0", ToVersion: "3815.2.0", Change: ChangeUnchanged},
+				{Name: "kubernetes", FromVersion: "1.25.16", ToVersion: "1.26.11", Change: ChangeUpgraded},
+				{Name: "os-tooling", FromVersion: "1.0.0", Change: ChangeRemoved},
+			},
+			expectedApps: []EntryDiff{
+				{Name: "cilium", FromVersion: "0.9.0", ToVersion: "1.0.0", Change: ChangeUpgraded, MajorBump: true},
+				{Name: "coredns", FromVersion: "1.21.0", ToVersion: "1.20.0", Change: ChangeDowngraded},
+				{Name: "net-exporter", FromVersion: "1.18.0", ToVersion: "1.18.0-dev", Change: ChangeDowngraded},
+				{Name: "observability-bundle", ToVersion: "1.2.0", Change: ChangeAdded},
+			},
+			expectChanges: true,
+		},
+		{
+			name: "case 2: versions which are not semver",
+			from: newRelease("aws-25.0.0", map[string]string{"kubernetes": "latest"}, nil),
+			to:   newRelease("aws-26.0.0", map[string]string{"kubernetes": "1.26.11"}, nil),
+			expectedComponents: []EntryDiff{
+				{Name: "kubernetes", FromVersion: "latest", ToVersion: "1.26.11", Change: ChangeChanged},
+			},
+			expectChanges: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := Compare(tc.from, tc.to)
+
+			if d.From != tc.from.Name || d.To != tc.to.Name {
+				t.Fatalf("unexpected release names %s and %s", d.From, d.To)
+			}
+			if diff := cmp.Diff(tc.expectedComponents, d.Components); diff != "" {
+				t.Fatalf("components not expected, got:\n %s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedApps, d.Apps); diff != "" {
+				t.Fatalf("apps not expected, got:\n %s", diff)
+			}
+			if d.HasChanges() != tc.expectChanges {
+				t.Fatalf("expected changes to be %t", tc.expectChanges)
+			}
+		})
+	}
+}
+
+func Test_DiffTable(t *testing.T) {
+	d := &Diff{
+		From: "v25.0.0",
+		To:   "v26.0.0",
+		Components: []EntryDiff{
+			{Name: "flatcar", FromVersion: "3815.2.0", ToVersion: "3815.2.0", Change: ChangeUnchanged},
+			{Name: "kubernetes", FromVersion: "1.25.16", ToVersion: "2.0.0", Change: ChangeUpgraded, MajorBump: true},
+		},
+		Apps: []EntryDiff{
+			{Name: "coredns", ToVersion: "1.21.0", Change: ChangeAdded},
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		includeUnchanged bool
+		expectedRows     [][]interface{}
+	}{
+		{
+			name: "case 0: changes only",
+			expectedRows: [][]interface{}{
+				{"component", "kubernetes", "1.25.16", "2.0.0", "upgraded (major)"},
+				{"app", "coredns", "n/a", "1.21.0", "added"},
+			},
+		},
+		{
+			name:             "case 1: including unchanged entries",
+			includeUnchanged: true,
+			expectedRows: [][]interface{}{
+				{"component", "flatcar", "3815.2.0", "3815.2.0", "unchanged"},
+				{"component", "kubernetes", "1.25.16", "2.0.0", "upgraded (major)"},
+				{"app", "coredns", "n/a", "1.21.0", "added"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			table := d.Table(tc.includeUnchanged)
+
+			var rows [][]interface{}
+			for _, row := range table.Rows {
+				rows = append(rows, row.Cells)
+			}
+			if diff := cmp.Diff(tc.expectedRows, rows); diff != "" {
+				t.Fatalf("rows not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func newRelease(name string, components, apps map[string]string) *releasev1alpha1.Release {
+	r := &releasev1alpha1.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	for n, v := range components {
+		r.Spec.Components = append(r.Spec.Components, releasev1alpha1.ReleaseSpecComponent{Name: n, Version: v})
+	}
+	for n, v := range apps {
+		r.Spec.Apps = append(r.Spec.Apps, releasev1alpha1.ReleaseSpecApp{Name: n, Version: v})
+	}
+
+	return r
+}
@@ -0,0 +1,106 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	entryTypeComponent = "component"
+	entryTypeApp       = "app"
+
+	// noVersion is shown for the version of a component or app which is
+	// not part of a release.
+	noVersion = "n/a"
+)
+
+// Table returns the components and apps of the diff as a table, for a table
+// printer. Unchanged components and apps are left out, unless
+// includeUnchanged is true.
+func (d *Diff) Table(includeUnchanged bool) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Type", Type: "string"},
+			{Name: "Name", Type: "string"},
+			{Name: d.From, Type: "string"},
+			{Name: d.To, Type: "string"},
+			{Name: "Change", Type: "string"},
+		},
+	}
+
+	entries := []struct {
+		entryType string
+		diffs     []EntryDiff
+	}{
+		{entryType: entryTypeComponent, diffs: d.Components},
+		{entryType: entryTypeApp, diffs: d.Apps},
+	}
+	for _, entry := range entries {
+		for _, e := range entry.diffs {
+			if e.Change == ChangeUnchanged && !includeUnchanged {
+				continue
+			}
+
+			table.Rows = append(table.Rows, metav1.TableRow{
+				Cells: []interface{}{
+					entry.entryType,
+					e.Name,
+					versionOrNoVersion(e.FromVersion),
+					versionOrNoVersion(e.ToVersion),
+					e.formatChange(),
+				},
+			})
+		}
+	}
+
+	return table
+}
+
+// Markdown returns the components and apps of the diff as Markdown tables,
+// e.g. for release notes.
+func (d *Diff) Markdown() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "## Changes from %s to %s\n", d.From, d.To)
+
+	sections := []struct {
+		title   string
+		entries []EntryDiff
+	}{
+		{title: "Components", entries: d.Components},
+		{title: "Apps", entries: d.Apps},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(&sb, "\n### %s\n\n", section.title)
+		fmt.Fprintf(&sb, "| Name | %s | %s | Change |\n", d.From, d.To)
+		sb.WriteString("| --- | --- | --- | --- |\n")
+
+		for _, e := range section.entries {
+			change := e.formatChange()
+			if e.MajorBump {
+				change = fmt.Sprintf("**%s**", change)
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n", e.Name, versionOrNoVersion(e.FromVersion), versionOrNoVersion(e.ToVersion), change)
+		}
+	}
+
+	return sb.String()
+}
+
+func (e EntryDiff) formatChange() string {
+	if e.MajorBump {
+		return fmt.Sprintf("%s (major)", e.Change)
+	}
+
+	return string(e.Change)
+}
+
+func versionOrNoVersion(version string) string {
+	if version == "" {
+		return noVersion
+	}
+
+	return version
+}
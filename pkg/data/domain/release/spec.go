@@ -6,6 +6,8 @@ import (
 	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
 )
 
 type GetOptions struct {
@@ -17,6 +19,7 @@ type GetOptions struct {
 
 type Interface interface {
 	Get(context.Context, GetOptions) (Resource, error)
+	Watch(context.Context, GetOptions) (<-chan watcher.Event, error)
 }
 
 type Resource interface {
@@ -0,0 +1,41 @@
+package release
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	releasev1alpha1 "github.com/giantswarm/release-operator/v4/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/watcher"
+)
+
+// Watch streams the changes of the releases matching the given options.
+func (s *Service) Watch(ctx context.Context, options GetOptions) (<-chan watcher.Event, error) {
+	config := watcher.Config{
+		Client:    s.client,
+		List:      &releasev1alpha1.ReleaseList{},
+		Namespace: options.Namespace,
+		Name:      options.Name,
+		Convert: func(ctx context.Context, eventType watch.EventType, obj client.Object) (watcher.Resource, bool) {
+			cr, ok := obj.(*releasev1alpha1.Release)
+			if !ok {
+				return nil, false
+			}
+
+			if options.ActiveOnly && !cr.Status.Ready && eventType != watch.Deleted {
+				return nil, false
+			}
+
+			return &Release{CR: omitManagedFields(cr)}, true
+		},
+	}
+
+	events, err := watcher.Watch(ctx, config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,23 @@
+package watcher
+
+import (
+	"github.com/giantswarm/microerror"
+)
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var watchNotSupportedError = &microerror.Error{
+	Kind: "watchNotSupportedError",
+}
+
+// IsWatchNotSupported asserts watchNotSupportedError.
+func IsWatchNotSupported(err error) bool {
+	return microerror.Cause(err) == watchNotSupportedError
+}
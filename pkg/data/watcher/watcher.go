@@ -0,0 +1,203 @@
+// Package watcher streams changes of the resources behind the domain
+// services in pkg/data/domain.
+package watcher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Resource is implemented by the resources of all domain services.
+type Resource interface {
+	Object() runtime.Object
+}
+
+// Event describes a change of a watched resource. Events of type
+// watch.Error carry the error which ended the watch.
+type Event struct {
+	Type     watch.EventType
+	Resource Resource
+	Err      error
+}
+
+// ConvertFunc turns a watched object into the resource of a domain service.
+// Returning false drops the event.
+type ConvertFunc func(ctx context.Context, eventType watch.EventType, obj client.Object) (Resource, bool)
+
+type Config struct {
+	Client client.Client
+	// List is the list type of the watched objects, e.g. &capi.ClusterList{}.
+	List          client.ObjectList
+	Namespace     string
+	Name          string
+	LabelSelector labels.Selector
+	Convert       ConvertFunc
+}
+
+// Watch streams all changes of the matching objects, which happen after the
+// call. The channel is closed when the context is cancelled, or when the
+// watch ends.
+func Watch(ctx context.Context, config Config) (<-chan Event, error) {
+	if config.List == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.List must not be empty", config)
+	}
+	if config.Convert == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Convert must not be empty", config)
+	}
+
+	c, ok := config.Client.(client.WithWatch)
+	if !ok {
+		return nil, microerror.Maskf(watchNotSupportedError, "The client does not support watching resources.")
+	}
+
+	options := []client.ListOption{
+		client.InNamespace(config.Namespace),
+	}
+	if config.LabelSelector != nil {
+		options = append(options, client.MatchingLabelsSelector{Selector: config.LabelSelector})
+	}
+
+	// Listing first gives us the resource version to start watching from,
+	// so that only changes are streamed, not the existing objects.
+	err := c.List(ctx, config.List, options...)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	options = append(options, &client.ListOptions{
+		Raw: &metav1.ListOptions{
+			ResourceVersion: config.List.GetResourceVersion(),
+		},
+	})
+
+	w, err := c.Watch(ctx, config.List, options...)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+		defer w.Stop()
+
+		for {
+			var e watch.Event
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok = <-w.ResultChan():
+				if !ok {
+					return
+				}
+			}
+
+			var event Event
+			switch e.Type {
+			case watch.Added, watch.Modified, watch.Deleted:
+				obj, ok := e.Object.(client.Object)
+				if !ok || !matches(config, obj) {
+					continue
+				}
+
+				setGVK(c.Scheme(), obj)
+
+				resource, ok := config.Convert(ctx, e.Type, obj)
+				if !ok {
+					continue
+				}
+				event = Event{
+					Type:     e.Type,
+					Resource: resource,
+				}
+
+			case watch.Error:
+				event = Event{
+					Type: watch.Error,
+					Err:  apierrors.FromObject(e.Object),
+				}
+
+			default:
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case events <- event:
+			}
+
+			if event.Type == watch.Error {
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// Merge streams the events of all given channels in one. The channel is
+// closed once all given channels are closed, or when the context is
+// cancelled.
+func Merge(ctx context.Context, channels ...<-chan Event) <-chan Event {
+	events := make(chan Event)
+
+	var wg sync.WaitGroup
+	wg.Add(len(channels))
+	for _, ch := range channels {
+		go func(ch <-chan Event) {
+			defer wg.Done()
+			for event := range ch {
+				select {
+				case <-ctx.Done():
+					return
+				case events <- event:
+				}
+			}
+		}(ch)
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return events
+}
+
+// matches filters the objects once more on the client side, as not every
+// client applies the list options to watches.
+func matches(config Config, obj client.Object) bool {
+	if config.Namespace != "" && obj.GetNamespace() != config.Namespace {
+		return false
+	}
+	if config.Name != "" && obj.GetName() != config.Name {
+		return false
+	}
+	if config.LabelSelector != nil && !config.LabelSelector.Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+
+	return true
+}
+
+// setGVK restores the type information, which is dropped when decoding watch events.
+func setGVK(scheme *runtime.Scheme, obj client.Object) {
+	if !obj.GetObjectKind().GroupVersionKind().Empty() {
+		return
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+}
@@ -0,0 +1,233 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type configMap struct {
+	cr *corev1.ConfigMap
+}
+
+func (c *configMap) Object() runtime.Object {
+	return c.cr
+}
+
+func Test_Watch(t *testing.T) {
+	testCases := []struct {
+		name           string
+		storage        []runtime.Object
+		namespace      string
+		objectName     string
+		labelSelector  string
+		changes        func(ctx context.Context, c client.Client) error
+		expectedEvents []string
+	}{
+		{
+			name: "case 0: stream created, updated and deleted objects",
+			changes: func(ctx context.Context, c client.Client) error {
+				cm := newConfigMap("default", "a", nil)
+				err := c.Create(ctx, cm)
+				if err != nil {
+					return err
+				}
+
+				cm.Data = map[string]string{"key": "value"}
+				err = c.Update(ctx, cm)
+				if err != nil {
+					return err
+				}
+
+				return c.Delete(ctx, cm)
+			},
+			expectedEvents: []string{
+				"ADDED default/a",
+				"MODIFIED default/a",
+				"DELETED default/a",
+			},
+		},
+		{
+			name: "case 1: existing objects are not streamed",
+			storage: []runtime.Object{
+				newConfigMap("default", "a", nil),
+			},
+			changes: func(ctx context.Context, c client.Client) error {
+				return c.Create(ctx, newConfigMap("default", "b", nil))
+			},
+			expectedEvents: []string{
+				"ADDED default/b",
+			},
+		},
+		{
+			name:       "case 2: filter by namespace and name",
+			namespace:  "org-test",
+			objectName: "b",
+			changes: func(ctx context.Context, c client.Client) error {
+				for _, cm := range []*corev1.ConfigMap{
+					newConfigMap("default", "b", nil),
+					newConfigMap("org-test", "a", nil),
+					newConfigMap("org-test", "b", nil),
+				} {
+					err := c.Create(ctx, cm)
+					if err != nil {
+						return err
+					}
+				}
+
+				return nil
+			},
+			expectedEvents: []string{
+				"ADDED org-test/b",
+			},
+		},
+		{
+			name:          "case 3: filter by label selector",
+			labelSelector: "app=test",
+			changes: func(ctx context.Context, c client.Client) error {
+				for _, cm := range []*corev1.ConfigMap{
+					newConfigMap("default", "a", map[string]string{"app": "other"}),
+					newConfigMap("default", "b", map[string]string{"app": "test"}),
+					newConfigMap("default", "c", nil),
+				} {
+					err := c.Create(ctx, cm)
+					if err != nil {
+						return err
+					}
+				}
+
+				return nil
+			},
+			expectedEvents: []string{
+				"ADDED default/b",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			c := fake.NewClientBuilder().WithRuntimeObjects(tc.storage...).Build()
+
+			selector, err := labels.Parse(tc.labelSelector)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			config := Config{
+				Client:        c,
+				List:          &corev1.ConfigMapList{},
+				Namespace:     tc.namespace,
+				Name:          tc.objectName,
+				LabelSelector: selector,
+				Convert: func(ctx context.Context, eventType watch.EventType, obj client.Object) (Resource, bool) {
+					cr, ok := obj.(*corev1.ConfigMap)
+					if !ok {
+						return nil, false
+					}
+
+					return &configMap{cr: cr}, true
+				},
+			}
+
+			events, err := Watch(ctx, config)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			err = tc.changes(ctx, c)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			var result []string
+			for len(result) < len(tc.expectedEvents) {
+				select {
+				case event, ok := <-events:
+					if !ok {
+						t.Fatalf("channel closed after %d events", len(result))
+					}
+					cm := event.Resource.(*configMap).cr
+					result = append(result, fmt.Sprintf("%s %s/%s", event.Type, cm.Namespace, cm.Name))
+				case <-ctx.Done():
+					t.Fatalf("timed out after %d events", len(result))
+				}
+			}
+
+			diff := cmp.Diff(tc.expectedEvents, result)
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+
+			cancel()
+			for range events {
+				// The channel must be closed once the context is cancelled.
+			}
+		})
+	}
+}
+
+func Test_Watch_NotSupported(t *testing.T) {
+	c := struct{ client.Client }{fake.NewClientBuilder().Build()}
+
+	_, err := Watch(context.Background(), Config{
+		Client: c,
+		List:   &corev1.ConfigMapList{},
+		Convert: func(ctx context.Context, eventType watch.EventType, obj client.Object) (Resource, bool) {
+			return nil, false
+		},
+	})
+	if !IsWatchNotSupported(err) {
+		t.Fatalf("expected watch not supported error, got: %v", err)
+	}
+}
+
+func Test_Merge(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	a := make(chan Event)
+	b := make(chan Event)
+	go func() {
+		a <- Event{Type: watch.Added}
+		close(a)
+	}()
+	go func() {
+		b <- Event{Type: watch.Deleted}
+		close(b)
+	}()
+
+	var result []string
+	for event := range Merge(ctx, a, b) {
+		result = append(result, string(event.Type))
+	}
+	sort.Strings(result)
+
+	diff := cmp.Diff([]string{"ADDED", "DELETED"}, result)
+	if diff != "" {
+		t.Fatalf("value not expected, got:\n %s", diff)
+	}
+}
+
+func newConfigMap(namespace, name string, l map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    l,
+		},
+	}
+}
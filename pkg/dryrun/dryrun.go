@@ -0,0 +1,157 @@
+// Package dryrun helps commands preview their changes, by either computing
+// them locally or by sending the requests to the API server in dry run mode.
+package dryrun
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// Strategy defines whether and how changes are previewed, following the
+// semantics of kubectl's --dry-run flag.
+type Strategy string
+
+const (
+	// None applies all changes.
+	None Strategy = "none"
+	// Client computes the changes locally, without sending them to the API
+	// server.
+	Client Strategy = "client"
+	// Server sends the changes to the API server, which validates them
+	// and runs admission, without persisting them.
+	Server Strategy = "server"
+)
+
+// Strategies lists all valid values of the --dry-run flag.
+var Strategies = []string{string(None), string(Client), string(Server)}
+
+// Parse validates the value of a --dry-run flag.
+func Parse(value string) (Strategy, error) {
+	switch Strategy(value) {
+	case "":
+		return None, nil
+	case None, Client, Server:
+		return Strategy(value), nil
+	}
+
+	return None, microerror.Maskf(invalidStrategyError, "dry run strategy must be one of %q, %q or %q, got %q", None, Client, Server, value)
+}
+
+// IsDryRun returns true if changes must not be persisted.
+func (s Strategy) IsDryRun() bool {
+	return s == Client || s == Server
+}
+
+// PatchOptions returns the options to send patches with.
+func (s Strategy) PatchOptions() []client.PatchOption {
+	if s == Server {
+		return []client.PatchOption{client.DryRunAll}
+	}
+
+	return nil
+}
+
+// UpdateOptions returns the options to send updates with.
+func (s Strategy) UpdateOptions() []client.UpdateOption {
+	if s == Server {
+		return []client.UpdateOption{client.DryRunAll}
+	}
+
+	return nil
+}
+
+// CreateOptions returns the options to create objects with.
+func (s Strategy) CreateOptions() []client.CreateOption {
+	if s == Server {
+		return []client.CreateOption{client.DryRunAll}
+	}
+
+	return nil
+}
+
+// Suffix is appended to messages reporting changes, like kubectl does.
+func (s Strategy) Suffix() string {
+	switch s {
+	case Client:
+		return " (dry run)"
+	case Server:
+		return " (server dry run)"
+	}
+
+	return ""
+}
+
+// PrintDiff prints the unified diff between the YAML representations of
+// the current and the resulting object. Managed fields are left out, as
+// they only add noise. A nil current object stands for an object that is
+// about to be created.
+func PrintDiff(out io.Writer, name string, current, result runtime.Object) error {
+	a, err := toYAML(current)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	b, err := toYAML(result)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(a),
+		B:        splitLines(b),
+		FromFile: fmt.Sprintf("%s (current)", name),
+		ToFile:   fmt.Sprintf("%s (updated)", name),
+		Context:  3,
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if diff == "" {
+		fmt.Fprintf(out, "No changes to %s.\n", name)
+		return nil
+	}
+
+	fmt.Fprint(out, diff)
+
+	return nil
+}
+
+// splitLines splits the YAML document into lines, without the empty line
+// difflib.SplitLines adds after the trailing newline.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return difflib.SplitLines(strings.TrimSuffix(s, "\n"))
+}
+
+func toYAML(obj runtime.Object) (string, error) {
+	if obj == nil || reflect.ValueOf(obj).IsNil() {
+		return "", nil
+	}
+
+	obj = obj.DeepCopyObject()
+
+	accessor, err := meta.Accessor(obj)
+	if err == nil {
+		accessor.SetManagedFields(nil)
+	}
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return string(data), nil
+}
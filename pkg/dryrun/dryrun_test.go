@@ -0,0 +1,128 @@
+package dryrun
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Parse(t *testing.T) {
+	testCases := []struct {
+		value            string
+		expectedStrategy Strategy
+		errorMatcher     func(error) bool
+	}{
+		{value: "", expectedStrategy: None},
+		{value: "none", expectedStrategy: None},
+		{value: "client", expectedStrategy: Client},
+		{value: "server", expectedStrategy: Server},
+		{value: "true", errorMatcher: IsInvalidStrategy},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %q", i, tc.value), func(t *testing.T) {
+			strategy, err := Parse(tc.value)
+			if tc.errorMatcher != nil {
+				if !tc.errorMatcher(err) {
+					t.Fatalf("error not matching expected matcher, got: %v", err)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if strategy != tc.expectedStrategy {
+				t.Fatalf("expected %q, got %q", tc.expectedStrategy, strategy)
+			}
+		})
+	}
+}
+
+func Test_PrintDiff(t *testing.T) {
+	testCases := []struct {
+		name           string
+		current        *corev1.ConfigMap
+		result         *corev1.ConfigMap
+		expectedOutput string
+	}{
+		{
+			name:    "case 0: changed values",
+			current: newConfigMap("global:\n  release:\n    version: 27.0.0\n"),
+			result:  newConfigMap("global:\n  release:\n    version: 28.1.0\n"),
+			expectedOutput: `--- configmap default/test1-userconfig (current)
++++ configmap default/test1-userconfig (updated)
+@@ -3,7 +3,7 @@
+   values: |
+     global:
+       release:
+-        version: 27.0.0
++        version: 28.1.0
+ kind: ConfigMap
+ metadata:
+   creationTimestamp: null
+`,
+		},
+		{
+			name:           "case 1: no changes",
+			current:        newConfigMap("global: {}\n"),
+			result:         newConfigMap("global: {}\n"),
+			expectedOutput: "No changes to configmap default/test1-userconfig.\n",
+		},
+		{
+			name:   "case 2: created object",
+			result: newConfigMap("global: {}\n"),
+			expectedOutput: `--- configmap default/test1-userconfig (current)
++++ configmap default/test1-userconfig (updated)
+@@ -0,0 +1,9 @@
++apiVersion: v1
++data:
++  values: |
++    global: {}
++kind: ConfigMap
++metadata:
++  creationTimestamp: null
++  name: test1-userconfig
++  namespace: default
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			err := PrintDiff(out, "configmap default/test1-userconfig", tc.current, tc.result)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			diff := cmp.Diff(tc.expectedOutput, out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
+
+func newConfigMap(values string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1-userconfig",
+			Namespace: "default",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kubectl"},
+			},
+		},
+		Data: map[string]string{
+			"values": values,
+		},
+	}
+}
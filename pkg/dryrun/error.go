@@ -0,0 +1,14 @@
+package dryrun
+
+import (
+	"github.com/giantswarm/microerror"
+)
+
+var invalidStrategyError = &microerror.Error{
+	Kind: "invalidStrategyError",
+}
+
+// IsInvalidStrategy asserts invalidStrategyError.
+func IsInvalidStrategy(err error) bool {
+	return microerror.Cause(err) == invalidStrategyError
+}
@@ -64,3 +64,11 @@ func (ep *ErrorPrinter) formatBody(body string) string {
 
 	return body
 }
+
+// FormatSingleLine returns the message of an error on a single line, without
+// prefix, colors and stack trace, e.g. for a table or a report.
+func FormatSingleLine(err error) string {
+	message := strings.TrimSpace(microerror.Pretty(err, false))
+
+	return strings.ReplaceAll(message, "\n", " ")
+}
@@ -210,3 +210,33 @@ func TestFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatSingleLine(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedResult string
+	}{
+		{
+			name:           "case 0: generic error",
+			err:            errors.New("something went wrong"),
+			expectedResult: "Something went wrong",
+		},
+		{
+			name: "case 1: custom microerror, with additional multiline message",
+			err: microerror.Maskf(&microerror.Error{
+				Kind: "somethingWentWrongError",
+			}, "something went wrong\nwhile doing something"),
+			expectedResult: "Something went wrong: something went wrong while doing something",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FormatSingleLine(tc.err)
+			if result != tc.expectedResult {
+				t.Fatalf("expected %q, got %q", tc.expectedResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package installation
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultCacheTTL is how long cached installation info is used before it
+	// is fetched from Athena again.
+	DefaultCacheTTL = 24 * time.Hour
+
+	cacheSubDir   = "installations"
+	cacheFileMode = 0600
+)
+
+// CacheConfig configures the on-disk cache of installation info.
+type CacheConfig struct {
+	// Dir is the cache directory of kubectl-gs.
+	Dir string
+	// TTL is how long cached installation info is used.
+	TTL time.Duration
+	// Refresh ignores cached installation info, unless Athena cannot be
+	// reached.
+	Refresh bool
+	// Warnings receives a warning whenever cached installation info is used
+	// because Athena cannot be reached.
+	Warnings io.Writer
+}
+
+// cacheEntry is the installation info stored in the cache.
+type cacheEntry struct {
+	K8sApiURL         string    `json:"k8sApiURL"`
+	K8sInternalApiURL string    `json:"k8sInternalApiURL"`
+	AuthURL           string    `json:"authURL"`
+	Provider          string    `json:"provider"`
+	Codename          string    `json:"codename"`
+	CACert            string    `json:"caCert"`
+	FetchedAt         time.Time `json:"fetchedAt"`
+}
+
+// NewFromCache returns the installation info like New, but reads it from the
+// on-disk cache as long as it is fresh. If Athena cannot be reached, cached
+// installation info is used regardless of its age.
+func NewFromCache(ctx context.Context, fromUrl, customAthenaUrl string, config CacheConfig) (*Installation, error) {
+	basePath, _, _, err := GetBaseAndInternalPath(fromUrl)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	path := cachePath(config.Dir, basePath, customAthenaUrl)
+
+	entry, cacheErr := loadCacheEntry(path)
+	if cacheErr == nil && !config.Refresh && time.Since(entry.FetchedAt) < config.TTL {
+		return entry.toInstallation(fromUrl), nil
+	}
+
+	i, err := New(ctx, fromUrl, customAthenaUrl)
+	if IsCannotGetInstallationInfo(err) && cacheErr == nil {
+		if config.Warnings != nil {
+			fmt.Fprint(config.Warnings, color.YellowString("Warning: The installation info of %s cannot be fetched from Athena. Using the cached info from %s, which may be outdated.\n", basePath, entry.FetchedAt.Local().Format(time.RFC1123)))
+		}
+
+		return entry.toInstallation(fromUrl), nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// The cache only saves time, so failing to write it is not an error.
+	_ = persistCacheEntry(path, cacheEntry{
+		K8sApiURL:         i.K8sApiURL,
+		K8sInternalApiURL: i.K8sInternalApiURL,
+		AuthURL:           i.AuthURL,
+		Provider:          i.Provider,
+		Codename:          i.Codename,
+		CACert:            i.CACert,
+		FetchedAt:         time.Now().UTC(),
+	})
+
+	return i, nil
+}
+
+func (e cacheEntry) toInstallation(fromUrl string) *Installation {
+	return &Installation{
+		K8sApiURL:         e.K8sApiURL,
+		K8sInternalApiURL: e.K8sInternalApiURL,
+		AuthURL:           e.AuthURL,
+		Provider:          e.Provider,
+		Codename:          e.Codename,
+		CACert:            e.CACert,
+		SourcePath:        fromUrl,
+	}
+}
+
+// cachePath returns the location of the cache file for the API URL of an
+// installation, given by its base path.
+func cachePath(cacheDir, basePath, customAthenaUrl string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s", basePath, customAthenaUrl)))
+
+	return filepath.Join(cacheDir, cacheSubDir, fmt.Sprintf("%x.yaml", sum[:16]))
+}
+
+func loadCacheEntry(path string) (cacheEntry, error) {
+	serialized, err := os.ReadFile(path)
+	if err != nil {
+		return cacheEntry{}, microerror.Mask(err)
+	}
+
+	var entry cacheEntry
+	err = yaml.Unmarshal(serialized, &entry)
+	if err != nil {
+		return cacheEntry{}, microerror.Mask(err)
+	}
+
+	return entry, nil
+}
+
+func persistCacheEntry(path string, entry cacheEntry) error {
+	serialized, err := yaml.Marshal(entry)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// Installations may be resolved concurrently, e.g. with login
+	// --all-from, so the entry is written to a temporary file first and
+	// then renamed, for readers to never see a partially written entry.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	defer func() {
+		// Only left behind when writing or renaming failed.
+		_ = os.Remove(tmp.Name())
+	}()
+
+	_, err = tmp.Write(serialized)
+	if err != nil {
+		_ = tmp.Close()
+		return microerror.Mask(err)
+	}
+	err = tmp.Chmod(os.FileMode(cacheFileMode))
+	if err != nil {
+		_ = tmp.Close()
+		return microerror.Mask(err)
+	}
+	err = tmp.Close()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
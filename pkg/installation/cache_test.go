@@ -0,0 +1,161 @@
+package installation
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_NewFromCache(t *testing.T) {
+	response, err := os.ReadFile("testdata/get_installation_info_correct_response.in")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	testCases := []struct {
+		name string
+		// cachedAge is the age of the cached installation info. No info is
+		// cached if zero.
+		cachedAge       time.Duration
+		refresh         bool
+		athenaAvailable bool
+
+		expectedRequests int
+		expectedCodename string
+		expectedWarning  string
+		expectError      bool
+	}{
+		{
+			name:             "case 0: nothing cached",
+			athenaAvailable:  true,
+			expectedRequests: 1,
+			expectedCodename: "test",
+		},
+		{
+			name:             "case 1: fresh info cached",
+			cachedAge:        time.Hour,
+			athenaAvailable:  true,
+			expectedRequests: 0,
+			expectedCodename: "cached",
+		},
+		{
+			name:             "case 2: expired info cached",
+			cachedAge:        25 * time.Hour,
+			athenaAvailable:  true,
+			expectedRequests: 1,
+			expectedCodename: "test",
+		},
+		{
+			name:             "case 3: fresh info cached, with refresh",
+			cachedAge:        time.Hour,
+			refresh:          true,
+			athenaAvailable:  true,
+			expectedRequests: 1,
+			expectedCodename: "test",
+		},
+		{
+			name:             "case 4: expired info cached, Athena unavailable",
+			cachedAge:        25 * time.Hour,
+			expectedRequests: 1,
+			expectedCodename: "cached",
+			expectedWarning:  "cannot be fetched from Athena. Using the cached info from",
+		},
+		{
+			name:             "case 5: nothing cached, Athena unavailable",
+			expectedRequests: 1,
+			expectError:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if !tc.athenaAvailable {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Write(response) // nolint:errcheck
+			}))
+			defer ts.Close()
+
+			warnings := new(bytes.Buffer)
+			config := CacheConfig{
+				Dir:      t.TempDir(),
+				TTL:      DefaultCacheTTL,
+				Refresh:  tc.refresh,
+				Warnings: warnings,
+			}
+
+			basePath, _, _, err := GetBaseAndInternalPath(ts.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			path := cachePath(config.Dir, basePath, "")
+
+			if tc.cachedAge > 0 {
+				err = persistCacheEntry(path, cacheEntry{
+					K8sApiURL: "https://g8s.cached.example.com",
+					AuthURL:   "https://dex.g8s.cached.example.com",
+					Provider:  "capa",
+					Codename:  "cached",
+					FetchedAt: time.Now().Add(-tc.cachedAge),
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+			}
+
+			i, err := NewFromCache(context.Background(), ts.URL, "", config)
+			if tc.expectError {
+				if !IsCannotGetInstallationInfo(err) {
+					t.Fatalf("expected cannotGetInstallationInfoError, got: %v", err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if requests != tc.expectedRequests {
+				t.Fatalf("expected %d requests to Athena, got %d", tc.expectedRequests, requests)
+			}
+			if i.Codename != tc.expectedCodename {
+				t.Fatalf("expected codename %q, got %q", tc.expectedCodename, i.Codename)
+			}
+			if i.SourcePath != ts.URL {
+				t.Fatalf("expected source path %q, got %q", ts.URL, i.SourcePath)
+			}
+
+			if tc.expectedWarning == "" && warnings.Len() > 0 {
+				t.Fatalf("unexpected warning: %q", warnings.String())
+			} else if !strings.Contains(warnings.String(), tc.expectedWarning) {
+				t.Fatalf("expected warning to contain %q, got: %q", tc.expectedWarning, warnings.String())
+			}
+
+			// Fetched info is cached.
+			entry, err := loadCacheEntry(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if entry.Codename != i.Codename || entry.Provider != i.Provider {
+				t.Fatalf("expected cached info %+v, got %+v", i, entry)
+			}
+
+			// No temporary files are left behind.
+			files, err := os.ReadDir(filepath.Dir(path))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if len(files) != 1 || files[0].Name() != filepath.Base(path) {
+				t.Fatalf("expected only the cache file, got %v", files)
+			}
+		})
+	}
+}
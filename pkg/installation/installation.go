@@ -33,8 +33,9 @@ func New(ctx context.Context, fromUrl, customAthenaUrl string) (*Installation, e
 
 	var gqlClient graphql.Client
 	{
-		httpClient := http.DefaultClient
-		httpClient.Timeout = requestTimeout
+		// Not modifying http.DefaultClient, as installations may be
+		// resolved concurrently.
+		httpClient := &http.Client{Timeout: requestTimeout}
 
 		if customAthenaUrl != "" {
 			athenaUrl = customAthenaUrl
@@ -11,6 +11,7 @@ const (
 	AuthTypeServiceAccount
 	AuthTypeAuthProvider
 	AuthTypeClientCertificate
+	AuthTypeExec
 )
 
 func GetAuthType(config *clientcmdapi.Config, contextName string) AuthType {
@@ -41,6 +42,8 @@ func GetAuthType(config *clientcmdapi.Config, contextName string) AuthType {
 		return AuthTypeClientCertificate
 	case len(authInfo.ClientKeyData) > 0:
 		return AuthTypeClientCertificate
+	case authInfo.Exec != nil:
+		return AuthTypeExec
 	}
 
 	return AuthTypeUnknown
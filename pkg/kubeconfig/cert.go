@@ -3,6 +3,7 @@ package kubeconfig
 import (
 	"os/user"
 	"path"
+	"strings"
 
 	"github.com/giantswarm/microerror"
 	"github.com/spf13/afero"
@@ -60,3 +61,34 @@ func WriteCertificate(cert, clusterName string, fs afero.Fs) error {
 
 	return nil
 }
+
+// RemoveCertificate deletes the certificate directory
+// written by WriteCertificate for the given cluster.
+func RemoveCertificate(clusterName string, fs afero.Fs) error {
+	certPath, err := GetKubeCertPath(clusterName)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = fs.RemoveAll(certPath)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// IsKubeCertFile checks whether a file path is located
+// in the directory managed by WriteCertificate.
+func IsKubeCertFile(filePath string) bool {
+	if filePath == "" {
+		return false
+	}
+
+	basePath, err := GetKubeCertPath("")
+	if err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(path.Clean(filePath), basePath+"/")
+}
@@ -0,0 +1,99 @@
+package kubeconfig
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// ClientCertExtensionName is the name of the context extension holding
+	// the information needed to renew a workload cluster client certificate.
+	ClientCertExtensionName = "giantswarm.io/client-certificate"
+)
+
+// ClientCertInfo describes how the client certificate of a
+// workload cluster context was issued.
+type ClientCertInfo struct {
+	MCContextName    string   `json:"mcContextName"`
+	ClusterName      string   `json:"clusterName"`
+	ClusterNamespace string   `json:"clusterNamespace"`
+	ClusterBasePath  string   `json:"clusterBasePath"`
+	Groups           []string `json:"groups,omitempty"`
+	TTL              string   `json:"ttl"`
+	CNPrefix         string   `json:"cnPrefix,omitempty"`
+
+	// CertOperatorVersion is set if the certificate is issued by
+	// cert-operator rather than signed with the workload cluster CA.
+	CertOperatorVersion string `json:"certOperatorVersion,omitempty"`
+	Organization        string `json:"organization,omitempty"`
+	Provider            string `json:"provider,omitempty"`
+}
+
+// SetClientCertInfo stores the client certificate information as an extension of the context.
+func SetClientCertInfo(context *clientcmdapi.Context, info ClientCertInfo) error {
+	raw, err := json.Marshal(info)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if context.Extensions == nil {
+		context.Extensions = map[string]runtime.Object{}
+	}
+	context.Extensions[ClientCertExtensionName] = &runtime.Unknown{
+		Raw:         raw,
+		ContentType: runtime.ContentTypeJSON,
+	}
+
+	return nil
+}
+
+// GetClientCertInfo returns the client certificate information stored in the given context, if any.
+func GetClientCertInfo(config *clientcmdapi.Config, contextName string) (ClientCertInfo, bool) {
+	context, exists := config.Contexts[contextName]
+	if !exists || context.Extensions == nil {
+		return ClientCertInfo{}, false
+	}
+
+	extension, ok := context.Extensions[ClientCertExtensionName].(*runtime.Unknown)
+	if !ok {
+		return ClientCertInfo{}, false
+	}
+
+	var info ClientCertInfo
+	err := json.Unmarshal(extension.Raw, &info)
+	if err != nil {
+		return ClientCertInfo{}, false
+	}
+
+	return info, true
+}
+
+// FindClientCertContext returns the name of the client certificate context of
+// the given workload cluster, created from the given management cluster
+// context. Contexts carrying the client certificate information are
+// preferred over the default context name.
+func FindClientCertContext(config *clientcmdapi.Config, mcContextName, clusterName string) (string, bool) {
+	var contextNames []string
+	for contextName := range config.Contexts {
+		contextNames = append(contextNames, contextName)
+	}
+	sort.Strings(contextNames)
+
+	for _, contextName := range contextNames {
+		info, ok := GetClientCertInfo(config, contextName)
+		if ok && info.ClusterName == clusterName && info.MCContextName == mcContextName {
+			return contextName, true
+		}
+	}
+
+	contextName := GenerateWCClientCertKubeContextName(mcContextName, clusterName)
+	if _, exists := config.Contexts[contextName]; exists {
+		return contextName, true
+	}
+
+	return "", false
+}
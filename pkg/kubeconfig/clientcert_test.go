@@ -0,0 +1,51 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"testing"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestFindClientCertContext(t *testing.T) {
+	newContext := func(info *ClientCertInfo) *clientcmdapi.Context {
+		context := clientcmdapi.NewContext()
+		if info != nil {
+			err := SetClientCertInfo(context, *info)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+		}
+
+		return context
+	}
+
+	config := clientcmdapi.NewConfig()
+	config.Contexts["gs-test"] = newContext(nil)
+	config.Contexts["gs-test-a01-clientcert"] = newContext(nil)
+	config.Contexts["wc-b01"] = newContext(&ClientCertInfo{MCContextName: "gs-test", ClusterName: "b01"})
+	config.Contexts["gs-test-b01-clientcert"] = newContext(nil)
+	config.Contexts["other-c01"] = newContext(&ClientCertInfo{MCContextName: "gs-other", ClusterName: "c01"})
+
+	testCases := []struct {
+		clusterName         string
+		expectedContextName string
+		expectedFound       bool
+	}{
+		{clusterName: "a01", expectedContextName: "gs-test-a01-clientcert", expectedFound: true},
+		{clusterName: "b01", expectedContextName: "wc-b01", expectedFound: true},
+		{clusterName: "c01", expectedFound: false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.clusterName), func(t *testing.T) {
+			contextName, found := FindClientCertContext(config, "gs-test", tc.clusterName)
+			if found != tc.expectedFound {
+				t.Fatalf("expected found %t, got %t", tc.expectedFound, found)
+			}
+			if contextName != tc.expectedContextName {
+				t.Fatalf("expected context %q, got %q", tc.expectedContextName, contextName)
+			}
+		})
+	}
+}
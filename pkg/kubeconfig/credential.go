@@ -0,0 +1,68 @@
+package kubeconfig
+
+import (
+	"time"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/certificate"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/oidc"
+)
+
+const (
+	// Keys of the config of the oidc auth provider.
+	authProviderIDTokenKey      = "id-token"
+	authProviderRefreshTokenKey = "refresh-token"
+)
+
+// GetCredentialExpiry returns when the credentials of a context expire, if
+// that is known: the expiry of the ID token of the oidc auth provider, or of
+// the client certificate.
+func GetCredentialExpiry(config *clientcmdapi.Config, contextName string) (time.Time, bool) {
+	context, exists := config.Contexts[contextName]
+	if !exists {
+		return time.Time{}, false
+	}
+	authInfo, exists := config.AuthInfos[context.AuthInfo]
+	if !exists {
+		return time.Time{}, false
+	}
+
+	if authInfo.AuthProvider != nil {
+		expiry, err := oidc.GetIDTokenExpiry(authInfo.AuthProvider.Config[authProviderIDTokenKey])
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		return expiry, true
+	}
+
+	if len(authInfo.ClientCertificateData) > 0 {
+		expiry, err := certificate.GetExpiry(authInfo.ClientCertificateData)
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		return expiry, true
+	}
+
+	return time.Time{}, false
+}
+
+// CanRenewCredentials tells whether the credentials of a context are renewed
+// once they expire: ID tokens of the oidc auth provider which come with a
+// refresh token, tokens of the credential plugin, and client certificates
+// created by 'kubectl gs login'.
+func CanRenewCredentials(config *clientcmdapi.Config, contextName string) bool {
+	if authProvider, ok := GetAuthProvider(config, contextName); ok {
+		return authProvider.Config[authProviderRefreshTokenKey] != ""
+	}
+	if _, ok := GetExecConfig(config, contextName); ok {
+		return true
+	}
+	if _, ok := GetClientCertInfo(config, contextName); ok {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,224 @@
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// DirFileExtension is the extension of the files in a kubeconfig
+	// directory.
+	DirFileExtension = ".yaml"
+)
+
+// DefaultDir is the default kubeconfig directory.
+var DefaultDir = filepath.Join(clientcmd.RecommendedConfigDir, "kubeconfig.d")
+
+type DirFileStatus string
+
+const (
+	// DirFileStatusValid is the status of files with credentials which
+	// have not expired, or whose expiry is unknown.
+	DirFileStatusValid DirFileStatus = "Valid"
+	// DirFileStatusRenewable is the status of files with expired
+	// credentials, which are renewed on use.
+	DirFileStatusRenewable DirFileStatus = "Expired, renewable"
+	// DirFileStatusExpired is the status of files with expired
+	// credentials, which cannot be renewed.
+	DirFileStatusExpired DirFileStatus = "Expired"
+	// DirFileStatusInvalid is the status of files which are not a
+	// kubeconfig holding its current context.
+	DirFileStatusInvalid DirFileStatus = "Invalid"
+)
+
+// DirFile is a file in a kubeconfig directory, holding a single context.
+type DirFile struct {
+	Path string
+	// Context is the current context of the file.
+	Context string
+	Config  *clientcmdapi.Config
+	// Err is set if the file is not a kubeconfig holding its current
+	// context.
+	Err error
+}
+
+// Status tells whether the file is valid, and whether its credentials have
+// expired at the given time.
+func (f DirFile) Status(now time.Time) DirFileStatus {
+	if f.Err != nil {
+		return DirFileStatusInvalid
+	}
+
+	expiry, ok := GetCredentialExpiry(f.Config, f.Context)
+	if !ok || expiry.After(now) {
+		return DirFileStatusValid
+	}
+	if CanRenewCredentials(f.Config, f.Context) {
+		return DirFileStatusRenewable
+	}
+
+	return DirFileStatusExpired
+}
+
+// IsWrittenByLogin tells whether the file looks like it was written by
+// 'kubectl gs login --kubeconfig-dir': it holds a context named like the
+// contexts created by kubectl-gs, and it is named after that context.
+func (f DirFile) IsWrittenByLogin() bool {
+	if f.Err != nil {
+		return false
+	}
+	if isContext, _ := IsKubeContext(f.Context); !isContext {
+		return false
+	}
+
+	return filepath.Base(f.Path) == f.Context+DirFileExtension
+}
+
+// GetDirFilePath returns the path of the file holding a context in a
+// kubeconfig directory. Files are named after their context.
+func GetDirFilePath(dir, contextName string) string {
+	return filepath.Join(dir, contextName+DirFileExtension)
+}
+
+// ListDirFiles returns the paths of the kubeconfig files in a directory,
+// sorted by name. A directory which does not exist holds no files.
+func ListDirFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), DirFileExtension) {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// ReadDir loads the kubeconfig files in a directory. Files which cannot be
+// loaded, or which do not hold their current context, are returned with an
+// error.
+func ReadDir(dir string) ([]DirFile, error) {
+	paths, err := ListDirFiles(dir)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	files := make([]DirFile, 0, len(paths))
+	for _, path := range paths {
+		file := DirFile{Path: path}
+
+		file.Config, err = clientcmd.LoadFromFile(path)
+		if err != nil {
+			file.Err = microerror.Maskf(invalidKubeconfigFileError, "%s", err.Error())
+		} else if file.Config.CurrentContext == "" {
+			file.Err = microerror.Maskf(invalidKubeconfigFileError, "The file has no current context.")
+		} else if _, exists := file.Config.Contexts[file.Config.CurrentContext]; !exists {
+			file.Err = microerror.Maskf(invalidKubeconfigFileError, "The current context %s does not exist in the file.", file.Config.CurrentContext)
+		} else {
+			file.Context = file.Config.CurrentContext
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// LoadDir merges the kubeconfig files in a directory, like kubectl does for
+// the files listed in KUBECONFIG.
+func LoadDir(dir string) (*clientcmdapi.Config, error) {
+	paths, err := ListDirFiles(dir)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if len(paths) < 1 {
+		return clientcmdapi.NewConfig(), nil
+	}
+
+	loadingRules := clientcmd.ClientConfigLoadingRules{Precedence: paths}
+	config, err := loadingRules.Load()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return config, nil
+}
+
+// ExtractContext returns a kubeconfig holding only the given context, with
+// its cluster and user, and selecting it as the current context.
+func ExtractContext(config *clientcmdapi.Config, contextName string) (*clientcmdapi.Config, bool) {
+	context, exists := config.Contexts[contextName]
+	if !exists {
+		return nil, false
+	}
+
+	extracted := clientcmdapi.NewConfig()
+	extracted.CurrentContext = contextName
+
+	extracted.Contexts[contextName] = context.DeepCopy()
+	extracted.Contexts[contextName].LocationOfOrigin = ""
+
+	if cluster, exists := config.Clusters[context.Cluster]; exists {
+		extracted.Clusters[context.Cluster] = cluster.DeepCopy()
+		extracted.Clusters[context.Cluster].LocationOfOrigin = ""
+	}
+	if authInfo, exists := config.AuthInfos[context.AuthInfo]; exists {
+		extracted.AuthInfos[context.AuthInfo] = authInfo.DeepCopy()
+		extracted.AuthInfos[context.AuthInfo].LocationOfOrigin = ""
+	}
+
+	return extracted, true
+}
+
+// WriteDirFile writes a context of the kubeconfig to its own file in a
+// kubeconfig directory, and returns the path of the file.
+func WriteDirFile(dir string, config *clientcmdapi.Config, contextName string) (string, error) {
+	extracted, exists := ExtractContext(config, contextName)
+	if !exists {
+		return "", microerror.Maskf(contextNotFoundError, "The context %s does not exist.", contextName)
+	}
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	path := GetDirFilePath(dir, contextName)
+	err = clientcmd.WriteToFile(*extracted, path)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return path, nil
+}
+
+// GetDirPathList returns the value of KUBECONFIG for the given kubeconfig
+// files. kubectl uses the current context of the first file setting one, so
+// the file of the selected context, if any, comes first.
+func GetDirPathList(paths []string, selectedPath string) string {
+	var pathList []string
+	for _, path := range paths {
+		if path == selectedPath {
+			pathList = append([]string{path}, pathList...)
+		} else {
+			pathList = append(pathList, path)
+		}
+	}
+
+	return strings.Join(pathList, string(filepath.ListSeparator))
+}
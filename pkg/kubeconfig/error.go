@@ -0,0 +1,21 @@
+package kubeconfig
+
+import "github.com/giantswarm/microerror"
+
+var contextNotFoundError = &microerror.Error{
+	Kind: "contextNotFoundError",
+}
+
+// IsContextNotFound asserts contextNotFoundError.
+func IsContextNotFound(err error) bool {
+	return microerror.Cause(err) == contextNotFoundError
+}
+
+var invalidKubeconfigFileError = &microerror.Error{
+	Kind: "invalidKubeconfigFileError",
+}
+
+// IsInvalidKubeconfigFile asserts invalidKubeconfigFileError.
+func IsInvalidKubeconfigFile(err error) bool {
+	return microerror.Cause(err) == invalidKubeconfigFileError
+}
@@ -0,0 +1,80 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"strings"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/project"
+)
+
+const (
+	// CredentialCommand is the name of the kubectl-gs subcommand
+	// implementing the client-go credential plugin protocol.
+	CredentialCommand = "credential"
+
+	CredentialFlagIssuer   = "issuer"
+	CredentialFlagClientID = "client-id"
+
+	ExecCredentialAPIVersion = "client.authentication.k8s.io/v1"
+)
+
+// NewCredentialExecConfig creates the exec configuration of a kubeconfig
+// user, which obtains OIDC tokens through 'kubectl gs credential'.
+func NewCredentialExecConfig(issuer, clientID string) *clientcmdapi.ExecConfig {
+	return &clientcmdapi.ExecConfig{
+		APIVersion: ExecCredentialAPIVersion,
+		Command:    project.Name(),
+		Args: []string{
+			CredentialCommand,
+			fmt.Sprintf("--%s=%s", CredentialFlagIssuer, issuer),
+			fmt.Sprintf("--%s=%s", CredentialFlagClientID, clientID),
+		},
+		InstallHint:     fmt.Sprintf("%s is required to authenticate to this cluster. See %s for installation instructions.", project.Name(), project.Source()),
+		InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+	}
+}
+
+// GetExecConfig fetches the exec credential plugin configuration
+// from kubeconfig, for a desired context name.
+func GetExecConfig(config *clientcmdapi.Config, contextName string) (*clientcmdapi.ExecConfig, bool) {
+	if contextName == "" {
+		return nil, false
+	}
+
+	currentContext, exists := config.Contexts[contextName]
+	if !exists {
+		return nil, false
+	}
+
+	authInfo, exists := config.AuthInfos[currentContext.AuthInfo]
+	if !exists {
+		return nil, false
+	}
+
+	if authInfo.Exec == nil {
+		return nil, false
+	}
+
+	return authInfo.Exec, true
+}
+
+// GetCredentialExecParams returns the issuer and client ID of an exec
+// configuration created by NewCredentialExecConfig. The last return value
+// is false for any other exec configuration, e.g. AWS IAM authentication.
+func GetCredentialExecParams(exec *clientcmdapi.ExecConfig) (issuer string, clientID string, ok bool) {
+	if exec == nil || exec.Command != project.Name() || len(exec.Args) < 1 || exec.Args[0] != CredentialCommand {
+		return "", "", false
+	}
+
+	for _, arg := range exec.Args[1:] {
+		if value, found := strings.CutPrefix(arg, "--"+CredentialFlagIssuer+"="); found {
+			issuer = value
+		} else if value, found := strings.CutPrefix(arg, "--"+CredentialFlagClientID+"="); found {
+			clientID = value
+		}
+	}
+
+	return issuer, clientID, len(issuer) > 0 && len(clientID) > 0
+}
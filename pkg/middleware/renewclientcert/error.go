@@ -0,0 +1,14 @@
+package renewclientcert
+
+import (
+	"github.com/giantswarm/microerror"
+)
+
+var mcContextNotFoundError = &microerror.Error{
+	Kind: "mcContextNotFoundError",
+}
+
+// IsMCContextNotFound asserts mcContextNotFoundError.
+func IsMCContextNotFound(err error) bool {
+	return microerror.Cause(err) == mcContextNotFoundError
+}
@@ -0,0 +1,160 @@
+package renewclientcert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/certificate"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/commonconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/clientcert"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/middleware"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/scheme"
+)
+
+const (
+	// renewBefore is how long before its expiry a client certificate gets renewed.
+	renewBefore = 5 * time.Minute
+)
+
+type serviceFactory func(restConfig *rest.Config) (clientcert.Interface, error)
+
+// Middleware will attempt to renew the client certificate of the current
+// workload cluster context, if it has expired or is about to expire.
+// If the renewal fails, this middleware will not fail.
+func Middleware(config genericclioptions.RESTClientGetter) middleware.Middleware {
+	return func(cmd *cobra.Command, args []string) error {
+		k8sConfigAccess := config.ToRawKubeConfigLoader().ConfigAccess()
+		contextName := commonconfig.New(config).GetContextOverride()
+
+		renewed, err := Renew(cmd.Context(), k8sConfigAccess, contextName)
+		if err != nil {
+			fmt.Fprint(cmd.ErrOrStderr(), color.YellowString("Warning: The client certificate could not be renewed: %s\n", err))
+		} else if renewed {
+			fmt.Fprint(cmd.ErrOrStderr(), color.GreenString("Renewed the expiring client certificate.\n"))
+		}
+
+		return nil
+	}
+}
+
+// Renew issues a new client certificate for the given workload cluster
+// context, if the current one has expired or is about to expire. The current
+// context is used if no context name is given. The management cluster context
+// the certificate was originally created with is used to sign the new one.
+//
+// Contexts which are not client certificate contexts created by kubectl-gs
+// are left untouched.
+func Renew(ctx context.Context, k8sConfigAccess clientcmd.ConfigAccess, contextName string) (bool, error) {
+	return renew(ctx, k8sConfigAccess, contextName, newClientCertService)
+}
+
+func renew(ctx context.Context, k8sConfigAccess clientcmd.ConfigAccess, contextName string, newService serviceFactory) (bool, error) {
+	config, err := k8sConfigAccess.GetStartingConfig()
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	if contextName == "" {
+		contextName = config.CurrentContext
+	}
+
+	info, ok := kubeconfig.GetClientCertInfo(config, contextName)
+	if !ok {
+		return false, nil
+	}
+
+	authInfo, ok := config.AuthInfos[config.Contexts[contextName].AuthInfo]
+	if !ok || len(authInfo.ClientCertificateData) == 0 {
+		return false, nil
+	}
+
+	expiry, err := certificate.GetExpiry(authInfo.ClientCertificateData)
+	if err == nil && time.Until(expiry) > renewBefore {
+		return false, nil
+	}
+
+	mcContextName := info.MCContextName
+	if mcContextName == "" {
+		mcContextName = kubeconfig.GenerateKubeContextName(kubeconfig.GetCodeNameFromKubeContext(contextName))
+	}
+	if _, exists := config.Contexts[mcContextName]; !exists {
+		return false, microerror.Maskf(mcContextNotFoundError, "The management cluster context %s does not exist. Please log in again.", mcContextName)
+	}
+
+	restConfig, err := clientcmd.NewNonInteractiveClientConfig(*config, mcContextName, &clientcmd.ConfigOverrides{}, k8sConfigAccess).ClientConfig()
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	clientCertService, err := newService(restConfig)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	certConfig := certificate.Config{
+		ClusterName:      info.ClusterName,
+		ClusterNamespace: info.ClusterNamespace,
+		ClusterBasePath:  info.ClusterBasePath,
+		Groups:           info.Groups,
+		TTL:              info.TTL,
+		CNPrefix:         info.CNPrefix,
+
+		CertOperatorVersion: info.CertOperatorVersion,
+		Organization:        info.Organization,
+		Provider:            info.Provider,
+	}
+
+	var secret *corev1.Secret
+	if certConfig.CertOperatorVersion != "" {
+		secret, err = certificate.IssueWithCertOperator(ctx, clientCertService, certConfig)
+	} else {
+		secret, err = certificate.Issue(ctx, clientCertService, certConfig)
+	}
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	authInfo.ClientCertificateData = secret.Data[certificate.KeyCertCRT]
+	authInfo.ClientKeyData = secret.Data[certificate.KeyCertKey]
+
+	if cluster, exists := config.Clusters[config.Contexts[contextName].Cluster]; exists && len(secret.Data[certificate.KeyCertCA]) > 0 {
+		cluster.CertificateAuthorityData = secret.Data[certificate.KeyCertCA]
+	}
+
+	err = clientcmd.ModifyConfig(k8sConfigAccess, *config, false)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return true, nil
+}
+
+func newClientCertService(restConfig *rest.Config) (clientcert.Interface, error) {
+	s, err := scheme.NewScheme()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: s})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	service, err := clientcert.New(clientcert.Config{Client: k8sClient})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return service, nil
+}
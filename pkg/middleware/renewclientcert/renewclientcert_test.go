@@ -0,0 +1,310 @@
+package renewclientcert
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/ptr"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/certificate"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/clientcert"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/kubeconfig"
+	"github.com/giantswarm/kubectl-gs/v5/test/kubeclient"
+	testkubeconfig "github.com/giantswarm/kubectl-gs/v5/test/kubeconfig"
+)
+
+const (
+	mcContextName = "gs-codename"
+	wcContextName = "gs-codename-cluster-clientcert"
+)
+
+func Test_Renew(t *testing.T) {
+	testCases := []struct {
+		name                string
+		certExpiry          time.Duration
+		withCertInfo        bool
+		mcContextName       string
+		certOperatorVersion string
+		expectRenew         bool
+		expectError         *microerror.Error
+	}{
+		{
+			name:          "case 0: valid client certificate is not renewed",
+			certExpiry:    time.Hour,
+			withCertInfo:  true,
+			mcContextName: mcContextName,
+		},
+		{
+			name:          "case 1: expired client certificate is renewed",
+			certExpiry:    -time.Hour,
+			withCertInfo:  true,
+			mcContextName: mcContextName,
+			expectRenew:   true,
+		},
+		{
+			name:          "case 2: client certificate about to expire is renewed",
+			certExpiry:    time.Minute,
+			withCertInfo:  true,
+			mcContextName: mcContextName,
+			expectRenew:   true,
+		},
+		{
+			name:          "case 3: expired client certificate is renewed using the context name to find the MC context",
+			certExpiry:    -time.Hour,
+			withCertInfo:  true,
+			mcContextName: "",
+			expectRenew:   true,
+		},
+		{
+			name:          "case 4: context without client certificate information is not renewed",
+			certExpiry:    -time.Hour,
+			withCertInfo:  false,
+			mcContextName: mcContextName,
+		},
+		{
+			name:          "case 5: missing MC context",
+			certExpiry:    -time.Hour,
+			withCertInfo:  true,
+			mcContextName: "gs-othercodename",
+			expectError:   mcContextNotFoundError,
+		},
+		{
+			name:                "case 6: expired client certificate issued by cert-operator is renewed",
+			certExpiry:          -time.Hour,
+			withCertInfo:        true,
+			mcContextName:       mcContextName,
+			certOperatorVersion: "1.0.0",
+			expectRenew:         true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			caKey, caPEM := createCA(t)
+			certPEM := createClientCert(t, caKey, caPEM, time.Now().Add(tc.certExpiry))
+
+			cf := genericclioptions.NewConfigFlags(true)
+			cf.KubeConfig = ptr.To[string](fmt.Sprintf("%s/config.yaml", t.TempDir()))
+			k8sConfigAccess := cf.ToRawKubeConfigLoader().ConfigAccess()
+
+			config := createTestConfig(t, certPEM, tc.withCertInfo, tc.mcContextName, tc.certOperatorVersion)
+			err := clientcmd.ModifyConfig(k8sConfigAccess, *config, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var certOperator *fakeCertOperator
+			newService := func(restConfig *rest.Config) (clientcert.Interface, error) {
+				if restConfig.Host != "https://api.codename.example.com" {
+					t.Fatalf("unexpected MC host %s", restConfig.Host)
+				}
+				client := kubeclient.FakeK8sClient(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cluster-ca",
+						Namespace: "org-test",
+					},
+					Data: map[string][]byte{
+						"tls.crt": caPEM,
+						"tls.key": certificate.EncodePrivateKeyPEM(caKey),
+					},
+				})
+				service, err := clientcert.New(clientcert.Config{Client: client.CtrlClient()})
+				if err != nil {
+					return nil, err
+				}
+				if tc.certOperatorVersion != "" {
+					certOperator = &fakeCertOperator{Interface: service, caKey: caKey, caPEM: caPEM}
+					return certOperator, nil
+				}
+				return service, nil
+			}
+
+			renewed, err := renew(context.Background(), k8sConfigAccess, "", newService)
+			if tc.expectError != nil {
+				if microerror.Cause(err) != tc.expectError {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if renewed != tc.expectRenew {
+				t.Fatalf("expected renewal to be %t, got %t", tc.expectRenew, renewed)
+			}
+
+			newConfig, err := k8sConfigAccess.GetStartingConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			authInfo := newConfig.AuthInfos[wcContextName+"-user"]
+			if bytes.Equal(authInfo.ClientCertificateData, certPEM) == tc.expectRenew {
+				t.Fatalf("expected certificate renewal to be %t", tc.expectRenew)
+			}
+
+			if tc.expectRenew {
+				expiry, err := certificate.GetExpiry(authInfo.ClientCertificateData)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if time.Until(expiry) < 50*time.Minute {
+					t.Fatalf("unexpected expiry of renewed certificate %s", expiry)
+				}
+				if _, ok := kubeconfig.GetClientCertInfo(newConfig, wcContextName); !ok {
+					t.Fatal("client certificate information got lost")
+				}
+			}
+
+			if certOperator != nil {
+				if certOperator.created == nil {
+					t.Fatal("expected a CertConfig to be created")
+				}
+				if certOperator.created.CertConfig.Spec.VersionBundle.Version != tc.certOperatorVersion {
+					t.Fatalf("unexpected cert-operator version %s", certOperator.created.CertConfig.Spec.VersionBundle.Version)
+				}
+				if !certOperator.deleted {
+					t.Fatal("expected the CertConfig to be deleted")
+				}
+			}
+		})
+	}
+}
+
+func createTestConfig(t *testing.T, certPEM []byte, withCertInfo bool, mcContext, certOperatorVersion string) *clientcmdapi.Config {
+	config := testkubeconfig.CreateTestConfig(mcContextName, mcContextName, mcContextName+"-user", "https://api.codename.example.com", "token")
+
+	config.Clusters[wcContextName] = &clientcmdapi.Cluster{
+		Server: "https://api.cluster.example.com",
+	}
+	config.AuthInfos[wcContextName+"-user"] = &clientcmdapi.AuthInfo{
+		ClientCertificateData: certPEM,
+		ClientKeyData:         []byte("key"),
+	}
+	config.Contexts[wcContextName] = &clientcmdapi.Context{
+		Cluster:  wcContextName,
+		AuthInfo: wcContextName + "-user",
+	}
+	config.CurrentContext = wcContextName
+
+	if withCertInfo {
+		err := kubeconfig.SetClientCertInfo(config.Contexts[wcContextName], kubeconfig.ClientCertInfo{
+			MCContextName:    mcContext,
+			ClusterName:      "cluster",
+			ClusterNamespace: "org-test",
+			ClusterBasePath:  "codename.example.com",
+			Groups:           []string{"system:masters"},
+			TTL:              "1h",
+
+			CertOperatorVersion: certOperatorVersion,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return config
+}
+
+// fakeCertOperator issues the client certificate of a CertConfig as soon as
+// it is created, signed with the given CA.
+type fakeCertOperator struct {
+	clientcert.Interface
+	caKey *rsa.PrivateKey
+	caPEM []byte
+
+	created *clientcert.ClientCert
+	secret  *corev1.Secret
+	deleted bool
+}
+
+func (f *fakeCertOperator) Create(ctx context.Context, clientCert *clientcert.ClientCert) error {
+	ca := &corev1.Secret{
+		Data: map[string][]byte{
+			"tls.crt": f.caPEM,
+			"tls.key": certificate.EncodePrivateKeyPEM(f.caKey),
+		},
+	}
+	secret, err := certificate.Generate(ca, certificate.Config{
+		ClusterName:     clientCert.CertConfig.Spec.Cert.ClusterID,
+		ClusterBasePath: "codename.example.com",
+		TTL:             clientCert.CertConfig.Spec.Cert.TTL,
+	})
+	if err != nil {
+		return err
+	}
+
+	f.created = clientCert
+	f.secret = secret
+
+	return nil
+}
+
+func (f *fakeCertOperator) Delete(ctx context.Context, clientCert *clientcert.ClientCert) error {
+	f.deleted = f.created != nil && clientCert.CertConfig.Name == f.created.CertConfig.Name
+	return nil
+}
+
+func (f *fakeCertOperator) GetCredential(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	if f.created == nil || name != f.created.CertConfig.Name {
+		return f.Interface.GetCredential(ctx, namespace, name)
+	}
+
+	return f.secret, nil
+}
+
+func createCA(t *testing.T) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+	}
+	caBytes, err := x509.CreateCertificate(rand.Reader, ca, ca, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, certificate.EncodeCertificatePEM(caBytes)
+}
+
+func createClientCert(t *testing.T, caKey *rsa.PrivateKey, caPEM []byte, notAfter time.Time) []byte {
+	ca, err := certificate.DecodeCertificatePEM(caPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    notAfter.Add(-2 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, cert, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certificate.EncodeCertificatePEM(certBytes)
+}
@@ -31,6 +31,33 @@ func IsCannotRenewToken(err error) bool {
 	return microerror.Cause(err) == cannotRenewTokenError
 }
 
+var cannotRevokeTokenError = &microerror.Error{
+	Kind: "cannotRevokeTokenError",
+}
+
+// IsCannotRevokeToken asserts cannotRevokeTokenError.
+func IsCannotRevokeToken(err error) bool {
+	return microerror.Cause(err) == cannotRevokeTokenError
+}
+
+var revocationNotSupportedError = &microerror.Error{
+	Kind: "revocationNotSupportedError",
+}
+
+// IsRevocationNotSupported asserts revocationNotSupportedError.
+func IsRevocationNotSupported(err error) bool {
+	return microerror.Cause(err) == revocationNotSupportedError
+}
+
+var cannotGetTokenError = &microerror.Error{
+	Kind: "cannotGetTokenError",
+}
+
+// IsCannotGetToken asserts cannotGetTokenError.
+func IsCannotGetToken(err error) bool {
+	return microerror.Cause(err) == cannotGetTokenError
+}
+
 var cannotGetDeviceCodeError = &microerror.Error{
 	Kind: "cannotGetDeviceCodeError",
 }
@@ -0,0 +1,136 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	ClientCredentialsGrantType = "client_credentials"
+	TokenExchangeGrantType     = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	TokenTypeIDToken = "urn:ietf:params:oauth:token-type:id_token"
+
+	TokenExchangeKeyConnectorID        = "connector_id"
+	TokenExchangeKeySubjectToken       = "subject_token"
+	TokenExchangeKeySubjectTokenType   = "subject_token_type"
+	TokenExchangeKeyRequestedTokenType = "requested_token_type"
+
+	tokenExchangeTimeout = 30 * time.Second
+)
+
+type TokenExchangeResponseData struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int    `json:"expires_in"`
+}
+
+// GetClientCredentialsToken obtains an ID token for the client itself, using
+// the OAuth2 client credentials grant. The issuer does not return a refresh
+// token for this grant.
+func (a *Authenticator) GetClientCredentialsToken(ctx context.Context) (UserInfo, error) {
+	config := clientcredentials.Config{
+		ClientID:     a.clientConfig.ClientID,
+		ClientSecret: a.clientConfig.ClientSecret,
+		TokenURL:     a.clientConfig.Endpoint.TokenURL,
+		Scopes:       a.clientConfig.Scopes,
+		AuthStyle:    a.clientConfig.Endpoint.AuthStyle,
+	}
+
+	token, err := config.Token(ctx)
+	if err != nil {
+		return UserInfo{}, microerror.Maskf(cannotGetTokenError, "%s", err.Error())
+	}
+
+	rawIDToken, err := ConvertTokenToRawIDToken(token)
+	if err != nil {
+		return UserInfo{}, microerror.Mask(err)
+	}
+
+	info, err := a.getUserInfo(ctx, rawIDToken, "")
+	if err != nil {
+		return UserInfo{}, microerror.Mask(err)
+	}
+
+	return info, nil
+}
+
+// ExchangeToken trades an ID token of another identity provider for an ID
+// token of the issuer, using the OAuth2 token exchange grant (RFC 8693) as
+// implemented by Dex. The connector is the one trusting the other identity
+// provider. The issuer does not return a refresh token for this grant.
+func (a *Authenticator) ExchangeToken(ctx context.Context, connectorID string, subjectToken string) (UserInfo, error) {
+	form := url.Values{}
+	form.Set(DeviceAuthKeyGrantType, TokenExchangeGrantType)
+	form.Set(DeviceAuthKeyClientID, a.clientConfig.ClientID)
+	form.Set(DeviceAuthKeyScope, strings.Join(a.clientConfig.Scopes, " "))
+	form.Set(TokenExchangeKeyConnectorID, connectorID)
+	form.Set(TokenExchangeKeySubjectToken, subjectToken)
+	form.Set(TokenExchangeKeySubjectTokenType, TokenTypeIDToken)
+	form.Set(TokenExchangeKeyRequestedTokenType, TokenTypeIDToken)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.clientConfig.Endpoint.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return UserInfo{}, microerror.Mask(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if a.clientConfig.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(a.clientConfig.ClientID), url.QueryEscape(a.clientConfig.ClientSecret))
+	}
+
+	res, err := tokenExchangeClient(ctx).Do(req)
+	if err != nil {
+		return UserInfo{}, microerror.Maskf(cannotGetTokenError, "%s", err.Error())
+	}
+
+	body, err := bytesFromResponse(res)
+	if err != nil {
+		return UserInfo{}, microerror.Maskf(cannotGetTokenError, "%s", err.Error())
+	}
+
+	if res.StatusCode != http.StatusOK {
+		result := ErrorResponseData{}
+		_ = json.Unmarshal(body, &result)
+		if result.Error == "" {
+			result.Error = strings.TrimSpace(string(body))
+		}
+
+		return UserInfo{}, microerror.Maskf(cannotGetTokenError, "token endpoint returned %s: %s", res.Status, result.Error)
+	}
+
+	result := TokenExchangeResponseData{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return UserInfo{}, microerror.Maskf(cannotGetTokenError, "%s", err.Error())
+	}
+	if result.IssuedTokenType != TokenTypeIDToken {
+		return UserInfo{}, microerror.Maskf(cannotGetTokenError, "token endpoint issued a token of type %q instead of an ID token", result.IssuedTokenType)
+	}
+
+	info, err := a.getUserInfo(ctx, result.AccessToken, "")
+	if err != nil {
+		return UserInfo{}, microerror.Mask(err)
+	}
+
+	return info, nil
+}
+
+// tokenExchangeClient returns the HTTP client given in the context with
+// oauth2.HTTPClient, like the oauth2 package uses for the other grants, or
+// else a client with a timeout.
+func tokenExchangeClient(ctx context.Context) *http.Client {
+	if client, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && client != nil {
+		return client
+	}
+
+	return &http.Client{Timeout: tokenExchangeTimeout}
+}
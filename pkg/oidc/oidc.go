@@ -3,6 +3,9 @@ package oidc
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"strings"
 
 	gooidc "github.com/coreos/go-oidc/v3/oidc"
@@ -109,6 +112,50 @@ func (a *Authenticator) RenewToken(ctx context.Context, refreshToken string) (id
 	return idToken, rToken, nil
 }
 
+// RevokeToken revokes a refresh token using the issuer's token
+// revocation endpoint (RFC 7009), if the issuer advertises one.
+func (a *Authenticator) RevokeToken(ctx context.Context, refreshToken string) error {
+	var providerClaims struct {
+		RevocationEndpoint string `json:"revocation_endpoint"`
+	}
+	err := a.provider.Claims(&providerClaims)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if providerClaims.RevocationEndpoint == "" {
+		return microerror.Maskf(revocationNotSupportedError, "issuer does not advertise a revocation endpoint")
+	}
+
+	form := url.Values{}
+	form.Set("token", refreshToken)
+	form.Set("token_type_hint", "refresh_token")
+	form.Set("client_id", a.clientConfig.ClientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, providerClaims.RevocationEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if a.clientConfig.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(a.clientConfig.ClientID), url.QueryEscape(a.clientConfig.ClientSecret))
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return microerror.Maskf(cannotRevokeTokenError, "%s", err.Error())
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return microerror.Maskf(cannotRevokeTokenError, "revocation endpoint returned %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
 func (a *Authenticator) HandleIssuerResponse(ctx context.Context, challenge string, code string) (UserInfo, error) {
 	var err error
 
@@ -130,6 +177,18 @@ func (a *Authenticator) HandleIssuerResponse(ctx context.Context, challenge stri
 		return UserInfo{}, microerror.Mask(err)
 	}
 
+	info, err := a.getUserInfo(ctx, rawIDToken, token.RefreshToken)
+	if err != nil {
+		return UserInfo{}, microerror.Mask(err)
+	}
+
+	return info, nil
+}
+
+// getUserInfo verifies a raw ID token issued to the client, and returns the
+// user's info from its claims.
+func (a *Authenticator) getUserInfo(ctx context.Context, rawIDToken string, refreshToken string) (UserInfo, error) {
+	var err error
 	var idToken *gooidc.IDToken
 	{
 		// Verify if ID Token is valid.
@@ -163,7 +222,7 @@ func (a *Authenticator) HandleIssuerResponse(ctx context.Context, challenge stri
 		Email:         claims.Email,
 		EmailVerified: claims.Verified,
 		IDToken:       rawIDToken,
-		RefreshToken:  token.RefreshToken,
+		RefreshToken:  refreshToken,
 		IssuerURL:     idToken.Issuer,
 		Username:      username,
 		Groups:        claims.Groups,
@@ -1,7 +1,10 @@
 package oidc
 
 import (
+	"time"
+
 	"github.com/giantswarm/microerror"
+	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/oauth2"
 )
 
@@ -14,3 +17,81 @@ func ConvertTokenToRawIDToken(token *oauth2.Token) (string, error) {
 
 	return rawIDToken, nil
 }
+
+// GetIDTokenExpiry returns the expiry encoded in a raw ID token. The token
+// signature is not verified.
+func GetIDTokenExpiry(rawIDToken string) (time.Time, error) {
+	parsedToken, _, err := new(jwt.Parser).ParseUnverified(rawIDToken, jwt.MapClaims{})
+	if err != nil {
+		return time.Time{}, microerror.Maskf(cannotParseJwtError, "%s", err.Error())
+	}
+
+	exp, err := parsedToken.Claims.GetExpirationTime()
+	if err != nil {
+		return time.Time{}, microerror.Maskf(cannotParseJwtError, "%s", err.Error())
+	}
+	if exp == nil {
+		return time.Time{}, microerror.Maskf(cannotParseJwtError, "token has no expiry")
+	}
+
+	return exp.Time, nil
+}
+
+// IDTokenClaims are the claims of an ID token identifying the user.
+type IDTokenClaims struct {
+	Issuer            string
+	Subject           string
+	Audience          []string
+	Email             string
+	Name              string
+	PreferredUsername string
+	Groups            []string
+	Expiry            time.Time
+}
+
+// ParseIDTokenClaims returns the claims of a raw ID token identifying the
+// user. The token signature is not verified.
+func ParseIDTokenClaims(rawIDToken string) (IDTokenClaims, error) {
+	parsedToken, _, err := new(jwt.Parser).ParseUnverified(rawIDToken, jwt.MapClaims{})
+	if err != nil {
+		return IDTokenClaims{}, microerror.Maskf(cannotParseJwtError, "%s", err.Error())
+	}
+
+	mapClaims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return IDTokenClaims{}, microerror.Maskf(cannotParseJwtError, "unexpected claims type %T", parsedToken.Claims)
+	}
+
+	claims := IDTokenClaims{
+		Email:             getStringClaim(mapClaims, "email"),
+		Name:              getStringClaim(mapClaims, "name"),
+		PreferredUsername: getStringClaim(mapClaims, "preferred_username"),
+	}
+
+	claims.Issuer, _ = mapClaims.GetIssuer()
+	claims.Subject, _ = mapClaims.GetSubject()
+	claims.Audience, _ = mapClaims.GetAudience()
+
+	exp, _ := mapClaims.GetExpirationTime()
+	if exp != nil {
+		claims.Expiry = exp.Time
+	}
+
+	switch groups := mapClaims["groups"].(type) {
+	case string:
+		claims.Groups = []string{groups}
+	case []interface{}:
+		for _, group := range groups {
+			if g, ok := group.(string); ok {
+				claims.Groups = append(claims.Groups, g)
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+func getStringClaim(claims jwt.MapClaims, key string) string {
+	value, _ := claims[key].(string)
+	return value
+}
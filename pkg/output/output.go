@@ -12,6 +12,7 @@ const (
 	TypeJsonPath       = "jsonpath"
 	TypeJsonPathFile   = "jsonpath-file"
 	TypeReport         = "report"
+	TypeMarkdown       = "markdown"
 )
 
 func IsOutputDefault(output *string) bool {
@@ -25,3 +26,7 @@ func IsOutputName(output *string) bool {
 func IsOutputReport(output *string) bool {
 	return *output == "report"
 }
+
+func IsOutputMarkdown(output *string) bool {
+	return output != nil && *output == TypeMarkdown
+}
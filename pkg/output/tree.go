@@ -0,0 +1,30 @@
+package output
+
+import "strings"
+
+// TreePrefix returns the prefix drawing the branches of a tree in front of
+// the name of an item. lastSiblings tells for each ancestor below the root,
+// and for the item itself, whether it is the last of its siblings. Roots,
+// given no siblings, have no prefix.
+func TreePrefix(lastSiblings []bool) string {
+	if len(lastSiblings) == 0 {
+		return ""
+	}
+
+	var prefix strings.Builder
+	for _, last := range lastSiblings[:len(lastSiblings)-1] {
+		if last {
+			prefix.WriteString("    ")
+		} else {
+			prefix.WriteString("│   ")
+		}
+	}
+
+	if lastSiblings[len(lastSiblings)-1] {
+		prefix.WriteString("└── ")
+	} else {
+		prefix.WriteString("├── ")
+	}
+
+	return prefix.String()
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTreePrefix(t *testing.T) {
+	testCases := []struct {
+		lastSiblings   []bool
+		expectedPrefix string
+	}{
+		{
+			expectedPrefix: "",
+		},
+		{
+			lastSiblings:   []bool{false},
+			expectedPrefix: "├── ",
+		},
+		{
+			lastSiblings:   []bool{true},
+			expectedPrefix: "└── ",
+		},
+		{
+			lastSiblings:   []bool{false, true},
+			expectedPrefix: "│   └── ",
+		},
+		{
+			lastSiblings:   []bool{true, false},
+			expectedPrefix: "    ├── ",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			prefix := TreePrefix(tc.lastSiblings)
+			if prefix != tc.expectedPrefix {
+				t.Fatalf("expected %q, got %q", tc.expectedPrefix, prefix)
+			}
+		})
+	}
+}
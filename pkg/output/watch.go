@@ -0,0 +1,70 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+)
+
+type watchEvent struct {
+	Type   watch.EventType `json:"type"`
+	Object runtime.Object  `json:"object"`
+}
+
+// PrintWatchEvent prints the objects affected by a watch event. With JSON and
+// YAML output, each object is wrapped into an event carrying its type, like
+// kubectl does with --output-watch-events. All other formats print the
+// objects only.
+func PrintWatchEvent(out io.Writer, printFlags *genericclioptions.PrintFlags, eventType watch.EventType, resource runtime.Object) error {
+	objects, err := extractResourcesOutOfList(resource)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, obj := range objects {
+		event := watchEvent{
+			Type:   eventType,
+			Object: obj,
+		}
+
+		switch *printFlags.OutputFormat {
+		case TypeJSON:
+			data, err := json.MarshalIndent(event, "", "    ")
+			if err != nil {
+				return microerror.Mask(err)
+			}
+			fmt.Fprintf(out, "%s\n", data)
+
+		case TypeYAML:
+			data, err := yaml.Marshal(event)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+			fmt.Fprintf(out, "---\n%s", data)
+
+		case TypeName:
+			err = PrintResourceNames(out, obj)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+		default:
+			printer, err := printFlags.ToPrinter()
+			if err != nil {
+				return microerror.Mask(err)
+			}
+			err = printer.PrintObj(obj, out)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/giantswarm/kubectl-gs/v5/test/goldenfile"
+)
+
+// TestPrintWatchEvent uses golden files.
+//
+// go test ./pkg/output -run TestPrintWatchEvent -update
+func TestPrintWatchEvent(t *testing.T) {
+	testCases := []struct {
+		name               string
+		eventType          watch.EventType
+		resource           runtime.Object
+		outputType         string
+		expectedGoldenFile string
+	}{
+		{
+			name:               "case 0: json event",
+			eventType:          watch.Added,
+			resource:           newResource("asbv2"),
+			outputType:         TypeJSON,
+			expectedGoldenFile: "watch_event_json.golden",
+		},
+		{
+			name:      "case 1: yaml events of a list resource",
+			eventType: watch.Modified,
+			resource: newListResource(
+				newResource("asbv2"),
+				newResource("ffs1s"),
+			),
+			outputType:         TypeYAML,
+			expectedGoldenFile: "watch_event_yaml.golden",
+		},
+		{
+			name:               "case 2: name output",
+			eventType:          watch.Deleted,
+			resource:           newResource("asbv2"),
+			outputType:         TypeName,
+			expectedGoldenFile: "watch_event_name.golden",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			printFlags := genericclioptions.NewPrintFlags("").WithDefaultOutput(tc.outputType)
+			err := PrintWatchEvent(out, printFlags, tc.eventType, tc.resource)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			gf := goldenfile.New("testdata", tc.expectedGoldenFile)
+			if *update {
+				err = gf.Update(out.Bytes())
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+			}
+
+			expectedResult, err := gf.Read()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			diff := cmp.Diff(string(expectedResult), out.String())
+			if diff != "" {
+				t.Fatalf("value not expected, got:\n %s", diff)
+			}
+		})
+	}
+}
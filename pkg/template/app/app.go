@@ -188,9 +188,15 @@ func NewConfigMap(config UserConfig) (*corev1.ConfigMap, error) {
 }
 
 func NewSecret(config UserConfig) (*corev1.Secret, error) {
-	userConfigSecretData, err := key.ReadSecretYamlFromFile(afero.NewOsFs(), config.Path)
-	if err != nil {
-		return nil, microerror.Mask(err)
+	var userConfigSecretData []byte
+	if config.Data != "" {
+		userConfigSecretData = []byte(config.Data)
+	} else {
+		var err error
+		userConfigSecretData, err = key.ReadSecretYamlFromFile(afero.NewOsFs(), config.Path)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
 	}
 
 	secret := &corev1.Secret{
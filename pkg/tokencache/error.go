@@ -0,0 +1,21 @@
+package tokencache
+
+import "github.com/giantswarm/microerror"
+
+var notFoundError = &microerror.Error{
+	Kind: "notFoundError",
+}
+
+// IsNotFound asserts notFoundError.
+func IsNotFound(err error) bool {
+	return microerror.Cause(err) == notFoundError
+}
+
+var lockTimeoutError = &microerror.Error{
+	Kind: "lockTimeoutError",
+}
+
+// IsLockTimeout asserts lockTimeoutError.
+func IsLockTimeout(err error) bool {
+	return microerror.Cause(err) == lockTimeoutError
+}
@@ -0,0 +1,72 @@
+package tokencache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	lockFileSuffix = ".lock"
+
+	// lockRetryInterval is the time to wait before trying again to
+	// acquire a lock held by another process.
+	lockRetryInterval = 100 * time.Millisecond
+	// lockTimeout is the time after which we give up acquiring a lock.
+	lockTimeout = 30 * time.Second
+	// lockStaleAge is the age after which a lock file is considered left
+	// behind by a process which did not release it, e.g. because it got
+	// killed, and gets removed.
+	lockStaleAge = 1 * time.Minute
+)
+
+// lock acquires an exclusive lock on the cache file at the given path, so
+// that only one process at a time renews its tokens. The lock is a file
+// next to the cache file, created exclusively. The returned function
+// releases the lock.
+func lock(ctx context.Context, path string) (func(), error) {
+	lockPath := path + lockFileSuffix
+
+	err := os.MkdirAll(filepath.Dir(lockPath), 0700)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	timeout := time.After(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, os.FileMode(cacheFileMode))
+		if err == nil {
+			err = f.Close()
+			if err != nil {
+				_ = os.Remove(lockPath)
+				return nil, microerror.Mask(err)
+			}
+
+			return func() { _ = os.Remove(lockPath) }, nil
+		} else if !os.IsExist(err) {
+			return nil, microerror.Mask(err)
+		}
+
+		info, err := os.Stat(lockPath)
+		if err == nil && time.Since(info.ModTime()) > lockStaleAge {
+			err = os.Remove(lockPath)
+			if err != nil && !os.IsNotExist(err) {
+				return nil, microerror.Mask(err)
+			}
+			continue
+		} else if err != nil && !os.IsNotExist(err) {
+			return nil, microerror.Mask(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, microerror.Mask(ctx.Err())
+		case <-timeout:
+			return nil, microerror.Maskf(lockTimeoutError, "could not acquire lock %s within %s", lockPath, lockTimeout)
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
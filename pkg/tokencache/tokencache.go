@@ -0,0 +1,200 @@
+package tokencache
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/oidc"
+)
+
+const (
+	cacheSubDir   = "tokens"
+	cacheFileMode = 0600
+
+	// expiryThreshold is the remaining lifetime below which
+	// an ID token is considered expired and gets renewed.
+	expiryThreshold = 1 * time.Minute
+)
+
+// Entry holds the OIDC tokens of a single login session.
+type Entry struct {
+	IDToken      string `json:"id_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Path returns the location of the cache file for the given
+// issuer and client ID.
+func Path(cacheDir, issuer, clientID string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s", issuer, clientID)))
+
+	return filepath.Join(cacheDir, cacheSubDir, fmt.Sprintf("%x.yaml", sum[:16]))
+}
+
+// Load reads the cached tokens for the given issuer and client ID.
+func Load(cacheDir, issuer, clientID string) (Entry, error) {
+	serialized, err := os.ReadFile(Path(cacheDir, issuer, clientID))
+	if os.IsNotExist(err) {
+		return Entry{}, microerror.Maskf(notFoundError, "no cached tokens for issuer %s", issuer)
+	} else if err != nil {
+		return Entry{}, microerror.Mask(err)
+	}
+
+	var entry Entry
+	err = yaml.Unmarshal(serialized, &entry)
+	if err != nil {
+		return Entry{}, microerror.Mask(err)
+	}
+
+	if len(entry.RefreshToken) < 1 {
+		return Entry{}, microerror.Maskf(notFoundError, "no cached refresh token for issuer %s", issuer)
+	}
+
+	return entry, nil
+}
+
+// Persist writes the tokens for the given issuer and client ID to the cache.
+// The file is written to a temporary file first and then renamed, so that
+// concurrent readers never see a partially written cache file.
+func Persist(cacheDir, issuer, clientID string, entry Entry) error {
+	serialized, err := yaml.Marshal(entry)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	out := Path(cacheDir, issuer, clientID)
+
+	err = os.MkdirAll(filepath.Dir(out), 0700)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(out), filepath.Base(out)+".*.tmp")
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	defer func() {
+		// Only left behind when writing or renaming failed.
+		_ = os.Remove(tmp.Name())
+	}()
+
+	_, err = tmp.Write(serialized)
+	if err != nil {
+		_ = tmp.Close()
+		return microerror.Mask(err)
+	}
+	err = tmp.Chmod(os.FileMode(cacheFileMode))
+	if err != nil {
+		_ = tmp.Close()
+		return microerror.Mask(err)
+	}
+	err = tmp.Close()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = os.Rename(tmp.Name(), out)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// Delete removes the cached tokens for the given issuer and client ID.
+// A missing cache file is not considered an error.
+func Delete(cacheDir, issuer, clientID string) error {
+	err := os.Remove(Path(cacheDir, issuer, clientID))
+	if err != nil && !os.IsNotExist(err) {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// GetFreshToken returns the cached tokens for the given issuer and client ID,
+// together with the ID token's expiry. If the ID token is expired or about
+// to expire, it gets renewed using the refresh token and the cache is updated.
+// The cache file is locked while renewing, so that concurrent invocations,
+// e.g. of the credential plugin, do not use the same refresh token twice.
+func GetFreshToken(ctx context.Context, cacheDir, issuer, clientID string) (Entry, time.Time, error) {
+	entry, expiry, fresh, err := loadFreshToken(cacheDir, issuer, clientID)
+	if err != nil {
+		return Entry{}, time.Time{}, microerror.Mask(err)
+	} else if fresh {
+		return entry, expiry, nil
+	}
+
+	unlock, err := lock(ctx, Path(cacheDir, issuer, clientID))
+	if err != nil {
+		return Entry{}, time.Time{}, microerror.Mask(err)
+	}
+	defer unlock()
+
+	// Another process may have renewed the tokens while we waited for the
+	// lock, in which case our refresh token may no longer be valid.
+	entry, expiry, fresh, err = loadFreshToken(cacheDir, issuer, clientID)
+	if err != nil {
+		return Entry{}, time.Time{}, microerror.Mask(err)
+	} else if fresh {
+		return entry, expiry, nil
+	}
+
+	var auther *oidc.Authenticator
+	{
+		oidcConfig := oidc.Config{
+			Issuer:   issuer,
+			ClientID: clientID,
+		}
+
+		auther, err = oidc.New(ctx, oidcConfig)
+		if err != nil {
+			return Entry{}, time.Time{}, microerror.Mask(err)
+		}
+	}
+
+	idToken, rToken, err := auther.RenewToken(ctx, entry.RefreshToken)
+	if err != nil {
+		return Entry{}, time.Time{}, microerror.Mask(err)
+	}
+
+	entry.IDToken = idToken
+	if len(rToken) > 0 {
+		// Issuers that do not rotate refresh tokens return an empty one.
+		entry.RefreshToken = rToken
+	}
+
+	err = Persist(cacheDir, issuer, clientID, entry)
+	if err != nil {
+		return Entry{}, time.Time{}, microerror.Mask(err)
+	}
+
+	expiry, err = oidc.GetIDTokenExpiry(entry.IDToken)
+	if err != nil {
+		return Entry{}, time.Time{}, microerror.Mask(err)
+	}
+
+	return entry, expiry, nil
+}
+
+// loadFreshToken reads the cached tokens for the given issuer and client ID
+// and returns whether the ID token is valid beyond the expiry threshold.
+func loadFreshToken(cacheDir, issuer, clientID string) (Entry, time.Time, bool, error) {
+	entry, err := Load(cacheDir, issuer, clientID)
+	if err != nil {
+		return Entry{}, time.Time{}, false, microerror.Mask(err)
+	}
+
+	expiry, err := oidc.GetIDTokenExpiry(entry.IDToken)
+	if err == nil && time.Now().Add(expiryThreshold).Before(expiry) {
+		return entry, expiry, true, nil
+	}
+
+	return entry, time.Time{}, false, nil
+}
@@ -0,0 +1,271 @@
+package tokencache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	testoidc "github.com/giantswarm/kubectl-gs/v5/test/oidc"
+)
+
+func Test_PersistLoadDelete(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	_, err := Load(cacheDir, "https://dex.example.com", "client")
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	entry := Entry{IDToken: "id-token", RefreshToken: "refresh-token"}
+	err = Persist(cacheDir, "https://dex.example.com", "client", entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(cacheDir, "https://dex.example.com", "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded != entry {
+		t.Fatalf("expected %v, got %v", entry, loaded)
+	}
+
+	_, err = Load(cacheDir, "https://dex.example.com", "other-client")
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error for other client, got %v", err)
+	}
+
+	err = Delete(cacheDir, "https://dex.example.com", "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Delete(cacheDir, "https://dex.example.com", "client")
+	if err != nil {
+		t.Fatalf("expected deleting a missing entry to succeed, got %v", err)
+	}
+
+	_, err = Load(cacheDir, "https://dex.example.com", "client")
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error after delete, got %v", err)
+	}
+}
+
+func Test_PersistLeavesNoTemporaryFiles(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	for _, idToken := range []string{"id-token", "renewed-id-token"} {
+		err := Persist(cacheDir, "https://dex.example.com", "client", Entry{IDToken: idToken, RefreshToken: "refresh-token"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := os.ReadDir(filepath.Join(cacheDir, cacheSubDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name() != filepath.Base(Path(cacheDir, "https://dex.example.com", "client")) {
+		t.Fatalf("expected only the cache file, got %v", files)
+	}
+
+	info, err := files[0].Info()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != cacheFileMode {
+		t.Fatalf("expected file mode %o, got %o", cacheFileMode, info.Mode().Perm())
+	}
+
+	loaded, err := Load(cacheDir, "https://dex.example.com", "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.IDToken != "renewed-id-token" {
+		t.Fatalf("expected the last written entry, got %v", loaded)
+	}
+}
+
+func Test_lock(t *testing.T) {
+	path := Path(t.TempDir(), "https://dex.example.com", "client")
+
+	unlock, err := lock(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*lockRetryInterval)
+	defer cancel()
+	_, err = lock(ctx, path)
+	if err == nil {
+		t.Fatal("expected the lock to be held, got none")
+	}
+
+	unlock()
+	unlock, err = lock(context.Background(), path)
+	if err != nil {
+		t.Fatalf("expected the released lock to be acquired, got %v", err)
+	}
+	unlock()
+
+	// A lock left behind by a killed process is taken over.
+	err = os.WriteFile(path+lockFileSuffix, nil, cacheFileMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := time.Now().Add(-2 * lockStaleAge)
+	err = os.Chtimes(path+lockFileSuffix, stale, stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock, err = lock(context.Background(), path)
+	if err != nil {
+		t.Fatalf("expected the stale lock to be taken over, got %v", err)
+	}
+	unlock()
+}
+
+func Test_GetFreshToken(t *testing.T) {
+	testCases := []struct {
+		name         string
+		idTokenExp   time.Time
+		expectRenew  bool
+		serverConfig testoidc.MockOidcServerConfig
+		expectError  bool
+	}{
+		{
+			name:        "case 0: valid token is returned from the cache",
+			idTokenExp:  time.Now().Add(time.Hour),
+			expectRenew: false,
+		},
+		{
+			name:        "case 1: expired token gets renewed",
+			idTokenExp:  time.Now().Add(-time.Hour),
+			expectRenew: true,
+		},
+		{
+			name:        "case 2: token about to expire gets renewed",
+			idTokenExp:  time.Now().Add(10 * time.Second),
+			expectRenew: true,
+		},
+		{
+			name:       "case 3: failed renewal",
+			idTokenExp: time.Now().Add(-time.Hour),
+			// The oauth2 client retries once with a different auth style.
+			serverConfig: testoidc.MockOidcServerConfig{TokenFatalFailures: 2},
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.serverConfig.ClientID = "client"
+			s := testoidc.NewServer(tc.serverConfig)
+			err := s.Start(t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Stop()
+
+			cacheDir := t.TempDir()
+
+			idToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": tc.idTokenExp.Unix()}).SignedString([]byte("secret"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = Persist(cacheDir, s.Issuer(), "client", Entry{IDToken: idToken, RefreshToken: "refresh-token"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entry, expiry, err := GetFreshToken(context.Background(), cacheDir, s.Issuer(), "client")
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if renewed := entry.IDToken != idToken; renewed != tc.expectRenew {
+				t.Fatalf("expected renewal to be %t, got %t", tc.expectRenew, renewed)
+			}
+			if entry.RefreshToken != "refresh-token" {
+				t.Fatalf("expected refresh token to be kept, got %q", entry.RefreshToken)
+			}
+			if !expiry.After(time.Now()) {
+				t.Fatalf("expected expiry in the future, got %s", expiry)
+			}
+
+			cached, err := Load(cacheDir, s.Issuer(), "client")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cached != entry {
+				t.Fatalf("expected cache to contain %v, got %v", entry, cached)
+			}
+		})
+	}
+}
+
+func Test_GetFreshToken_RenewedWhileWaiting(t *testing.T) {
+	// Renewing would fail, so the token must come from the other process.
+	s := testoidc.NewServer(testoidc.MockOidcServerConfig{ClientID: "client", TokenFatalFailures: 2})
+	err := s.Start(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+
+	cacheDir := t.TempDir()
+
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	renewed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Persist(cacheDir, s.Issuer(), "client", Entry{IDToken: expired, RefreshToken: "refresh-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process holds the lock while renewing the tokens.
+	unlock, err := lock(context.Background(), Path(cacheDir, s.Issuer(), "client"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type result struct {
+		entry Entry
+		err   error
+	}
+	done := make(chan result)
+	go func() {
+		entry, _, err := GetFreshToken(context.Background(), cacheDir, s.Issuer(), "client")
+		done <- result{entry: entry, err: err}
+	}()
+
+	time.Sleep(2 * lockRetryInterval)
+	err = Persist(cacheDir, s.Issuer(), "client", Entry{IDToken: renewed, RefreshToken: "rotated-refresh-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+
+	r := <-done
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if r.entry.IDToken != renewed || r.entry.RefreshToken != "rotated-refresh-token" {
+		t.Fatalf("expected the tokens renewed by the other process, got %v", r.entry)
+	}
+}
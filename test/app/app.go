@@ -0,0 +1,85 @@
+package app
+
+import (
+	"testing"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
+
+	"github.com/giantswarm/kubectl-gs/v5/pkg/data/domain/app"
+	"github.com/giantswarm/kubectl-gs/v5/pkg/scheme"
+)
+
+// NewService returns an app service backed by a fake client holding the
+// given objects.
+func NewService(t *testing.T, object ...runtime.Object) *app.Service {
+	clientScheme, err := scheme.NewScheme()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", microerror.Pretty(err, true))
+	}
+
+	appService, err := app.New(app.Config{
+		Client: fake.NewClientBuilder().WithScheme(clientScheme).WithRuntimeObjects(object...).Build(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", microerror.Pretty(err, true))
+	}
+
+	return appService
+}
+
+// NewClusterApp returns an app of the given workload cluster, deployed in
+// version 1.0.0. Without a cluster, the app has no cluster label.
+func NewClusterApp(name, namespace, cluster string) *applicationv1alpha1.App {
+	a := &applicationv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{},
+		},
+		Spec: applicationv1alpha1.AppSpec{
+			Version: "1.0.0",
+		},
+		Status: applicationv1alpha1.AppStatus{
+			Version: "1.0.0",
+			Release: applicationv1alpha1.AppStatusRelease{
+				Status: "deployed",
+			},
+		},
+	}
+	if cluster != "" {
+		a.Labels[label.Cluster] = cluster
+	}
+
+	return a
+}
+
+// WithLabel sets a label of the app.
+func WithLabel(a *applicationv1alpha1.App, key, value string) *applicationv1alpha1.App {
+	a.Labels[key] = value
+	return a
+}
+
+// WithInCluster makes the app deployed to the cluster it is defined in.
+func WithInCluster(a *applicationv1alpha1.App) *applicationv1alpha1.App {
+	a.Spec.KubeConfig.InCluster = true
+	return a
+}
+
+// WithVersion sets the desired and the deployed version of the app.
+func WithVersion(a *applicationv1alpha1.App, version string) *applicationv1alpha1.App {
+	a.Spec.Version = version
+	a.Status.Version = version
+	return a
+}
+
+// WithReleaseStatus sets the status of the Helm release of the app.
+func WithReleaseStatus(a *applicationv1alpha1.App, status, reason string) *applicationv1alpha1.App {
+	a.Status.Release.Status = status
+	a.Status.Release.Reason = reason
+	return a
+}
@@ -3,6 +3,7 @@ package kubeclient
 import (
 	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
 	"github.com/giantswarm/k8sclient/v8/pkg/k8scrdclient"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/authorization/v1"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -27,6 +28,8 @@ type Interface interface {
 	AddSubjectAccessResolver(accessResolver authAccessResolver)
 	AddSubjectAccess(accessAllowed bool)
 	AddSubjectResourceRules(namespace string, rules []v1.ResourceRule)
+	AddIncompleteSubjectResourceRules(namespace string)
+	AddSelfSubjectReview(userInfo authenticationv1.UserInfo)
 }
 
 type authAccessResolver func(action testing.Action) bool
@@ -97,6 +100,14 @@ func (f *fakeK8sClient) AddSubjectResourceRules(namespace string, rules []v1.Res
 	f.k8sClient.PrependReactor("create", "selfsubjectrulesreviews", newSelfSubjectRulesReviewReaction(namespace, rules))
 }
 
+func (f *fakeK8sClient) AddIncompleteSubjectResourceRules(namespace string) {
+	f.k8sClient.PrependReactor("create", "selfsubjectrulesreviews", newIncompleteSelfSubjectRulesReviewReaction(namespace))
+}
+
+func (f *fakeK8sClient) AddSelfSubjectReview(userInfo authenticationv1.UserInfo) {
+	f.k8sClient.PrependReactor("create", "selfsubjectreviews", newSelfSubjectReviewReaction(userInfo))
+}
+
 func newSelfSubjectAccessReviewReaction(accessResolver authAccessResolver) testing.ReactionFunc {
 	return func(action testing.Action) (handled bool, ret runtime.Object, err error) {
 		selfSubjectAccessReview := &v1.SelfSubjectAccessReview{
@@ -109,8 +120,14 @@ func newSelfSubjectAccessReviewReaction(accessResolver authAccessResolver) testi
 	}
 }
 
+// newSelfSubjectRulesReviewReaction handles the reviews of the namespace
+// only, so rules can be added for several namespaces.
 func newSelfSubjectRulesReviewReaction(namespace string, resourceRules []v1.ResourceRule) testing.ReactionFunc {
 	return func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+		if rulesReviewNamespace(action) != namespace {
+			return false, nil, nil
+		}
+
 		selfSubjectResourceRules := &v1.SelfSubjectRulesReview{
 			Spec: v1.SelfSubjectRulesReviewSpec{
 				Namespace: namespace,
@@ -122,3 +139,42 @@ func newSelfSubjectRulesReviewReaction(namespace string, resourceRules []v1.Reso
 		return true, selfSubjectResourceRules, nil
 	}
 }
+
+func newIncompleteSelfSubjectRulesReviewReaction(namespace string) testing.ReactionFunc {
+	return func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+		if rulesReviewNamespace(action) != namespace {
+			return false, nil, nil
+		}
+
+		selfSubjectResourceRules := &v1.SelfSubjectRulesReview{
+			Spec: v1.SelfSubjectRulesReviewSpec{
+				Namespace: namespace,
+			},
+			Status: v1.SubjectRulesReviewStatus{
+				Incomplete:      true,
+				EvaluationError: "rules cannot be listed",
+			},
+		}
+		return true, selfSubjectResourceRules, nil
+	}
+}
+
+func rulesReviewNamespace(action testing.Action) string {
+	review, ok := action.(testing.CreateAction).GetObject().(*v1.SelfSubjectRulesReview)
+	if !ok {
+		return ""
+	}
+
+	return review.Spec.Namespace
+}
+
+func newSelfSubjectReviewReaction(userInfo authenticationv1.UserInfo) testing.ReactionFunc {
+	return func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+		selfSubjectReview := &authenticationv1.SelfSubjectReview{
+			Status: authenticationv1.SelfSubjectReviewStatus{
+				UserInfo: userInfo,
+			},
+		}
+		return true, selfSubjectReview, nil
+	}
+}
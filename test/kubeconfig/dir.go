@@ -0,0 +1,103 @@
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+var (
+	// ValidExpiry is the expiry of the credentials of the valid and
+	// renewable contexts written by WriteTestDir.
+	ValidExpiry = time.Date(2100, 1, 2, 15, 4, 5, 0, time.UTC)
+	// ExpiredExpiry is the expiry of the credentials of the expired
+	// contexts written by WriteTestDir.
+	ExpiredExpiry = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+)
+
+// WriteTestDir writes a kubeconfig directory like the one written with
+// 'kubectl gs login --kubeconfig-dir', with a file of each status:
+//
+//   - gs-valid.yaml, gs-renewable.yaml, gs-expired.yaml and gs-token.yaml,
+//     each holding the context the file is named after.
+//   - custom.yaml, holding the expired context gs-custom, which is not
+//     named after its context.
+//   - invalid.yaml, which has no current context.
+//   - README, which is not a file of a kubeconfig directory.
+func WriteTestDir(t *testing.T, dir string) {
+	valid := CreateTestIDToken(t, ValidExpiry)
+	expired := CreateTestIDToken(t, ExpiredExpiry)
+
+	configs := map[string]*clientcmdapi.Config{
+		"gs-valid.yaml":     CreateDirTestConfig("gs-valid", &clientcmdapi.AuthInfo{AuthProvider: CreateTestAuthProvider(valid, "refresh-token")}),
+		"gs-renewable.yaml": CreateDirTestConfig("gs-renewable", &clientcmdapi.AuthInfo{AuthProvider: CreateTestAuthProvider(expired, "refresh-token")}),
+		"gs-expired.yaml":   CreateDirTestConfig("gs-expired", &clientcmdapi.AuthInfo{AuthProvider: CreateTestAuthProvider(expired, "")}),
+		"gs-token.yaml":     CreateDirTestConfig("gs-token", &clientcmdapi.AuthInfo{Token: "token"}),
+		"custom.yaml":       CreateDirTestConfig("gs-custom", &clientcmdapi.AuthInfo{AuthProvider: CreateTestAuthProvider(expired, "")}),
+	}
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	for name, config := range configs {
+		err = clientcmd.WriteToFile(*config, filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("apiVersion: v1\nkind: Config\n"), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	err = os.WriteFile(filepath.Join(dir, "README"), []byte("Contexts\n"), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+}
+
+// CreateDirTestConfig creates a kubeconfig holding a single context, like
+// the files in a kubeconfig directory.
+func CreateDirTestConfig(contextName string, authInfo *clientcmdapi.AuthInfo) *clientcmdapi.Config {
+	config := clientcmdapi.NewConfig()
+	config.CurrentContext = contextName
+	config.Clusters[contextName] = &clientcmdapi.Cluster{
+		Server: "https://api." + contextName + ".example.com",
+	}
+	config.AuthInfos[contextName+"-user"] = authInfo
+	config.Contexts[contextName] = &clientcmdapi.Context{
+		Cluster:  contextName,
+		AuthInfo: contextName + "-user",
+	}
+
+	return config
+}
+
+func CreateTestAuthProvider(idToken, refreshToken string) *clientcmdapi.AuthProviderConfig {
+	return &clientcmdapi.AuthProviderConfig{
+		Name: "oidc",
+		Config: map[string]string{
+			"client-id":      "dex-k8s-authenticator",
+			"id-token":       idToken,
+			"idp-issuer-url": "https://dex.example.com",
+			"refresh-token":  refreshToken,
+		},
+	}
+}
+
+// CreateTestIDToken creates an unverifiable ID token, which only has an
+// expiry.
+func CreateTestIDToken(t *testing.T, expiry time.Time) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": expiry.Unix()}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	return token
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,7 +19,12 @@ import (
 )
 
 type MockOidcServerConfig struct {
-	ClientID                 string
+	ClientID string
+	// ClientSecret, if set, is required with the client credentials grant.
+	ClientSecret string
+	// SubjectToken, if set, is the only token accepted with the token
+	// exchange grant.
+	SubjectToken             string
 	InstallationCodename     string
 	TokenRecoverableFailures int
 	TokenFatalFailures       int
@@ -26,16 +32,23 @@ type MockOidcServerConfig struct {
 
 type MockOidcServer struct {
 	clientID                 string
+	clientSecret             string
+	subjectToken             string
 	installationCodename     string
 	tokenRecoverableFailures int
 	tokenFatalFailures       int
 	server                   *httptest.Server
 	issuerURL                string
+
+	revokedTokensMutex sync.Mutex
+	revokedTokens      []string
 }
 
 func NewServer(config MockOidcServerConfig) *MockOidcServer {
 	return &MockOidcServer{
 		clientID:                 config.ClientID,
+		clientSecret:             config.ClientSecret,
+		subjectToken:             config.SubjectToken,
 		installationCodename:     config.InstallationCodename,
 		tokenRecoverableFailures: config.TokenRecoverableFailures,
 		tokenFatalFailures:       config.TokenFatalFailures,
@@ -90,6 +103,43 @@ func (s *MockOidcServer) Start(t *testing.T) error {
 				}
 				return
 			}
+			if grantType == oidc.ClientCredentialsGrantType && s.clientSecret != "" {
+				_, secret, ok := r.BasicAuth()
+				if !ok {
+					secret = r.Form.Get("client_secret")
+				}
+				if secret != s.clientSecret {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusUnauthorized)
+					_, err := w.Write([]byte(`{"error":"invalid_client"}`))
+					if err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+			}
+			if grantType == oidc.TokenExchangeGrantType {
+				subjectToken := r.Form.Get(oidc.TokenExchangeKeySubjectToken)
+				if subjectToken == "" || (s.subjectToken != "" && subjectToken != s.subjectToken) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusUnauthorized)
+					_, err := w.Write([]byte(`{"error":"access_denied"}`))
+					if err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				body, err := getTokenExchangeResponseData(s.clientID, s.issuerURL, key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, err = w.Write(body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
 			token, err := generateAndGetToken(s.clientID, s.issuerURL, key)
 			if err != nil {
 				t.Fatal(err)
@@ -99,6 +149,12 @@ func (s *MockOidcServer) Start(t *testing.T) error {
 			if err != nil {
 				t.Fatal(err)
 			}
+		} else if r.URL.Path == "/token/revoke" {
+			_ = r.ParseForm()
+			s.revokedTokensMutex.Lock()
+			s.revokedTokens = append(s.revokedTokens, r.Form.Get("token"))
+			s.revokedTokensMutex.Unlock()
+			w.WriteHeader(http.StatusOK)
 		} else if r.URL.Path == "/keys" {
 			webKey, err := getJSONWebKey(key)
 			if err != nil {
@@ -156,6 +212,14 @@ func (s *MockOidcServer) Issuer() string {
 	return s.issuerURL
 }
 
+// RevokedTokens returns the tokens sent to the revocation endpoint.
+func (s *MockOidcServer) RevokedTokens() []string {
+	s.revokedTokensMutex.Lock()
+	defer s.revokedTokensMutex.Unlock()
+
+	return append([]string(nil), s.revokedTokens...)
+}
+
 func getDeviceCodeResponseData(issuer string) ([]byte, error) {
 	data := oidc.DeviceCodeResponseData{
 		DeviceCode:              "test-device-code",
@@ -183,6 +247,20 @@ func getDeviceTokenResponseData(clientID, issuer string, key *rsa.PrivateKey) ([
 	return json.Marshal(data)
 }
 
+func getTokenExchangeResponseData(clientID, issuer string, key *rsa.PrivateKey) ([]byte, error) {
+	token, err := getRawToken(clientID, issuer, key)
+	if err != nil {
+		return nil, err
+	}
+	data := oidc.TokenExchangeResponseData{
+		AccessToken:     token,
+		IssuedTokenType: oidc.TokenTypeIDToken,
+		TokenType:       "bearer",
+		ExpiresIn:       60,
+	}
+	return json.Marshal(data)
+}
+
 func getRawToken(clientID, issuer string, key *rsa.PrivateKey) (string, error) {
 	token := jwt.New(jwt.SigningMethodRS256)
 	claims := make(jwt.MapClaims)
@@ -23,6 +23,7 @@ func GetIssuerData() string {
 		"token_endpoint": "ISSUER/token",
 		"jwks_uri": "ISSUER/keys",
 		"userinfo_endpoint": "ISSUER/userinfo",
+		"revocation_endpoint": "ISSUER/token/revoke",
 		"response_types_supported": [
 		  "code"
 		],